@@ -8,8 +8,8 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
-// DRAction which will be either a Failover or Relocate action
-// +kubebuilder:validation:Enum=Failover;Relocate
+// DRAction which will be either a Failover, Relocate or TestFailover action
+// +kubebuilder:validation:Enum=Failover;Relocate;TestFailover
 type DRAction string
 
 // These are the valid values for DRAction
@@ -20,6 +20,10 @@ const (
 	// Relocate, restore PVs to the designated TargetCluster.  PreferredCluster will change
 	// to be the TargetCluster.
 	ActionRelocate = DRAction("Relocate")
+
+	// TestFailover, restore a copy of the PVs and kube objects into isolated namespaces on the
+	// FailoverCluster, without disrupting the application or its replication
+	ActionTestFailover = DRAction("TestFailover")
 )
 
 // DRState for keeping track of the DR placement
@@ -61,6 +65,14 @@ const (
 	// Relocated, state recorded in
 	Relocated = DRState("Relocated")
 
+	// TestingFailover, state recorded in the DRPC status when a test failover
+	// is initiated but the restored copy is not yet ready
+	TestingFailover = DRState("TestingFailover")
+
+	// TestedFailover, state recorded in the DRPC status when the copy of the
+	// application restored by a test failover is ready
+	TestedFailover = DRState("TestedFailover")
+
 	Deleting = DRState("Deleting")
 )
 
@@ -76,6 +88,11 @@ const (
 	// Protected condition provides the latest available observation regarding the protection status of the workload,
 	// on the cluster it is expected to be available on.
 	ConditionProtected = "Protected"
+
+	// SplitBrain condition is reported while the VolumeReplicationGroup of a cluster reports volumes that the
+	// storage failed to demote, and the VolumeReplicationGroup of another cluster is primary. Volumes of the
+	// workload are then primary on both clusters. Actions are paused until the split-brain is resolved.
+	ConditionSplitBrain = "SplitBrain"
 )
 
 const (
@@ -93,6 +110,46 @@ const (
 	ReasonProtected            = "Protected"
 )
 
+const (
+	ReasonSplitBrainDetected = "SplitBrainDetected"
+)
+
+const (
+	// ReasonTestFailoverUnsupported is the Available condition reason while a TestFailover action is refused, as
+	// the workload has PVCs protected by VolumeReplication. A copy of their volumes can be made only by promoting
+	// the replicated volumes, which would stop their replication.
+	ReasonTestFailoverUnsupported = "TestFailoverUnsupported"
+)
+
+// FinalSyncTimeoutAction is the action taken when the final sync of a relocation does not complete in time
+// +kubebuilder:validation:Enum=Abort;Failover
+type FinalSyncTimeoutAction string
+
+// These are the valid values for FinalSyncTimeoutAction
+const (
+	// Abort the relocation, and restore the workload on the cluster it is relocated from
+	FinalSyncTimeoutActionAbort = FinalSyncTimeoutAction("Abort")
+
+	// Failover, proceed with the relocation as a failover, acknowledging the loss of the data of the PVCs whose
+	// final sync did not complete
+	FinalSyncTimeoutActionFailover = FinalSyncTimeoutAction("Failover")
+)
+
+// FinalSyncResult is the outcome of the final sync of a relocation
+type FinalSyncResult string
+
+// These are the valid values for FinalSyncResult
+const (
+	// Completed, the final sync of all PVCs completed
+	FinalSyncResultCompleted = FinalSyncResult("Completed")
+
+	// Aborted, the final sync timed out and the relocation was aborted
+	FinalSyncResultAborted = FinalSyncResult("Aborted")
+
+	// FailedOver, the final sync timed out and the relocation proceeded as a failover
+	FinalSyncResultFailedOver = FinalSyncResult("FailedOver")
+)
+
 type ProgressionStatus string
 
 const (
@@ -110,6 +167,7 @@ const (
 	ProgressionClearingPlacement                   = ProgressionStatus("ClearingPlacement")
 	ProgressionRunningFinalSync                    = ProgressionStatus("RunningFinalSync")
 	ProgressionFinalSyncComplete                   = ProgressionStatus("FinalSyncComplete")
+	ProgressionFinalSyncTimedOut                   = ProgressionStatus("FinalSyncTimedOut")
 	ProgressionEnsuringVolumesAreSecondary         = ProgressionStatus("EnsuringVolumesAreSecondary")
 	ProgressionWaitingForResourceRestore           = ProgressionStatus("WaitingForResourceRestore")
 	ProgressionUpdatedPlacement                    = ProgressionStatus("UpdatedPlacement")
@@ -118,6 +176,8 @@ const (
 	ProgressionDeleting                            = ProgressionStatus("Deleting")
 	ProgressionDeleted                             = ProgressionStatus("Deleted")
 	ProgressionActionPaused                        = ProgressionStatus("Paused")
+	ProgressionRestoringTestFailover               = ProgressionStatus("RestoringTestFailover")
+	ProgressionCleaningUpTestFailover              = ProgressionStatus("CleaningUpTestFailover")
 )
 
 // DRPlacementControlSpec defines the desired state of DRPlacementControl
@@ -154,11 +214,56 @@ type DRPlacementControlSpec struct {
 	// +kubebuilder:validation:XValidation:rule="self == oldSelf", message="pvcSelector is immutable"
 	PVCSelector metav1.LabelSelector `json:"pvcSelector"`
 
-	// Action is either Failover or Relocate operation
+	// PVCFilter further narrows down the PVCs identified by the PVCSelector, by StorageClass, access mode, size
+	// and annotations. A recipe volumes filter overrides it. It will be passed in to the VRG when it is created
+	// +optional
+	PVCFilter *PVCFilter `json:"pvcFilter,omitempty"`
+
+	// Action is either Failover, Relocate or TestFailover operation
 	Action DRAction `json:"action,omitempty"`
 
+	// TestFailover configures the DR drill run on the FailoverCluster when Action is TestFailover
+	// +optional
+	TestFailover *TestFailoverSpec `json:"testFailover,omitempty"`
+
 	// +optional
 	KubeObjectProtection *KubeObjectProtectionSpec `json:"kubeObjectProtection,omitempty"`
+
+	// ResyncPolicy configures the remediation of degraded VolumeReplications by the VRGs
+	// +optional
+	ResyncPolicy *ResyncPolicy `json:"resyncPolicy,omitempty"`
+
+	// VMProtection enables protection of KubeVirt virtual machines by the VRGs
+	// +optional
+	VMProtection *VMProtectionSpec `json:"vmProtection,omitempty"`
+
+	// VolSyncMaxConcurrentSyncs overrides the maxConcurrentSyncs of the VolSync profile of the DRPolicy, to bound
+	// the network use of the replication, which has no bandwidth limit. It will be passed in to the VRG when it
+	// is created
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	VolSyncMaxConcurrentSyncs *int32 `json:"volSyncMaxConcurrentSyncs,omitempty"`
+
+	// FailoverRecoveryPointTime selects, on failover, the latest recovery point of each PVC protected by VolSync at
+	// or before this time to restore from, instead of the latest. The VolSync profile of the DRPolicy must
+	// retain more than one snapshot for earlier recovery points to exist.
+	// +optional
+	FailoverRecoveryPointTime *metav1.Time `json:"failoverRecoveryPointTime,omitempty"`
+
+	// FinalSyncTimeout is how long a relocation waits for the final sync of the PVCs protected by VolSync, from
+	// the time it starts quiescing the workload on the cluster it is relocated from. The relocation waits
+	// indefinitely if unset.
+	// +optional
+	FinalSyncTimeout *metav1.Duration `json:"finalSyncTimeout,omitempty"`
+
+	// FinalSyncTimeoutAction is taken once the final sync times out. Abort cancels the final sync, and restores
+	// the workload on the cluster it is relocated from, from the PVCs kept there. Failover proceeds with the
+	// relocation as a failover, and acknowledges the loss of the data of the PVCs whose final sync did not
+	// complete. If unset, the relocation waits indefinitely in progression FinalSyncTimedOut, with the timeout
+	// reported once in a warning event and in the Available condition, until this is set or the final sync
+	// completes.
+	// +optional
+	FinalSyncTimeoutAction FinalSyncTimeoutAction `json:"finalSyncTimeoutAction,omitempty"`
 }
 
 // PlacementDecision defines the decision made by controller
@@ -203,6 +308,51 @@ type VRGConditions struct {
 	Conditions []metav1.Condition `json:"conditions,omitempty"`
 }
 
+// FinalSyncPVCStatus is the final sync status of a PVC protected by VolSync
+type FinalSyncPVCStatus struct {
+	// Name of the PVC
+	Name string `json:"name"`
+
+	// Namespace of the PVC
+	Namespace string `json:"namespace"`
+
+	// Complete is true once the final sync of the PVC has completed
+	//+optional
+	Complete bool `json:"complete,omitempty"`
+
+	// EstimatedCompletionTime of the final sync of the PVC, while it is in progress
+	//+optional
+	EstimatedCompletionTime *metav1.Time `json:"estimatedCompletionTime,omitempty"`
+}
+
+// FinalSyncStatus is the status of the final sync of the PVCs protected by VolSync during a relocation
+type FinalSyncStatus struct {
+	// StartTime is when the relocation started quiescing the workload for the final sync
+	//+optional
+	StartTime *metav1.Time `json:"startTime,omitempty"`
+
+	// Deadline is when the final sync times out, if spec.finalSyncTimeout is set
+	//+optional
+	Deadline *metav1.Time `json:"deadline,omitempty"`
+
+	// TimedOut is true once the final sync did not complete by its deadline
+	//+optional
+	TimedOut bool `json:"timedOut,omitempty"`
+
+	// Result of the final sync, unset while it is in progress
+	//+optional
+	Result FinalSyncResult `json:"result,omitempty"`
+
+	// ObservedGeneration is the generation of the DRPC that the result was reached for. An aborted relocation
+	// is retried once the spec of the DRPC is updated.
+	//+optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+
+	// PVCs is the final sync status of each PVC
+	//+optional
+	PVCs []FinalSyncPVCStatus `json:"pvcs,omitempty"`
+}
+
 // DRPlacementControlStatus defines the observed state of DRPlacementControl
 type DRPlacementControlStatus struct {
 	Phase              DRState            `json:"phase,omitempty"`
@@ -231,9 +381,27 @@ type DRPlacementControlStatus struct {
 	//+optional
 	LastGroupSyncBytes *int64 `json:"lastGroupSyncBytes,omitempty"`
 
+	// groupSyncsInProgress is the number of PVCs whose synchronization is in progress, such as those of a final
+	// sync during relocation
+	//+optional
+	GroupSyncsInProgress int `json:"groupSyncsInProgress,omitempty"`
+
+	// estimatedGroupSyncCompletionTime is the latest estimated completion time of the synchronizations
+	// in progress of all PVCs
+	//+optional
+	EstimatedGroupSyncCompletionTime *metav1.Time `json:"estimatedGroupSyncCompletionTime,omitempty"`
+
 	// lastKubeObjectProtectionTime is the time of the most recent successful kube object protection
 	//+optional
 	LastKubeObjectProtectionTime *metav1.Time `json:"lastKubeObjectProtectionTime,omitempty"`
+
+	// finalSync is the status of the final sync of the PVCs protected by VolSync during the most recent relocation
+	//+optional
+	FinalSync *FinalSyncStatus `json:"finalSync,omitempty"`
+
+	// volSyncKeyTime is when the VolSync pre-shared key that the rsync TLS movers connect with was generated
+	//+optional
+	VolSyncKeyTime *metav1.Time `json:"volSyncKeyTime,omitempty"`
 }
 
 // +kubebuilder:object:root=true
@@ -247,6 +415,8 @@ type DRPlacementControlStatus struct {
 // +kubebuilder:printcolumn:JSONPath=".status.actionStartTime",name=start time,type=string,priority=2
 // +kubebuilder:printcolumn:JSONPath=".status.actionDuration",name=duration,type=string,priority=2
 // +kubebuilder:printcolumn:JSONPath=".status.conditions[1].status",name=peer ready,type=string,priority=2
+// +kubebuilder:printcolumn:JSONPath=".status.estimatedGroupSyncCompletionTime",name=sync eta,type=string,priority=2
+// +kubebuilder:printcolumn:JSONPath=".status.volSyncKeyTime",name=volsync key age,type=date,priority=2
 // +kubebuilder:resource:shortName=drpc
 
 // DRPlacementControl is the Schema for the drplacementcontrols API
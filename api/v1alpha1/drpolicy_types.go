@@ -20,6 +20,19 @@ type DRPolicySpec struct {
 	// +kubebuilder:validation:XValidation:rule="self == oldSelf", message="schedulingInterval is immutable"
 	SchedulingInterval string `json:"schedulingInterval"`
 
+	// Schedule is a cron expression of when to replicate Persistent Volume data, instead of every
+	// schedulingInterval, which remains the interval data is expected to be replicated within. It is a standard
+	// cron expression of five fields, in UTC, which may have ranges, lists and steps. It will be passed in to the
+	// VRG when it is created
+	//+optional
+	// +kubebuilder:validation:Pattern=`^\S+(\s+\S+){4}$`
+	Schedule string `json:"schedule,omitempty"`
+
+	// BlackoutWindows are weekly time windows, in UTC, when Persistent Volume data is not replicated. It will
+	// be passed in to the VRG when it is created
+	//+optional
+	BlackoutWindows []ReplicationWindow `json:"blackoutWindows,omitempty"`
+
 	// Label selector to identify all the VolumeReplicationClasses.
 	// This selector is assumed to be the same for all subscriptions that
 	// need DR protection. It will be passed in to the VRG when it is created
@@ -38,13 +51,54 @@ type DRPolicySpec struct {
 	// +kubebuilder:validation:XValidation:rule="self == oldSelf", message="volumeSnapshotClassSelector is immutable"
 	VolumeSnapshotClassSelector metav1.LabelSelector `json:"volumeSnapshotClassSelector"`
 
-	// List of DRCluster resources that are governed by this policy
+	// Label selector to identify all the VolumeGroupReplicationClasses. When specified, PVCs
+	// that share a StorageClass and a VolumeReplicationClass are replicated as a consistency
+	// group, using a single VolumeGroupReplication resource. It will be passed in to the VRG
+	// when it is created
+	//+optional
+	VolumeGroupReplicationClassSelector *metav1.LabelSelector `json:"volumeGroupReplicationClassSelector,omitempty"`
+
+	// StorageClassMappings lists StorageClasses that are equivalent across the DRPolicy clusters, when the
+	// clusters do not share StorageClass names. PVs and PVCs recovered on a cluster use its StorageClass
+	// from the mapping that contains the StorageClass of the protected PVC. It will be passed in to the
+	// VRG when it is created
+	//+optional
+	StorageClassMappings []StorageClassMapping `json:"storageClassMappings,omitempty"`
+
+	// StaticVolumeEndpointMappings lists endpoints of statically provisioned PVs, such as NFS servers and
+	// node names, that are equivalent across the DRPolicy clusters. Static PVs restored on a cluster use its
+	// endpoint from the mapping that contains the endpoint of the protected PV. It will be passed in to the
+	// VRG when it is created
+	//+optional
+	StaticVolumeEndpointMappings []StaticVolumeEndpointMapping `json:"staticVolumeEndpointMappings,omitempty"`
+
+	// VolSyncProfile tunes the VolSync replication of the PVCs protected by VolSync. It will be passed in to
+	// the VRG when it is created
+	//+optional
+	VolSyncProfile *VolSyncProfile `json:"volSyncProfile,omitempty"`
+
+	// List of DRCluster resources that are governed by this policy. An async policy may list more than 2
+	// clusters, to replicate from the primary to all the others
 	// +kubebuilder:validation:Required
-	// +kubebuilder:validation:XValidation:rule="size(self) == 2", message="drClusters requires a list of 2 clusters"
+	// +kubebuilder:validation:XValidation:rule="size(self) >= 2", message="drClusters requires a list of at least 2 clusters"
 	// +kubebuilder:validation:XValidation:rule="self == oldSelf", message="drClusters is immutable"
 	DRClusters []string `json:"drClusters"`
 }
 
+// StorageClassMapping lists equivalent StorageClasses by the name of the DRCluster they are on
+type StorageClassMapping struct {
+	// StorageClassNames maps a DRCluster name to the name of the StorageClass on that cluster
+	// +kubebuilder:validation:MinProperties=2
+	StorageClassNames map[string]string `json:"storageClassNames"`
+}
+
+// StaticVolumeEndpointMapping lists equivalent static PV endpoints by the name of the DRCluster they are on
+type StaticVolumeEndpointMapping struct {
+	// Endpoints maps a DRCluster name to the endpoint on that cluster
+	// +kubebuilder:validation:MinProperties=2
+	Endpoints map[string]string `json:"endpoints"`
+}
+
 // DRPolicyStatus defines the observed state of DRPolicy
 type DRPolicyStatus struct {
 	Conditions []metav1.Condition `json:"conditions,omitempty"`
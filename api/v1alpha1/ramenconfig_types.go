@@ -21,6 +21,20 @@ const (
 	DRHubType ControllerType = "dr-hub"
 )
 
+// VolSyncSecretPropagation is how the hub propagates the VolSync pre-shared key secret of a DRPlacementControl to
+// its clusters
+// +kubebuilder:validation:Enum=Policy;ManifestWork
+type VolSyncSecretPropagation string
+
+const (
+	// VolSyncSecretPropagationPolicy propagates the secret with an OCM governance policy
+	VolSyncSecretPropagationPolicy VolSyncSecretPropagation = "Policy"
+
+	// VolSyncSecretPropagationManifestWork propagates the secret with a ManifestWork to each cluster, which does not
+	// require the OCM governance policy add-on. The ManifestWork spec embeds the pre-shared key unencrypted.
+	VolSyncSecretPropagationManifestWork VolSyncSecretPropagation = "ManifestWork"
+)
+
 // When naming a S3 bucket, follow the bucket naming rules at:
 // https://docs.aws.amazon.com/AmazonS3/latest/userguide/bucketnamingrules.html
 // - Bucket names must be between 3 and 63 characters long.
@@ -136,8 +150,19 @@ type RamenConfig struct {
 		// from source to destination. Should be Snapshot/Direct
 		// default: Snapshot
 		DestinationCopyMethod string `json:"destinationCopyMethod,omitempty"`
+
+		// keyRotationInterval is how often the hub rotates the VolSync rsync TLS pre-shared key of each
+		// DRPlacementControl. The key is not rotated if it is not set.
+		KeyRotationInterval metav1.Duration `json:"keyRotationInterval,omitempty"`
+
+		// secretPropagation is how the hub propagates the VolSync pre-shared key secret of each
+		// DRPlacementControl to its clusters: Policy, the default, or ManifestWork.
+		SecretPropagation VolSyncSecretPropagation `json:"secretPropagation,omitempty"`
 	} `json:"volSync,omitempty"`
 
+	// VolSyncProfile tunes the VolSync replication of the VRGs whose DRPolicy has no VolSync profile
+	VolSyncProfile *VolSyncProfile `json:"volSyncProfile,omitempty"`
+
 	KubeObjectProtection struct {
 		// Disabled is used to disable KubeObjectProtection usage in Ramen.
 		Disabled bool `json:"disabled,omitempty"`
@@ -151,6 +176,12 @@ type RamenConfig struct {
 		VolsyncSupported bool `json:"volsyncSupported,omitempty"`
 	} `json:"multiNamespace,omitempty"`
 
+	VolumeGroupReplication struct {
+		// Enables replication of PVCs as consistency groups using VolumeGroupReplication resources.
+		// Requires the VolumeGroupReplication CRDs to be installed.
+		FeatureEnabled bool `json:"featureEnabled,omitempty"`
+	} `json:"volumeGroupReplication,omitempty"`
+
 	// Unprotect deleted or deselected PVCs
 	VolumeUnprotectionEnabled bool `json:"volumeUnprotectionEnabled,omitempty"`
 
@@ -7,6 +7,7 @@ import (
 	"time"
 
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
@@ -49,6 +50,15 @@ type VRGAsyncSpec struct {
 	//+optional
 	VolumeSnapshotClassSelector metav1.LabelSelector `json:"volumeSnapshotClassSelector,omitempty"`
 
+	// Label selector to identify the VolumeGroupReplicationClass resources
+	// that are scanned to select an appropriate VolumeGroupReplicationClass
+	// for the VolumeGroupReplication resource. When specified, PVCs that share
+	// a StorageClass and a VolumeReplicationClass are replicated as a
+	// consistency group by a single VolumeGroupReplication resource, instead
+	// of a VolumeReplication resource per PVC.
+	//+optional
+	VolumeGroupReplicationClassSelector *metav1.LabelSelector `json:"volumeGroupReplicationClassSelector,omitempty"`
+
 	// scheduling Interval for replicating Persistent Volume
 	// data to a peer cluster. Interval is typically in the
 	// form <num><m,h,d>. Here <num> is a number, 'm' means
@@ -56,10 +66,148 @@ type VRGAsyncSpec struct {
 	// +kubebuilder:validation:Required
 	// +kubebuilder:validation:Pattern=`^\d+[mhd]$`
 	SchedulingInterval string `json:"schedulingInterval"`
+
+	// Schedule is a cron expression of when to replicate Persistent Volume data, instead of every
+	// schedulingInterval. A VolumeReplicationClass declares the schedule it replicates on with annotation
+	// ramendr.openshift.io/schedule. VolSync is triggered manually on a schedule whose fields have ranges or lists,
+	// which the VolSync API does not accept.
+	//+optional
+	// +kubebuilder:validation:Pattern=`^\S+(\s+\S+){4}$`
+	Schedule string `json:"schedule,omitempty"`
+
+	// BlackoutWindows are weekly time windows when Persistent Volume data is not replicated. A
+	// VolumeReplicationClass declares the windows it does not replicate in with annotation
+	// ramendr.openshift.io/blackout-windows.
+	//+optional
+	BlackoutWindows []ReplicationWindow `json:"blackoutWindows,omitempty"`
+
+	// VolSyncProfile tunes the VolSync ReplicationSources and ReplicationDestinations of the PVCs protected by
+	// VolSync. Defaults to the profile of the RamenConfig, if any.
+	//+optional
+	VolSyncProfile *VolSyncProfile `json:"volSyncProfile,omitempty"`
+
+	// VolSyncMaxConcurrentSyncs overrides the maxConcurrentSyncs of the VolSync profile
+	//+optional
+	// +kubebuilder:validation:Minimum=0
+	VolSyncMaxConcurrentSyncs *int32 `json:"volSyncMaxConcurrentSyncs,omitempty"`
+}
+
+// VolSyncCopyMethod is the method VolSync uses to take a point in time copy of a PVC to sync
+// +kubebuilder:validation:Enum=Snapshot;Clone;Direct
+type VolSyncCopyMethod string
+
+const (
+	VolSyncCopyMethodSnapshot = VolSyncCopyMethod("Snapshot")
+	VolSyncCopyMethodClone    = VolSyncCopyMethod("Clone")
+	VolSyncCopyMethodDirect   = VolSyncCopyMethod("Direct")
+)
+
+// VolSyncMover is the VolSync data mover that replicates the PVCs protected by VolSync
+// +kubebuilder:validation:Enum=RsyncTLS;Restic
+type VolSyncMover string
+
+const (
+	// VolSyncMoverRsyncTLS syncs a ReplicationSource directly to the ReplicationDestination on the peer cluster
+	VolSyncMoverRsyncTLS = VolSyncMover("RsyncTLS")
+
+	// VolSyncMoverRestic pushes a ReplicationSource to a restic repository in an S3 store, and a
+	// ReplicationDestination pulls from it, for clusters without network connectivity to each other
+	VolSyncMoverRestic = VolSyncMover("Restic")
+)
+
+// VolSyncProfile has the settings of the VolSync ReplicationSources and ReplicationDestinations, and their
+// movers, of the PVCs protected by VolSync. The profile must be the same on the clusters a VRG is protected on.
+// Mover resources, node selectors and tolerations are not supported, as VolSync does not offer them.
+// +kubebuilder:validation:XValidation:rule="!has(self.serviceType) || self.serviceType == 'ClusterIP' || has(self.loadBalancerDomain)", message="loadBalancerDomain is required for serviceType LoadBalancer or NodePort"
+type VolSyncProfile struct {
+	// Mover replicates the PVCs. Defaults to RsyncTLS.
+	//+optional
+	Mover VolSyncMover `json:"mover,omitempty"`
+
+	// ResticS3ProfileName is the name of the S3 profile of the restic repositories of the Restic mover. Its
+	// S3 secret has the credentials of the repositories, whose password is the VolSync pre-shared key of the
+	// VRG. Defaults to the first S3 profile of the VRG.
+	//+optional
+	ResticS3ProfileName string `json:"resticS3ProfileName,omitempty"`
+
+	// ServiceType of the ReplicationDestination services. A ClusterIP service is exported to the peer cluster
+	// with a ServiceExport. A LoadBalancer service is reached with its host name in loadBalancerDomain. A NodePort
+	// service is reached likewise, its host name resolving to the nodes of its cluster, on a node port in the
+	// default range derived from the namespace and name of its PVC, or the next free one, which the VRG reports
+	// for the hub to pass it to the primary VRG. Defaults to ClusterIP.
+	// +kubebuilder:validation:Enum=ClusterIP;LoadBalancer;NodePort
+	//+optional
+	ServiceType *corev1.ServiceType `json:"serviceType,omitempty"`
+
+	// LoadBalancerDomain is the DNS domain of the LoadBalancer, or NodePort, ReplicationDestination services. A
+	// service is annotated with host name <service name>.<namespace>.<loadBalancerDomain> for external-dns to
+	// publish, and the ReplicationSource syncs to this host name.
+	//+optional
+	LoadBalancerDomain string `json:"loadBalancerDomain,omitempty"`
+
+	// ServiceAnnotations are added to the ReplicationDestination services
+	//+optional
+	ServiceAnnotations map[string]string `json:"serviceAnnotations,omitempty"`
+
+	// CopyMethod of the ReplicationSources. Snapshot and Clone sync a point in time copy of a PVC, and Direct
+	// syncs the PVC while in use and is also the copy method of the ReplicationDestinations. Defaults to
+	// Snapshot.
+	//+optional
+	CopyMethod VolSyncCopyMethod `json:"copyMethod,omitempty"`
+
+	// MoverSecurityContext is the pod security context of the movers
+	//+optional
+	MoverSecurityContext *corev1.PodSecurityContext `json:"moverSecurityContext,omitempty"`
+
+	// MoverServiceAccount is the service account the movers run as. It must exist in the namespaces of the
+	// protected PVCs.
+	//+optional
+	MoverServiceAccount *string `json:"moverServiceAccount,omitempty"`
+
+	// PrivilegedMovers annotates the namespaces of the protected PVCs for VolSync to run privileged movers,
+	// which preserve file ownership and permissions
+	//+optional
+	PrivilegedMovers bool `json:"privilegedMovers,omitempty"`
+
+	// MaxConcurrentSyncs caps the number of ReplicationSources of a VRG that sync at the same time, including
+	// final syncs. The others due to sync are paused until one completes. Defaults to no cap. It is the only
+	// throttle of the replication's network use, as the VolSync API in use cannot limit the bandwidth of a mover.
+	// +kubebuilder:validation:Minimum=0
+	//+optional
+	MaxConcurrentSyncs int32 `json:"maxConcurrentSyncs,omitempty"`
+
+	// RetainedSnapshots is the number of the latest VolumeSnapshots of a PVC that its ReplicationDestination
+	// retains as recovery points, which a failover can restore from instead of the latest. Defaults to 1, the
+	// latest only.
+	// +kubebuilder:validation:Minimum=0
+	//+optional
+	RetainedSnapshots int32 `json:"retainedSnapshots,omitempty"`
+}
+
+// ReplicationWindow is a weekly time window, in UTC
+type ReplicationWindow struct {
+	// Days of the week the window starts on. Defaults to every day.
+	//+optional
+	// +kubebuilder:validation:items:Enum=Mon;Tue;Wed;Thu;Fri;Sat;Sun
+	Days []string `json:"days,omitempty"`
+
+	// StartTime of the window, as HH:MM
+	// +kubebuilder:validation:Pattern=`^([01]\d|2[0-3]):[0-5]\d$`
+	StartTime string `json:"startTime"`
+
+	// EndTime of the window, as HH:MM. A window whose end time is not after its start time ends on the next day.
+	// +kubebuilder:validation:Pattern=`^([01]\d|2[0-3]):[0-5]\d$`
+	EndTime string `json:"endTime"`
 }
 
 // VRGSyncSpec has the parameters associated with MetroDR
-type VRGSyncSpec struct{}
+type VRGSyncSpec struct {
+	// Label selector to identify the VolumeReplicationClasses through which storage backends report
+	// the mirroring mode of the volumes of their provisioner. A PVC is reported as not DataProtected
+	// if such a VolumeReplicationClass reports a mirroring mode other than synchronous.
+	//+optional
+	ReplicationClassSelector metav1.LabelSelector `json:"replicationClassSelector,omitempty"`
+}
 
 // VolSyncReplicationDestinationSpec defines the configuration for the VolSync
 // protected PVC to be used by the destination cluster (Secondary)
@@ -86,6 +234,46 @@ type VolSyncSpec struct {
 
 	// disabled when set, all the VolSync code is bypassed. Default is 'false'
 	Disabled bool `json:"disabled,omitempty"`
+
+	// RecoveryPointTime selects, on failover, the latest recovery point of each PVC at or before this time to
+	// restore from, instead of the latest VolumeSnapshot of its ReplicationDestination
+	//+optional
+	RecoveryPointTime *metav1.Time `json:"recoveryPointTime,omitempty"`
+
+	// Peers are the clusters the PVCs are replicated to, if more than one. The primary then replicates each PVC
+	// to the ReplicationDestination on every peer, with an rsync TLS ReplicationSource per peer
+	//+optional
+	Peers []string `json:"peers,omitempty"`
+
+	// RDNodePorts are the node ports of the NodePort services of the ReplicationDestinations of the PVCs on the
+	// peer clusters, as reported by their VRGs, which the ReplicationSources of the PVCs sync to
+	//+optional
+	RDNodePorts []VolSyncNodePort `json:"rdNodePorts,omitempty"`
+}
+
+// VolSyncNodePort is the node port of the NodePort service of the ReplicationDestination of a PVC
+type VolSyncNodePort struct {
+	// Cluster of the ReplicationDestination, if reported to a peer
+	//+optional
+	Cluster string `json:"cluster,omitempty"`
+
+	// Namespace of the PVC
+	Namespace string `json:"namespace"`
+
+	// Name of the PVC
+	Name string `json:"name"`
+
+	// NodePort of the service
+	NodePort int32 `json:"nodePort"`
+}
+
+// VolSyncRecoveryPoint is a VolumeSnapshot retained by the ReplicationDestination of a PVC
+type VolSyncRecoveryPoint struct {
+	// SnapshotName is the name of the VolumeSnapshot, in the namespace of the PVC
+	SnapshotName string `json:"snapshotName"`
+
+	// Time the VolumeSnapshot was taken
+	Time metav1.Time `json:"time"`
 }
 
 // VRGAction which will be either a Failover or Relocate
@@ -115,6 +303,13 @@ type KubeObjectProtectionSpec struct {
 	//+optional
 	RecipeRef *RecipeRef `json:"recipeRef,omitempty"`
 
+	// List of Recipes to compose, in order, after the one named by RecipeRef, if any. A later
+	// recipe's groups and hooks replace an earlier recipe's groups and hooks of the same name and
+	// are otherwise appended. A later recipe's volumes and workflows, if specified, replace an
+	// earlier recipe's.
+	//+optional
+	RecipeRefs []RecipeRef `json:"recipeRefs,omitempty"`
+
 	// Recipe parameter definitions
 	//+optional
 	RecipeParameters map[string][]string `json:"recipeParameters,omitempty"`
@@ -136,6 +331,34 @@ type RecipeRef struct {
 
 const KubeObjectProtectionCaptureIntervalDefault = 5 * time.Minute
 
+// PVCFilter narrows down the PVCs selected by a label selector using properties of the claims
+type PVCFilter struct {
+	// StorageClassNames, if specified, selects only PVCs that request one of these StorageClasses
+	//+optional
+	StorageClassNames []string `json:"storageClassNames,omitempty"`
+
+	// ExcludedStorageClassNames excludes PVCs that request one of these StorageClasses
+	//+optional
+	ExcludedStorageClassNames []string `json:"excludedStorageClassNames,omitempty"`
+
+	// AccessModes, if specified, selects only PVCs that request at least one of these access modes
+	//+optional
+	AccessModes []corev1.PersistentVolumeAccessMode `json:"accessModes,omitempty"`
+
+	// MinSize, if specified, excludes PVCs that request less storage
+	//+optional
+	MinSize *resource.Quantity `json:"minSize,omitempty"`
+
+	// MaxSize, if specified, excludes PVCs that request more storage
+	//+optional
+	MaxSize *resource.Quantity `json:"maxSize,omitempty"`
+
+	// AnnotationSelector, if specified, selects only PVCs whose annotations match it, using
+	// label selector semantics
+	//+optional
+	AnnotationSelector *metav1.LabelSelector `json:"annotationSelector,omitempty"`
+}
+
 // VolumeReplicationGroup (VRG) spec declares the desired schedule for data
 // replication and replication state of all PVCs identified via the given
 // PVC label selector. For each such PVC, the VRG will do the following:
@@ -153,6 +376,12 @@ type VolumeReplicationGroupSpec struct {
 	// that needs to be replicated to the peer cluster.
 	PVCSelector metav1.LabelSelector `json:"pvcSelector"`
 
+	// Filter to further narrow down the PVCs identified by the PVCSelector, by StorageClass,
+	// access mode, size and annotations. A recipe volume group filter overrides it. A PVC that
+	// is protected already remains protected if it no longer satisfies the filter.
+	//+optional
+	PVCFilter *PVCFilter `json:"pvcFilter,omitempty"`
+
 	// Desired state of all volumes [primary or secondary] in this replication group;
 	// this value is propagated to children VolumeReplication CRs
 	ReplicationState ReplicationState `json:"replicationState"`
@@ -193,6 +422,185 @@ type VolumeReplicationGroupSpec struct {
 	// You can use a recipe to filter and coordinate the order of the resources that are protected.
 	//+optional
 	ProtectedNamespaces *[]string `json:"protectedNamespaces,omitempty"`
+
+	// TestFailover requests a secondary VRG to restore a copy of the replicated PVCs and kube objects into
+	// isolated namespaces, while it continues to receive replicated data from the primary.
+	// Removing it tears down the restored copy.
+	//+optional
+	TestFailover *TestFailoverSpec `json:"testFailover,omitempty"`
+
+	// StorageClassMapping maps the StorageClass names of protected PVCs on peer clusters to the names of
+	// equivalent StorageClasses on this cluster. It is applied to PVs and PVCs restored on this cluster, and
+	// to PVCs that VolSync replicates to this cluster.
+	//+optional
+	StorageClassMapping map[string]string `json:"storageClassMapping,omitempty"`
+
+	// ResyncPolicy configures the remediation of VolumeReplications that report their volumes as degraded
+	//+optional
+	ResyncPolicy *ResyncPolicy `json:"resyncPolicy,omitempty"`
+
+	// StaticVolumeEndpointMapping maps the endpoints of statically provisioned PVs on peer clusters, such as
+	// NFS servers and node names, to the equivalent endpoints on this cluster. It is applied to static PVs
+	// restored on this cluster.
+	//+optional
+	StaticVolumeEndpointMapping map[string]string `json:"staticVolumeEndpointMapping,omitempty"`
+
+	// VMProtection enables protection of KubeVirt virtual machines in the protected namespaces. The PVCs of
+	// the virtual machines are protected, the virtual machines are stopped before their volumes change
+	// replication state, and their definitions are recovered after the rest of the kube objects.
+	//+optional
+	VMProtection *VMProtectionSpec `json:"vmProtection,omitempty"`
+
+	// ConsistentSync requests an on-demand, application-consistent, sync of the volumes of a primary VRG
+	//+optional
+	ConsistentSync *ConsistentSyncSpec `json:"consistentSync,omitempty"`
+
+	// VolumeSnapshotProtection enables protection of the VolumeSnapshots of protected PVCs, for them to exist on
+	// the peer cluster after a failover or relocation
+	//+optional
+	VolumeSnapshotProtection *VolumeSnapshotProtectionSpec `json:"volumeSnapshotProtection,omitempty"`
+}
+
+// VolumeSnapshotProtectionSpec configures the protection of the VolumeSnapshots of protected PVCs
+type VolumeSnapshotProtectionSpec struct {
+	// Label selector to identify the VolumeSnapshots to protect. All ready VolumeSnapshots of protected PVCs are
+	// protected if it is not specified.
+	//+optional
+	VolumeSnapshotSelector *metav1.LabelSelector `json:"volumeSnapshotSelector,omitempty"`
+
+	// StorageReplicatesSnapshots is set if the storage of the PVCs protected by VolumeReplication replicates their
+	// snapshots, with the same snapshot handles on the peer cluster. Only then are their VolumeSnapshots protected,
+	// as storage mirroring does not replicate snapshots otherwise.
+	//+optional
+	StorageReplicatesSnapshots bool `json:"storageReplicatesSnapshots,omitempty"`
+}
+
+// ProtectedVolumeSnapshot is a VolumeSnapshot protected by a VRG
+type ProtectedVolumeSnapshot struct {
+	// Namespace of the VolumeSnapshot
+	Namespace string `json:"namespace"`
+
+	// Name of the VolumeSnapshot
+	Name string `json:"name"`
+
+	// VolumeSnapshotContentName is the name of the VolumeSnapshotContent archived with the VolumeSnapshot of a
+	// PVC that the storage replicates
+	//+optional
+	VolumeSnapshotContentName string `json:"volumeSnapshotContentName,omitempty"`
+
+	// Archived is the UID and generation of the VolumeSnapshot last uploaded to the S3 stores
+	//+optional
+	Archived string `json:"archived,omitempty"`
+
+	// PVCName is the name of the PVC, restored from the VolumeSnapshot of a PVC that VolSync replicates, whose
+	// data VolSync replicates for the VolumeSnapshot to be restored from it
+	//+optional
+	PVCName string `json:"pvcName,omitempty"`
+}
+
+// ConsistentSyncSpec requests an application-consistent sync: the application is frozen by recipe hooks, each
+// protected volume is synced, and the application is unfrozen
+type ConsistentSyncSpec struct {
+	// RequestID identifies the request. A consistent sync is run once for each new value.
+	// +kubebuilder:validation:MinLength=1
+	RequestID string `json:"requestID"`
+
+	// FreezeHooks are recipe hook operations, each named <hook name>/<operation name>, to run in order to freeze
+	// the application. The inverse operations of those that have one are run in reverse order to unfreeze it.
+	//+optional
+	FreezeHooks []string `json:"freezeHooks,omitempty"`
+
+	// Timeout is the time to wait for the volumes to sync once the application is frozen, after which it is
+	// unfrozen and the request fails. Defaults to 10 minutes. A request fails without freezing the application if
+	// an async VolRep volume, which syncs on its schedule only, has a longer scheduling interval.
+	//+optional
+	Timeout *metav1.Duration `json:"timeout,omitempty"`
+}
+
+// ConsistentSyncPhase is the progress of a consistent sync request
+type ConsistentSyncPhase string
+
+const (
+	ConsistentSyncPhaseFreezing   = ConsistentSyncPhase("Freezing")
+	ConsistentSyncPhaseSyncing    = ConsistentSyncPhase("Syncing")
+	ConsistentSyncPhaseUnfreezing = ConsistentSyncPhase("Unfreezing")
+	ConsistentSyncPhaseCompleted  = ConsistentSyncPhase("Completed")
+	ConsistentSyncPhaseFailed     = ConsistentSyncPhase("Failed")
+)
+
+// ConsistentSyncStatus reports the progress of the most recent consistent sync request
+type ConsistentSyncStatus struct {
+	// RequestID of the request
+	RequestID string `json:"requestID"`
+
+	// Phase of the request
+	Phase ConsistentSyncPhase `json:"phase"`
+
+	// HooksCompleted is the number of freeze or unfreeze hook operations completed in the current phase
+	//+optional
+	HooksCompleted int `json:"hooksCompleted,omitempty"`
+
+	// SyncStartTime is the time the volumes were requested to sync, with the application frozen
+	//+optional
+	SyncStartTime *metav1.Time `json:"syncStartTime,omitempty"`
+
+	// Message describes the error that failed the request
+	//+optional
+	Message string `json:"message,omitempty"`
+}
+
+// VMProtectionSpec configures the protection of KubeVirt virtual machines
+type VMProtectionSpec struct {
+	// Label selector to identify the virtual machines to protect. All virtual machines in the protected
+	// namespaces are protected if it is not specified.
+	//+optional
+	VMSelector *metav1.LabelSelector `json:"vmSelector,omitempty"`
+}
+
+// TestFailoverSpec configures a non-disruptive DR drill. Only VolSync protected PVCs are supported, as a copy of
+// a VolumeReplication protected volume would require its promotion.
+type TestFailoverSpec struct {
+	// NamespaceMapping maps each protected namespace to the namespace its PVCs and kube objects are
+	// restored into during the drill. Target namespaces must not be protected namespaces.
+	// +kubebuilder:validation:MinProperties=1
+	NamespaceMapping map[string]string `json:"namespaceMapping"`
+}
+
+// ResyncRemediation is the remediation of a degraded VolumeReplication
+// +kubebuilder:validation:Enum=None;AutoResync;AlertOnly
+type ResyncRemediation string
+
+const (
+	// ResyncRemediationNone only reports a degraded VolumeReplication in the PVC's conditions
+	ResyncRemediationNone = ResyncRemediation("None")
+
+	// ResyncRemediationAutoResync requests a resync of a degraded, Secondary, VolumeReplication that is not
+	// resyncing, and reports an event once the attempts are exhausted. PVCs replicated by a
+	// VolumeGroupReplication are not resynced, and an event is reported instead.
+	ResyncRemediationAutoResync = ResyncRemediation("AutoResync")
+
+	// ResyncRemediationAlertOnly reports an event for a degraded VolumeReplication
+	ResyncRemediationAlertOnly = ResyncRemediation("AlertOnly")
+)
+
+// ResyncPolicy configures the remediation of degraded VolumeReplications
+type ResyncPolicy struct {
+	// Remediation of a degraded VolumeReplication
+	// +kubebuilder:default=None
+	//+optional
+	Remediation ResyncRemediation `json:"remediation,omitempty"`
+
+	// MaxAttempts is the number of resyncs requested for a PVC, while it is degraded
+	// +kubebuilder:default=3
+	// +kubebuilder:validation:Minimum=1
+	//+optional
+	MaxAttempts int32 `json:"maxAttempts,omitempty"`
+
+	// Backoff is the time to wait after the first resync request before the next one, and is doubled
+	// after each request, up to 24 hours
+	// +kubebuilder:default="5m"
+	//+optional
+	Backoff metav1.Duration `json:"backoff,omitempty"`
 }
 
 type Identifier struct {
@@ -245,6 +653,12 @@ type ProtectedPVC struct {
 	//+optional
 	ProtectedByVolSync bool `json:"protectedByVolSync,omitempty"`
 
+	// StaticPVName is the name of the statically provisioned PV, not provisioned by a CSI driver, that the PVC
+	// is bound to. Its data is replicated by VolSync without snapshots, and its spec is protected in the S3
+	// store for it to be restored on a peer cluster.
+	//+optional
+	StaticPVName string `json:"staticPVName,omitempty"`
+
 	//+optional
 	StorageIdentifiers `json:",inline,omitempty"`
 
@@ -268,6 +682,11 @@ type ProtectedPVC struct {
 	//+optional
 	Resources corev1.VolumeResourceRequirements `json:"resources,omitempty"`
 
+	// SchedulingInterval is the effective interval for replicating the PVC's data to a peer cluster,
+	// which is either the VRG's scheduling interval or an override specified by the PVC's annotation
+	//+optional
+	SchedulingInterval string `json:"schedulingInterval,omitempty"`
+
 	// Conditions for this protected pvc
 	//+optional
 	Conditions []metav1.Condition `json:"conditions,omitempty"`
@@ -282,8 +701,42 @@ type ProtectedPVC struct {
 	//+optional
 	LastSyncDuration *metav1.Duration `json:"lastSyncDuration,omitempty"`
 
-	// Bytes transferred per sync, if protected in async mode only
+	// Bytes transferred per sync, if protected in async or volsync mode
 	LastSyncBytes *int64 `json:"lastSyncBytes,omitempty"`
+
+	// SyncInProgress is true while a synchronization of the PVC is in progress, if protected in the volsync mode
+	//+optional
+	SyncInProgress bool `json:"syncInProgress,omitempty"`
+
+	// Start time of the synchronization in progress for the PVC, if protected in the volsync mode
+	//+optional
+	SyncStartTime *metav1.Time `json:"syncStartTime,omitempty"`
+
+	// Estimated completion time of the synchronization in progress for the PVC, which is its start time
+	// plus the duration of the most recent successful synchronization, if protected in the volsync mode
+	//+optional
+	EstimatedSyncCompletionTime *metav1.Time `json:"estimatedSyncCompletionTime,omitempty"`
+
+	// FinalSyncComplete is true once the final sync of the PVC, requested by runFinalSync, has completed, if
+	// protected in the volsync mode
+	//+optional
+	FinalSyncComplete bool `json:"finalSyncComplete,omitempty"`
+
+	// RecoveryPoints retained by the ReplicationDestination of a PVC protected by VolSync, latest first
+	//+optional
+	RecoveryPoints []VolSyncRecoveryPoint `json:"recoveryPoints,omitempty"`
+
+	// Time since the VolumeReplication of the PVC is continuously reported as degraded
+	//+optional
+	DegradedSince *metav1.Time `json:"degradedSince,omitempty"`
+
+	// Number of resyncs requested for the PVC since it is degraded
+	//+optional
+	ResyncAttempts int32 `json:"resyncAttempts,omitempty"`
+
+	// Time of the most recent resync requested for the PVC
+	//+optional
+	LastResyncAttemptTime *metav1.Time `json:"lastResyncAttemptTime,omitempty"`
 }
 
 type KubeObjectsCaptureIdentifier struct {
@@ -321,6 +774,11 @@ type VolumeReplicationGroupStatus struct {
 	PrepareForFinalSyncComplete bool `json:"prepareForFinalSyncComplete,omitempty"`
 	FinalSyncComplete           bool `json:"finalSyncComplete,omitempty"`
 
+	// groupSchedulingInterval is the longest effective scheduling interval of all PVCs, which
+	// bounds the recovery point objective of the group
+	//+optional
+	GroupSchedulingInterval string `json:"groupSchedulingInterval,omitempty"`
+
 	// lastGroupSyncTime is the time of the most recent successful synchronization of all PVCs
 	//+optional
 	LastGroupSyncTime *metav1.Time `json:"lastGroupSyncTime,omitempty"`
@@ -333,6 +791,47 @@ type VolumeReplicationGroupStatus struct {
 	// successful synchronization of all PVCs
 	//+optional
 	LastGroupSyncBytes *int64 `json:"lastGroupSyncBytes,omitempty"`
+
+	// groupSyncsInProgress is the number of PVCs whose synchronization is in progress
+	//+optional
+	GroupSyncsInProgress int `json:"groupSyncsInProgress,omitempty"`
+
+	// estimatedGroupSyncCompletionTime is the latest estimated completion time of the synchronizations
+	// in progress of all PVCs
+	//+optional
+	EstimatedGroupSyncCompletionTime *metav1.Time `json:"estimatedGroupSyncCompletionTime,omitempty"`
+
+	// ConsistentSync reports the progress of the most recent consistent sync request
+	//+optional
+	ConsistentSync *ConsistentSyncStatus `json:"consistentSync,omitempty"`
+
+	// LastConsistentSyncTime is the point in time of the most recent successful consistent sync, at which the
+	// application was frozen and all its volumes were requested to sync
+	//+optional
+	LastConsistentSyncTime *metav1.Time `json:"lastConsistentSyncTime,omitempty"`
+
+	// ProtectedVolumeSnapshots are the VolumeSnapshots protected by the VRG
+	//+optional
+	ProtectedVolumeSnapshots []ProtectedVolumeSnapshot `json:"protectedVolumeSnapshots,omitempty"`
+
+	// VolSyncKeys are the keys of the VolSync pre-shared key secret of the VRG
+	//+optional
+	VolSyncKeys *VolSyncKeysStatus `json:"volSyncKeys,omitempty"`
+
+	// VolSyncRDNodePorts are the node ports of the NodePort services of the ReplicationDestinations of a
+	// secondary VRG, for the hub to pass them to the primary VRG
+	//+optional
+	VolSyncRDNodePorts []VolSyncNodePort `json:"volSyncRDNodePorts,omitempty"`
+}
+
+// VolSyncKeysStatus has the identities of the keys of the VolSync pre-shared key secret of a VRG, and when the VRG
+// observed them
+type VolSyncKeysStatus struct {
+	// Identities of the keys. The rsync TLS movers connect with the key of the first, and accept all of them.
+	Identities []string `json:"identities"`
+
+	// ObservedTime is when the VRG observed the identities
+	ObservedTime metav1.Time `json:"observedTime"`
 }
 
 // +kubebuilder:object:root=true
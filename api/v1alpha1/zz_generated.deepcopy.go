@@ -35,6 +35,50 @@ func (in *ClusterMaintenanceMode) DeepCopy() *ClusterMaintenanceMode {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ConsistentSyncSpec) DeepCopyInto(out *ConsistentSyncSpec) {
+	*out = *in
+	if in.FreezeHooks != nil {
+		in, out := &in.FreezeHooks, &out.FreezeHooks
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Timeout != nil {
+		in, out := &in.Timeout, &out.Timeout
+		*out = new(v1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ConsistentSyncSpec.
+func (in *ConsistentSyncSpec) DeepCopy() *ConsistentSyncSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ConsistentSyncSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ConsistentSyncStatus) DeepCopyInto(out *ConsistentSyncStatus) {
+	*out = *in
+	if in.SyncStartTime != nil {
+		in, out := &in.SyncStartTime, &out.SyncStartTime
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ConsistentSyncStatus.
+func (in *ConsistentSyncStatus) DeepCopy() *ConsistentSyncStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ConsistentSyncStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *DRCluster) DeepCopyInto(out *DRCluster) {
 	*out = *in
@@ -217,11 +261,45 @@ func (in *DRPlacementControlSpec) DeepCopyInto(out *DRPlacementControlSpec) {
 	}
 	out.DRPolicyRef = in.DRPolicyRef
 	in.PVCSelector.DeepCopyInto(&out.PVCSelector)
+	if in.PVCFilter != nil {
+		in, out := &in.PVCFilter, &out.PVCFilter
+		*out = new(PVCFilter)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.TestFailover != nil {
+		in, out := &in.TestFailover, &out.TestFailover
+		*out = new(TestFailoverSpec)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.KubeObjectProtection != nil {
 		in, out := &in.KubeObjectProtection, &out.KubeObjectProtection
 		*out = new(KubeObjectProtectionSpec)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.ResyncPolicy != nil {
+		in, out := &in.ResyncPolicy, &out.ResyncPolicy
+		*out = new(ResyncPolicy)
+		**out = **in
+	}
+	if in.VMProtection != nil {
+		in, out := &in.VMProtection, &out.VMProtection
+		*out = new(VMProtectionSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.VolSyncMaxConcurrentSyncs != nil {
+		in, out := &in.VolSyncMaxConcurrentSyncs, &out.VolSyncMaxConcurrentSyncs
+		*out = new(int32)
+		**out = **in
+	}
+	if in.FailoverRecoveryPointTime != nil {
+		in, out := &in.FailoverRecoveryPointTime, &out.FailoverRecoveryPointTime
+		*out = (*in).DeepCopy()
+	}
+	if in.FinalSyncTimeout != nil {
+		in, out := &in.FinalSyncTimeout, &out.FinalSyncTimeout
+		*out = new(v1.Duration)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DRPlacementControlSpec.
@@ -273,10 +351,23 @@ func (in *DRPlacementControlStatus) DeepCopyInto(out *DRPlacementControlStatus)
 		*out = new(int64)
 		**out = **in
 	}
+	if in.EstimatedGroupSyncCompletionTime != nil {
+		in, out := &in.EstimatedGroupSyncCompletionTime, &out.EstimatedGroupSyncCompletionTime
+		*out = (*in).DeepCopy()
+	}
 	if in.LastKubeObjectProtectionTime != nil {
 		in, out := &in.LastKubeObjectProtectionTime, &out.LastKubeObjectProtectionTime
 		*out = (*in).DeepCopy()
 	}
+	if in.FinalSync != nil {
+		in, out := &in.FinalSync, &out.FinalSync
+		*out = new(FinalSyncStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.VolSyncKeyTime != nil {
+		in, out := &in.VolSyncKeyTime, &out.VolSyncKeyTime
+		*out = (*in).DeepCopy()
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DRPlacementControlStatus.
@@ -351,8 +442,39 @@ func (in *DRPolicyList) DeepCopyObject() runtime.Object {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *DRPolicySpec) DeepCopyInto(out *DRPolicySpec) {
 	*out = *in
+	if in.BlackoutWindows != nil {
+		in, out := &in.BlackoutWindows, &out.BlackoutWindows
+		*out = make([]ReplicationWindow, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	in.ReplicationClassSelector.DeepCopyInto(&out.ReplicationClassSelector)
 	in.VolumeSnapshotClassSelector.DeepCopyInto(&out.VolumeSnapshotClassSelector)
+	if in.VolumeGroupReplicationClassSelector != nil {
+		in, out := &in.VolumeGroupReplicationClassSelector, &out.VolumeGroupReplicationClassSelector
+		*out = new(v1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.StorageClassMappings != nil {
+		in, out := &in.StorageClassMappings, &out.StorageClassMappings
+		*out = make([]StorageClassMapping, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.StaticVolumeEndpointMappings != nil {
+		in, out := &in.StaticVolumeEndpointMappings, &out.StaticVolumeEndpointMappings
+		*out = make([]StaticVolumeEndpointMapping, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.VolSyncProfile != nil {
+		in, out := &in.VolSyncProfile, &out.VolSyncProfile
+		*out = new(VolSyncProfile)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.DRClusters != nil {
 		in, out := &in.DRClusters, &out.DRClusters
 		*out = make([]string, len(*in))
@@ -392,6 +514,55 @@ func (in *DRPolicyStatus) DeepCopy() *DRPolicyStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FinalSyncPVCStatus) DeepCopyInto(out *FinalSyncPVCStatus) {
+	*out = *in
+	if in.EstimatedCompletionTime != nil {
+		in, out := &in.EstimatedCompletionTime, &out.EstimatedCompletionTime
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FinalSyncPVCStatus.
+func (in *FinalSyncPVCStatus) DeepCopy() *FinalSyncPVCStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(FinalSyncPVCStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FinalSyncStatus) DeepCopyInto(out *FinalSyncStatus) {
+	*out = *in
+	if in.StartTime != nil {
+		in, out := &in.StartTime, &out.StartTime
+		*out = (*in).DeepCopy()
+	}
+	if in.Deadline != nil {
+		in, out := &in.Deadline, &out.Deadline
+		*out = (*in).DeepCopy()
+	}
+	if in.PVCs != nil {
+		in, out := &in.PVCs, &out.PVCs
+		*out = make([]FinalSyncPVCStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FinalSyncStatus.
+func (in *FinalSyncStatus) DeepCopy() *FinalSyncStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(FinalSyncStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Identifier) DeepCopyInto(out *Identifier) {
 	*out = *in
@@ -425,6 +596,11 @@ func (in *KubeObjectProtectionSpec) DeepCopyInto(out *KubeObjectProtectionSpec)
 		*out = new(RecipeRef)
 		**out = **in
 	}
+	if in.RecipeRefs != nil {
+		in, out := &in.RecipeRefs, &out.RecipeRefs
+		*out = make([]RecipeRef, len(*in))
+		copy(*out, *in)
+	}
 	if in.RecipeParameters != nil {
 		in, out := &in.RecipeParameters, &out.RecipeParameters
 		*out = make(map[string][]string, len(*in))
@@ -596,6 +772,51 @@ func (in *MaintenanceModeStatus) DeepCopy() *MaintenanceModeStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PVCFilter) DeepCopyInto(out *PVCFilter) {
+	*out = *in
+	if in.StorageClassNames != nil {
+		in, out := &in.StorageClassNames, &out.StorageClassNames
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.ExcludedStorageClassNames != nil {
+		in, out := &in.ExcludedStorageClassNames, &out.ExcludedStorageClassNames
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.AccessModes != nil {
+		in, out := &in.AccessModes, &out.AccessModes
+		*out = make([]corev1.PersistentVolumeAccessMode, len(*in))
+		copy(*out, *in)
+	}
+	if in.MinSize != nil {
+		in, out := &in.MinSize, &out.MinSize
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+	if in.MaxSize != nil {
+		in, out := &in.MaxSize, &out.MaxSize
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+	if in.AnnotationSelector != nil {
+		in, out := &in.AnnotationSelector, &out.AnnotationSelector
+		*out = new(v1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PVCFilter.
+func (in *PVCFilter) DeepCopy() *PVCFilter {
+	if in == nil {
+		return nil
+	}
+	out := new(PVCFilter)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *PlacementDecision) DeepCopyInto(out *PlacementDecision) {
 	*out = *in
@@ -661,6 +882,29 @@ func (in *ProtectedPVC) DeepCopyInto(out *ProtectedPVC) {
 		*out = new(int64)
 		**out = **in
 	}
+	if in.SyncStartTime != nil {
+		in, out := &in.SyncStartTime, &out.SyncStartTime
+		*out = (*in).DeepCopy()
+	}
+	if in.EstimatedSyncCompletionTime != nil {
+		in, out := &in.EstimatedSyncCompletionTime, &out.EstimatedSyncCompletionTime
+		*out = (*in).DeepCopy()
+	}
+	if in.RecoveryPoints != nil {
+		in, out := &in.RecoveryPoints, &out.RecoveryPoints
+		*out = make([]VolSyncRecoveryPoint, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.DegradedSince != nil {
+		in, out := &in.DegradedSince, &out.DegradedSince
+		*out = (*in).DeepCopy()
+	}
+	if in.LastResyncAttemptTime != nil {
+		in, out := &in.LastResyncAttemptTime, &out.LastResyncAttemptTime
+		*out = (*in).DeepCopy()
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProtectedPVC.
@@ -774,6 +1018,21 @@ func (in *ProtectedVolumeReplicationGroupListStatus) DeepCopy() *ProtectedVolume
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ProtectedVolumeSnapshot) DeepCopyInto(out *ProtectedVolumeSnapshot) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProtectedVolumeSnapshot.
+func (in *ProtectedVolumeSnapshot) DeepCopy() *ProtectedVolumeSnapshot {
+	if in == nil {
+		return nil
+	}
+	out := new(ProtectedVolumeSnapshot)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *RamenConfig) DeepCopyInto(out *RamenConfig) {
 	*out = *in
@@ -788,8 +1047,14 @@ func (in *RamenConfig) DeepCopyInto(out *RamenConfig) {
 	}
 	out.DrClusterOperator = in.DrClusterOperator
 	out.VolSync = in.VolSync
+	if in.VolSyncProfile != nil {
+		in, out := &in.VolSyncProfile, &out.VolSyncProfile
+		*out = new(VolSyncProfile)
+		(*in).DeepCopyInto(*out)
+	}
 	out.KubeObjectProtection = in.KubeObjectProtection
 	out.MultiNamespace = in.MultiNamespace
+	out.VolumeGroupReplication = in.VolumeGroupReplication
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RamenConfig.
@@ -825,6 +1090,42 @@ func (in *RecipeRef) DeepCopy() *RecipeRef {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ReplicationWindow) DeepCopyInto(out *ReplicationWindow) {
+	*out = *in
+	if in.Days != nil {
+		in, out := &in.Days, &out.Days
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ReplicationWindow.
+func (in *ReplicationWindow) DeepCopy() *ReplicationWindow {
+	if in == nil {
+		return nil
+	}
+	out := new(ReplicationWindow)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ResyncPolicy) DeepCopyInto(out *ResyncPolicy) {
+	*out = *in
+	out.Backoff = in.Backoff
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ResyncPolicy.
+func (in *ResyncPolicy) DeepCopy() *ResyncPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(ResyncPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *S3StoreProfile) DeepCopyInto(out *S3StoreProfile) {
 	*out = *in
@@ -851,6 +1152,50 @@ func (in *S3StoreProfile) DeepCopy() *S3StoreProfile {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *StaticVolumeEndpointMapping) DeepCopyInto(out *StaticVolumeEndpointMapping) {
+	*out = *in
+	if in.Endpoints != nil {
+		in, out := &in.Endpoints, &out.Endpoints
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new StaticVolumeEndpointMapping.
+func (in *StaticVolumeEndpointMapping) DeepCopy() *StaticVolumeEndpointMapping {
+	if in == nil {
+		return nil
+	}
+	out := new(StaticVolumeEndpointMapping)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *StorageClassMapping) DeepCopyInto(out *StorageClassMapping) {
+	*out = *in
+	if in.StorageClassNames != nil {
+		in, out := &in.StorageClassNames, &out.StorageClassNames
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new StorageClassMapping.
+func (in *StorageClassMapping) DeepCopy() *StorageClassMapping {
+	if in == nil {
+		return nil
+	}
+	out := new(StorageClassMapping)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *StorageIdentifiers) DeepCopyInto(out *StorageIdentifiers) {
 	*out = *in
@@ -868,11 +1213,75 @@ func (in *StorageIdentifiers) DeepCopy() *StorageIdentifiers {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TestFailoverSpec) DeepCopyInto(out *TestFailoverSpec) {
+	*out = *in
+	if in.NamespaceMapping != nil {
+		in, out := &in.NamespaceMapping, &out.NamespaceMapping
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TestFailoverSpec.
+func (in *TestFailoverSpec) DeepCopy() *TestFailoverSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(TestFailoverSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VMProtectionSpec) DeepCopyInto(out *VMProtectionSpec) {
+	*out = *in
+	if in.VMSelector != nil {
+		in, out := &in.VMSelector, &out.VMSelector
+		*out = new(v1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VMProtectionSpec.
+func (in *VMProtectionSpec) DeepCopy() *VMProtectionSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(VMProtectionSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *VRGAsyncSpec) DeepCopyInto(out *VRGAsyncSpec) {
 	*out = *in
 	in.ReplicationClassSelector.DeepCopyInto(&out.ReplicationClassSelector)
 	in.VolumeSnapshotClassSelector.DeepCopyInto(&out.VolumeSnapshotClassSelector)
+	if in.VolumeGroupReplicationClassSelector != nil {
+		in, out := &in.VolumeGroupReplicationClassSelector, &out.VolumeGroupReplicationClassSelector
+		*out = new(v1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.BlackoutWindows != nil {
+		in, out := &in.BlackoutWindows, &out.BlackoutWindows
+		*out = make([]ReplicationWindow, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.VolSyncProfile != nil {
+		in, out := &in.VolSyncProfile, &out.VolSyncProfile
+		*out = new(VolSyncProfile)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.VolSyncMaxConcurrentSyncs != nil {
+		in, out := &in.VolSyncMaxConcurrentSyncs, &out.VolSyncMaxConcurrentSyncs
+		*out = new(int32)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VRGAsyncSpec.
@@ -931,6 +1340,7 @@ func (in *VRGResourceMeta) DeepCopy() *VRGResourceMeta {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *VRGSyncSpec) DeepCopyInto(out *VRGSyncSpec) {
 	*out = *in
+	in.ReplicationClassSelector.DeepCopyInto(&out.ReplicationClassSelector)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VRGSyncSpec.
@@ -943,6 +1353,95 @@ func (in *VRGSyncSpec) DeepCopy() *VRGSyncSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VolSyncKeysStatus) DeepCopyInto(out *VolSyncKeysStatus) {
+	*out = *in
+	if in.Identities != nil {
+		in, out := &in.Identities, &out.Identities
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	in.ObservedTime.DeepCopyInto(&out.ObservedTime)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VolSyncKeysStatus.
+func (in *VolSyncKeysStatus) DeepCopy() *VolSyncKeysStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(VolSyncKeysStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VolSyncNodePort) DeepCopyInto(out *VolSyncNodePort) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VolSyncNodePort.
+func (in *VolSyncNodePort) DeepCopy() *VolSyncNodePort {
+	if in == nil {
+		return nil
+	}
+	out := new(VolSyncNodePort)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VolSyncProfile) DeepCopyInto(out *VolSyncProfile) {
+	*out = *in
+	if in.ServiceType != nil {
+		in, out := &in.ServiceType, &out.ServiceType
+		*out = new(corev1.ServiceType)
+		**out = **in
+	}
+	if in.ServiceAnnotations != nil {
+		in, out := &in.ServiceAnnotations, &out.ServiceAnnotations
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.MoverSecurityContext != nil {
+		in, out := &in.MoverSecurityContext, &out.MoverSecurityContext
+		*out = new(corev1.PodSecurityContext)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.MoverServiceAccount != nil {
+		in, out := &in.MoverServiceAccount, &out.MoverServiceAccount
+		*out = new(string)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VolSyncProfile.
+func (in *VolSyncProfile) DeepCopy() *VolSyncProfile {
+	if in == nil {
+		return nil
+	}
+	out := new(VolSyncProfile)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VolSyncRecoveryPoint) DeepCopyInto(out *VolSyncRecoveryPoint) {
+	*out = *in
+	in.Time.DeepCopyInto(&out.Time)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VolSyncRecoveryPoint.
+func (in *VolSyncRecoveryPoint) DeepCopy() *VolSyncRecoveryPoint {
+	if in == nil {
+		return nil
+	}
+	out := new(VolSyncRecoveryPoint)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *VolSyncReplicationDestinationSpec) DeepCopyInto(out *VolSyncReplicationDestinationSpec) {
 	*out = *in
@@ -985,6 +1484,20 @@ func (in *VolSyncSpec) DeepCopyInto(out *VolSyncSpec) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.RecoveryPointTime != nil {
+		in, out := &in.RecoveryPointTime, &out.RecoveryPointTime
+		*out = (*in).DeepCopy()
+	}
+	if in.Peers != nil {
+		in, out := &in.Peers, &out.Peers
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.RDNodePorts != nil {
+		in, out := &in.RDNodePorts, &out.RDNodePorts
+		*out = make([]VolSyncNodePort, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VolSyncSpec.
@@ -1060,6 +1573,11 @@ func (in *VolumeReplicationGroupList) DeepCopyObject() runtime.Object {
 func (in *VolumeReplicationGroupSpec) DeepCopyInto(out *VolumeReplicationGroupSpec) {
 	*out = *in
 	in.PVCSelector.DeepCopyInto(&out.PVCSelector)
+	if in.PVCFilter != nil {
+		in, out := &in.PVCFilter, &out.PVCFilter
+		*out = new(PVCFilter)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.S3Profiles != nil {
 		in, out := &in.S3Profiles, &out.S3Profiles
 		*out = make([]string, len(*in))
@@ -1073,7 +1591,7 @@ func (in *VolumeReplicationGroupSpec) DeepCopyInto(out *VolumeReplicationGroupSp
 	if in.Sync != nil {
 		in, out := &in.Sync, &out.Sync
 		*out = new(VRGSyncSpec)
-		**out = **in
+		(*in).DeepCopyInto(*out)
 	}
 	in.VolSync.DeepCopyInto(&out.VolSync)
 	if in.KubeObjectProtection != nil {
@@ -1090,6 +1608,45 @@ func (in *VolumeReplicationGroupSpec) DeepCopyInto(out *VolumeReplicationGroupSp
 			copy(*out, *in)
 		}
 	}
+	if in.TestFailover != nil {
+		in, out := &in.TestFailover, &out.TestFailover
+		*out = new(TestFailoverSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.StorageClassMapping != nil {
+		in, out := &in.StorageClassMapping, &out.StorageClassMapping
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.ResyncPolicy != nil {
+		in, out := &in.ResyncPolicy, &out.ResyncPolicy
+		*out = new(ResyncPolicy)
+		**out = **in
+	}
+	if in.StaticVolumeEndpointMapping != nil {
+		in, out := &in.StaticVolumeEndpointMapping, &out.StaticVolumeEndpointMapping
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.VMProtection != nil {
+		in, out := &in.VMProtection, &out.VMProtection
+		*out = new(VMProtectionSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ConsistentSync != nil {
+		in, out := &in.ConsistentSync, &out.ConsistentSync
+		*out = new(ConsistentSyncSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.VolumeSnapshotProtection != nil {
+		in, out := &in.VolumeSnapshotProtection, &out.VolumeSnapshotProtection
+		*out = new(VolumeSnapshotProtectionSpec)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VolumeReplicationGroupSpec.
@@ -1135,6 +1692,34 @@ func (in *VolumeReplicationGroupStatus) DeepCopyInto(out *VolumeReplicationGroup
 		*out = new(int64)
 		**out = **in
 	}
+	if in.EstimatedGroupSyncCompletionTime != nil {
+		in, out := &in.EstimatedGroupSyncCompletionTime, &out.EstimatedGroupSyncCompletionTime
+		*out = (*in).DeepCopy()
+	}
+	if in.ConsistentSync != nil {
+		in, out := &in.ConsistentSync, &out.ConsistentSync
+		*out = new(ConsistentSyncStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.LastConsistentSyncTime != nil {
+		in, out := &in.LastConsistentSyncTime, &out.LastConsistentSyncTime
+		*out = (*in).DeepCopy()
+	}
+	if in.ProtectedVolumeSnapshots != nil {
+		in, out := &in.ProtectedVolumeSnapshots, &out.ProtectedVolumeSnapshots
+		*out = make([]ProtectedVolumeSnapshot, len(*in))
+		copy(*out, *in)
+	}
+	if in.VolSyncKeys != nil {
+		in, out := &in.VolSyncKeys, &out.VolSyncKeys
+		*out = new(VolSyncKeysStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.VolSyncRDNodePorts != nil {
+		in, out := &in.VolSyncRDNodePorts, &out.VolSyncRDNodePorts
+		*out = make([]VolSyncNodePort, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VolumeReplicationGroupStatus.
@@ -1146,3 +1731,23 @@ func (in *VolumeReplicationGroupStatus) DeepCopy() *VolumeReplicationGroupStatus
 	in.DeepCopyInto(out)
 	return out
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VolumeSnapshotProtectionSpec) DeepCopyInto(out *VolumeSnapshotProtectionSpec) {
+	*out = *in
+	if in.VolumeSnapshotSelector != nil {
+		in, out := &in.VolumeSnapshotSelector, &out.VolumeSnapshotSelector
+		*out = new(v1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VolumeSnapshotProtectionSpec.
+func (in *VolumeSnapshotProtectionSpec) DeepCopy() *VolumeSnapshotProtectionSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(VolumeSnapshotProtectionSpec)
+	in.DeepCopyInto(out)
+	return out
+}
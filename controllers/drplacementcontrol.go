@@ -7,6 +7,8 @@ import (
 	"context"
 	"fmt"
 	"reflect"
+	"sort"
+	"strings"
 	"time"
 
 	"github.com/go-logr/logr"
@@ -15,7 +17,9 @@ import (
 	"golang.org/x/exp/slices"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
 
 	rmn "github.com/ramendr/ramen/api/v1alpha1"
 	rmnutil "github.com/ramendr/ramen/controllers/util"
@@ -105,6 +109,18 @@ func (d *DRPCInstance) startProcessing() bool {
 func (d *DRPCInstance) processPlacement() (bool, error) {
 	d.log.Info("Process DRPC Placement", "DRAction", d.instance.Spec.Action)
 
+	if d.splitBrainDetected() {
+		return false, nil
+	}
+
+	if d.instance.Spec.Action == rmn.ActionTestFailover {
+		return d.RunTestFailover()
+	}
+
+	if cleanedUp, err := d.ensureTestFailoverCleanedUp(); !cleanedUp || err != nil {
+		return false, err
+	}
+
 	switch d.instance.Spec.Action {
 	case rmn.ActionFailover:
 		return d.RunFailover()
@@ -385,11 +401,18 @@ func (d *DRPCInstance) RunFailover() (bool, error) {
 }
 
 // isValidFailoverTarget determines if the passed in cluster is a valid target to failover to. A valid failover target
-// may already be Primary, if it is Secondary then it has to be protecting PVCs with VolSync.
+// is a cluster of the DRPolicy, which may be any of the secondaries of a policy with more than 2 clusters. It may
+// already be Primary, if it is Secondary then it has to be protecting PVCs with VolSync.
 // NOTE: Currently there is a gap where, right after DR protection when a Secondary VRG is not yet created for VolSync
 // workloads, a failover if initiated will pass these checks. When we fix to retain VRG for VR as well, a more
 // deterministic check for VRG as Secondary can be performed.
 func (d *DRPCInstance) isValidFailoverTarget(cluster string) bool {
+	if !slices.Contains(rmnutil.DRPolicyClusterNames(d.drPolicy), cluster) {
+		d.log.Info(fmt.Sprintf("Cluster %q is not in the DRPolicy", cluster))
+
+		return false
+	}
+
 	annotations := make(map[string]string)
 	annotations[DRPCNameAnnotation] = d.instance.GetName()
 	annotations[DRPCNamespaceAnnotation] = d.instance.GetNamespace()
@@ -510,6 +533,11 @@ func (d *DRPCInstance) getCurrentHomeClusterName(toCluster string, drClusters []
 		return d.instance.Status.PreferredDecision.ClusterName
 	}
 
+	// otherwise, return the cluster of the primary VRG, if any, as there may be more than one peer cluster
+	if primaryCluster, _ := d.selectCurrentPrimaryAndSecondaries(); primaryCluster != "" && primaryCluster != toCluster {
+		return primaryCluster
+	}
+
 	// otherwise, just return the peer cluster
 	for i := range drClusters {
 		if drClusters[i].Name != toCluster {
@@ -565,6 +593,10 @@ func (d *DRPCInstance) checkFailoverPrerequisites(curHomeCluster string) (bool,
 func (d *DRPCInstance) checkMetroFailoverPrerequisites(curHomeCluster string) (bool, error) {
 	met := true
 
+	if mirroringMet, err := d.checkMetroMirroring(curHomeCluster); !mirroringMet || err != nil {
+		return !met, err
+	}
+
 	d.setProgression(rmn.ProgressionWaitForFencing)
 
 	fenced, err := d.checkClusterFenced(curHomeCluster, d.drClusters)
@@ -579,6 +611,50 @@ func (d *DRPCInstance) checkMetroFailoverPrerequisites(curHomeCluster string) (b
 	return met, nil
 }
 
+// metroMirroringStatusMaxAge is the age beyond which the status of a VRG that has not observed its latest
+// generation is presumed to be that of an unreachable cluster, rather than a VRG yet to be reconciled
+const metroMirroringStatusMaxAge = 5 * time.Minute
+
+// checkMetroMirroring checks the synchronous mirroring state last reported by the VRG of the current home cluster,
+// which reflects the health of the storage mirroring of its PVCs. A failover is refused if the VRG reports the
+// mirroring as unhealthy, or that the storage does not report it, and waits for a VRG that has not yet observed its
+// latest generation. The current home cluster may be unreachable, in which case fencing alone gates the failover.
+func (d *DRPCInstance) checkMetroMirroring(curHomeCluster string) (bool, error) {
+	const met = true
+
+	vrg, ok := d.vrgs[curHomeCluster]
+	if !ok {
+		return met, nil
+	}
+
+	condition := findCondition(vrg.Status.Conditions, VRGConditionTypeDataProtected)
+	if vrg.Status.ObservedGeneration != vrg.Generation ||
+		(condition != nil && condition.ObservedGeneration != vrg.Generation) {
+		if time.Since(vrg.Status.LastUpdateTime.Time) > metroMirroringStatusMaxAge {
+			d.log.Info("Synchronous mirroring state unknown, VRG status is stale", "cluster", curHomeCluster,
+				"lastUpdateTime", vrg.Status.LastUpdateTime)
+
+			return met, nil
+		}
+
+		d.log.Info("Waiting for VRG to report the synchronous mirroring state", "cluster", curHomeCluster)
+
+		return !met, nil
+	}
+
+	if condition != nil && condition.Status == metav1.ConditionFalse && condition.Reason == VRGConditionReasonError {
+		return !met, fmt.Errorf("synchronous mirroring from cluster %s is not healthy: %s",
+			curHomeCluster, condition.Message)
+	}
+
+	if condition != nil && condition.Reason == VRGConditionReasonMirroringUnknown {
+		return !met, fmt.Errorf("synchronous mirroring state from cluster %s is unknown: %s",
+			curHomeCluster, condition.Message)
+	}
+
+	return met, nil
+}
+
 // checkRegionalFailoverPrerequisites checks for any RegionalDR failover prerequsites that need to be met on the
 // failoverCluster before initiating a failover.
 // Returns:
@@ -859,6 +935,10 @@ func (d *DRPCInstance) RunRelocate() (bool, error) {
 
 	d.setStatusInitiating()
 
+	if d.relocationAborted() {
+		return !done, nil
+	}
+
 	// Check if current primary (that is not the preferred cluster), is ready to switch over
 	if curHomeCluster != "" && curHomeCluster != preferredCluster &&
 		!d.readyToSwitchOver(curHomeCluster, preferredCluster) {
@@ -887,6 +967,146 @@ func (d *DRPCInstance) RunRelocate() (bool, error) {
 	return d.relocate(preferredCluster, preferredClusterNamespace, rmn.Relocating)
 }
 
+// RunTestFailover requests the secondary VRG on the FailoverCluster to restore a copy of the workload into the
+// namespaces mapped by spec.TestFailover. The primary VRG, the placement and replication are left untouched.
+func (d *DRPCInstance) RunTestFailover() (bool, error) {
+	d.log.Info("Entering RunTestFailover", "state", d.getLastDRState())
+
+	const done = true
+
+	testCluster := d.instance.Spec.FailoverCluster
+	if testCluster == "" || d.instance.Spec.TestFailover == nil {
+		msg := "missing value for spec.FailoverCluster or spec.TestFailover"
+		addOrUpdateCondition(&d.instance.Status.Conditions, rmn.ConditionAvailable, d.instance.Generation,
+			d.getConditionStatusForTypeAvailable(), string(d.instance.Status.Phase), msg)
+
+		return done, fmt.Errorf(msg)
+	}
+
+	if d.vrgExistsAndPrimary(testCluster) {
+		err := fmt.Errorf("unable to start test failover, spec.FailoverCluster (%s) is the current primary",
+			testCluster)
+		addOrUpdateCondition(&d.instance.Status.Conditions, rmn.ConditionAvailable, d.instance.Generation,
+			d.getConditionStatusForTypeAvailable(), string(d.instance.Status.Phase), err.Error())
+
+		return !done, err
+	}
+
+	if err := d.testFailoverSupported(testCluster); err != nil {
+		addOrUpdateCondition(&d.instance.Status.Conditions, rmn.ConditionAvailable, d.instance.Generation,
+			d.getConditionStatusForTypeAvailable(), rmn.ReasonTestFailoverUnsupported, err.Error())
+
+		return done, err
+	}
+
+	vrg, err := d.getVRGFromManifestWork(testCluster)
+	if err != nil {
+		return !done, fmt.Errorf("unable to start test failover, no secondary VRG for cluster %s (%w)",
+			testCluster, err)
+	}
+
+	if !reflect.DeepEqual(vrg.Spec.TestFailover, d.instance.Spec.TestFailover) {
+		vrg.Spec.TestFailover = d.instance.Spec.TestFailover.DeepCopy()
+
+		if err := d.updateManifestWork(testCluster, vrg); err != nil {
+			return !done, err
+		}
+	}
+
+	if d.getLastDRState() != rmn.TestingFailover && d.getLastDRState() != rmn.TestedFailover {
+		d.instance.Status.ActionStartTime = &metav1.Time{Time: time.Now()}
+		d.instance.Status.ActionDuration = nil
+	}
+
+	if !d.isVRGConditionMet(testCluster, VRGConditionTypeTestFailover) {
+		d.setDRState(rmn.TestingFailover)
+		d.setProgression(rmn.ProgressionRestoringTestFailover)
+
+		return !done, nil
+	}
+
+	d.setDRState(rmn.TestedFailover)
+	addOrUpdateCondition(&d.instance.Status.Conditions, rmn.ConditionAvailable, d.instance.Generation,
+		metav1.ConditionTrue, string(d.instance.Status.Phase), "Test failover completed")
+	d.setProgression(rmn.ProgressionCompleted)
+	d.setActionDuration()
+
+	return done, nil
+}
+
+// testFailoverSupported returns an error if the workload has PVCs protected by VolumeReplication, as reported by
+// the VRGs of the clusters, or if the VRG on the test cluster reports the test failover as unsupported. A copy of
+// such PVCs can be made only by promoting their replicated volumes, which would stop their replication, as the
+// storage does not snapshot or clone a secondary volume.
+func (d *DRPCInstance) testFailoverSupported(testCluster string) error {
+	pvcs := sets.New[string]()
+
+	for _, vrg := range d.vrgs {
+		for i := range vrg.Status.ProtectedPVCs {
+			if protectedPVC := &vrg.Status.ProtectedPVCs[i]; !protectedPVC.ProtectedByVolSync {
+				pvcs.Insert(protectedPVC.Namespace + "/" + protectedPVC.Name)
+			}
+		}
+	}
+
+	if pvcs.Len() != 0 {
+		return fmt.Errorf("test failover is not supported for PVCs protected by VolumeReplication, as a copy of "+
+			"their volumes can be made only by promoting them, which would stop their replication: %s",
+			strings.Join(sets.List(pvcs), ", "))
+	}
+
+	if vrg := d.vrgs[testCluster]; vrg != nil {
+		condition := findCondition(vrg.Status.Conditions, VRGConditionTypeTestFailover)
+		if condition != nil && condition.Reason == VRGConditionReasonTestFailoverUnsupported {
+			return fmt.Errorf("%s", condition.Message)
+		}
+	}
+
+	return nil
+}
+
+// ensureTestFailoverCleanedUp removes the test failover request from the VRGs of all clusters, once the DRPC action
+// is changed from TestFailover, and waits for the reachable clusters to tear down the restored copy.
+func (d *DRPCInstance) ensureTestFailoverCleanedUp() (bool, error) {
+	const cleanedUp = true
+
+	if d.getLastDRState() != rmn.TestingFailover && d.getLastDRState() != rmn.TestedFailover {
+		return cleanedUp, nil
+	}
+
+	for _, clusterName := range rmnutil.DRPolicyClusterNames(d.drPolicy) {
+		vrg, err := d.getVRGFromManifestWork(clusterName)
+		if err != nil {
+			if errors.IsNotFound(err) {
+				continue
+			}
+
+			return !cleanedUp, err
+		}
+
+		if vrg.Spec.TestFailover == nil {
+			continue
+		}
+
+		vrg.Spec.TestFailover = nil
+
+		if err := d.updateManifestWork(clusterName, vrg); err != nil {
+			return !cleanedUp, err
+		}
+	}
+
+	for clusterName, vrg := range d.vrgs {
+		if findCondition(vrg.Status.Conditions, VRGConditionTypeTestFailover) != nil {
+			d.log.Info("Waiting for test failover cleanup", "cluster", clusterName)
+			d.setProgression(rmn.ProgressionCleaningUpTestFailover)
+
+			return !cleanedUp, nil
+		}
+	}
+
+	return cleanedUp, nil
+}
+
 func (d *DRPCInstance) ensureActionCompleted(srcCluster string) (bool, error) {
 	const done = true
 
@@ -949,15 +1169,26 @@ func (d *DRPCInstance) ensureCleanupAndVolSyncReplicationSetup(srcCluster string
 func (d *DRPCInstance) quiesceAndRunFinalSync(homeCluster string) (bool, error) {
 	const done = true
 
+	d.finalSyncStatusStart()
+
+	// The relocation proceeds as a failover, without the final sync, or the final sync completed as it was being
+	// aborted
+	if d.finalSyncFailedOver() || d.finalSyncCompleted() {
+		return done, nil
+	}
+
+	// The final sync is being canceled, to abort the relocation
+	if d.finalSyncAborting() {
+		return d.finalSyncWait(homeCluster, d.instance.Status.Progression)
+	}
+
 	result, err := d.prepareForFinalSync(homeCluster)
 	if err != nil {
 		return !done, err
 	}
 
 	if !result {
-		d.setProgression(rmn.ProgressionPreparingFinalSync)
-
-		return !done, nil
+		return d.finalSyncWait(homeCluster, rmn.ProgressionPreparingFinalSync)
 	}
 
 	clusterDecision := d.reconciler.getClusterDecision(d.userPlacement)
@@ -981,17 +1212,237 @@ func (d *DRPCInstance) quiesceAndRunFinalSync(homeCluster string) (bool, error)
 		return !done, err
 	}
 
-	if !result {
-		d.setProgression(rmn.ProgressionRunningFinalSync)
+	d.finalSyncPVCsStatusUpdate(homeCluster)
 
-		return !done, nil
+	if !result {
+		return d.finalSyncWait(homeCluster, rmn.ProgressionRunningFinalSync)
 	}
 
+	d.instance.Status.FinalSync.Result = rmn.FinalSyncResultCompleted
+	d.instance.Status.FinalSync.ObservedGeneration = d.instance.Generation
 	d.setProgression(rmn.ProgressionFinalSyncComplete)
 
 	return done, nil
 }
 
+// currentFinalSync returns the final sync status of the current action, or nil if it has not started a final sync
+func (d *DRPCInstance) currentFinalSync() *rmn.FinalSyncStatus {
+	finalSync := d.instance.Status.FinalSync
+	if finalSync == nil || finalSync.StartTime == nil {
+		return nil
+	}
+
+	if d.instance.Status.ActionStartTime != nil && finalSync.StartTime.Before(d.instance.Status.ActionStartTime) {
+		return nil
+	}
+
+	return finalSync
+}
+
+// finalSyncStatusStart resets the final sync status, unless the current action has started a final sync already
+func (d *DRPCInstance) finalSyncStatusStart() {
+	if d.currentFinalSync() != nil {
+		return
+	}
+
+	d.instance.Status.FinalSync = &rmn.FinalSyncStatus{StartTime: &metav1.Time{Time: time.Now()}}
+}
+
+// finalSyncFailedOver returns true if the final sync of the current relocation timed out, and the relocation
+// proceeds as a failover
+func (d *DRPCInstance) finalSyncFailedOver() bool {
+	finalSync := d.currentFinalSync()
+
+	return finalSync != nil && finalSync.Result == rmn.FinalSyncResultFailedOver
+}
+
+// finalSyncCompleted returns true if the final sync of the current relocation completed
+func (d *DRPCInstance) finalSyncCompleted() bool {
+	finalSync := d.currentFinalSync()
+
+	return finalSync != nil && finalSync.Result == rmn.FinalSyncResultCompleted
+}
+
+// finalSyncAborting returns true if the final sync of the current relocation timed out, and is being canceled to
+// abort the relocation
+func (d *DRPCInstance) finalSyncAborting() bool {
+	finalSync := d.currentFinalSync()
+
+	return finalSync != nil && finalSync.TimedOut && finalSync.Result == "" &&
+		d.instance.Spec.FinalSyncTimeoutAction == rmn.FinalSyncTimeoutActionAbort
+}
+
+// finalSyncPVCsStatusUpdate reports the final sync status of each PVC protected by VolSync on the home cluster
+func (d *DRPCInstance) finalSyncPVCsStatusUpdate(homeCluster string) {
+	vrg := d.vrgs[homeCluster]
+	if vrg == nil {
+		return
+	}
+
+	pvcs := []rmn.FinalSyncPVCStatus{}
+
+	for _, protectedPVC := range vrg.Status.ProtectedPVCs {
+		if !protectedPVC.ProtectedByVolSync {
+			continue
+		}
+
+		pvc := rmn.FinalSyncPVCStatus{
+			Name:      protectedPVC.Name,
+			Namespace: protectedPVC.Namespace,
+			Complete:  protectedPVC.FinalSyncComplete,
+		}
+
+		if !pvc.Complete && protectedPVC.SyncInProgress {
+			pvc.EstimatedCompletionTime = protectedPVC.EstimatedSyncCompletionTime
+		}
+
+		pvcs = append(pvcs, pvc)
+	}
+
+	d.instance.Status.FinalSync.PVCs = pvcs
+}
+
+// finalSyncTimedOut updates the deadline of the final sync from spec.FinalSyncTimeout, and returns true once it
+// has passed
+func (d *DRPCInstance) finalSyncTimedOut() bool {
+	finalSync := d.instance.Status.FinalSync
+
+	finalSync.Deadline = nil
+	finalSync.TimedOut = false
+
+	if d.instance.Spec.FinalSyncTimeout == nil {
+		return false
+	}
+
+	finalSync.Deadline = &metav1.Time{Time: finalSync.StartTime.Add(d.instance.Spec.FinalSyncTimeout.Duration)}
+	finalSync.TimedOut = time.Now().After(finalSync.Deadline.Time)
+
+	return finalSync.TimedOut
+}
+
+// finalSyncWait waits for the final sync to complete, until it times out. On a timeout, the relocation is aborted,
+// proceeds as a failover, or waits for the operator to choose either, as set by spec.FinalSyncTimeoutAction.
+func (d *DRPCInstance) finalSyncWait(homeCluster string, progression rmn.ProgressionStatus) (bool, error) {
+	const done = true
+
+	d.setProgression(progression)
+
+	if !d.finalSyncTimedOut() {
+		return !done, nil
+	}
+
+	finalSync := d.instance.Status.FinalSync
+	incomplete := 0
+
+	for _, pvc := range finalSync.PVCs {
+		if !pvc.Complete {
+			incomplete++
+		}
+	}
+
+	msg := fmt.Sprintf("Final sync on cluster %s timed out at %v, with %d of %d PVCs not synced",
+		homeCluster, finalSync.Deadline, incomplete, len(finalSync.PVCs))
+
+	switch d.instance.Spec.FinalSyncTimeoutAction {
+	case rmn.FinalSyncTimeoutActionAbort:
+		aborted, err := d.abortRelocation(homeCluster)
+		if err != nil {
+			return !done, err
+		}
+
+		switch {
+		case aborted:
+			msg += ", relocation aborted"
+		case finalSync.Result == rmn.FinalSyncResultCompleted:
+			msg += ", but completed before it was canceled, relocation continued"
+		default:
+			msg += ", waiting for the cluster to cancel it to abort the relocation"
+		}
+	case rmn.FinalSyncTimeoutActionFailover:
+		finalSync.Result = rmn.FinalSyncResultFailedOver
+		finalSync.ObservedGeneration = d.instance.Generation
+		msg += ", relocating as a failover with the loss of the data not synced"
+	default:
+		msg += ", set spec.finalSyncTimeoutAction to Abort or Failover to proceed"
+
+		d.setProgression(rmn.ProgressionFinalSyncTimedOut)
+	}
+
+	d.log.Info(msg)
+	addOrUpdateCondition(&d.instance.Status.Conditions, rmn.ConditionAvailable, d.instance.Generation,
+		d.getConditionStatusForTypeAvailable(), string(d.instance.Status.Phase), msg)
+	rmnutil.ReportIfNotPresent(d.reconciler.eventRecorder, d.instance, corev1.EventTypeWarning,
+		rmnutil.EventReasonFinalSyncTimedOut, msg)
+
+	return finalSync.Result == rmn.FinalSyncResultFailedOver || finalSync.Result == rmn.FinalSyncResultCompleted, nil
+}
+
+// abortRelocation cancels the final sync on the home cluster, and places the workload back on it once the VRG
+// reports the final sync canceled, so that the workload is restored from the PVCs the VRG kept. It returns false
+// while the VRG has not canceled it, or if the final sync completed first, in which case the VRG may have deleted
+// the PVCs and the relocation continues.
+func (d *DRPCInstance) abortRelocation(homeCluster string) (bool, error) {
+	finalSync := d.instance.Status.FinalSync
+
+	vrg := d.vrgs[homeCluster]
+	if vrg != nil && vrg.Spec.RunFinalSync && vrg.Status.FinalSyncComplete {
+		d.log.Info(fmt.Sprintf("Final sync on cluster %s completed before it was canceled", homeCluster))
+
+		finalSync.Result = rmn.FinalSyncResultCompleted
+		finalSync.ObservedGeneration = d.instance.Generation
+		d.setProgression(rmn.ProgressionFinalSyncComplete)
+
+		return false, nil
+	}
+
+	if err := d.updateVRGToCancelFinalSync(homeCluster); err != nil {
+		return false, err
+	}
+
+	if vrg == nil || vrg.Spec.PrepareForFinalSync || vrg.Spec.RunFinalSync ||
+		vrg.Status.ObservedGeneration != vrg.Generation {
+		d.log.Info(fmt.Sprintf("Waiting for cluster %s to cancel the final sync", homeCluster))
+
+		return false, nil
+	}
+
+	if err := d.ensurePlacement(homeCluster); err != nil {
+		return false, err
+	}
+
+	finalSync.Result = rmn.FinalSyncResultAborted
+	finalSync.ObservedGeneration = d.instance.Generation
+	d.setProgression(rmn.ProgressionActionPaused)
+
+	return true, nil
+}
+
+// relocationAborted pauses the DRPC after its relocation was aborted on a final sync timeout. The relocation is
+// retried once the spec of the DRPC is updated.
+func (d *DRPCInstance) relocationAborted() bool {
+	finalSync := d.currentFinalSync()
+	if finalSync == nil || finalSync.Result != rmn.FinalSyncResultAborted {
+		return false
+	}
+
+	if finalSync.ObservedGeneration != d.instance.Generation {
+		d.log.Info("Retrying relocation aborted on a final sync timeout")
+
+		d.instance.Status.FinalSync = nil
+
+		return false
+	}
+
+	msg := "Operation Paused - User Intervention Required. " +
+		"Relocation aborted as the final sync timed out, update the DRPC to retry"
+
+	addOrUpdateCondition(&d.instance.Status.Conditions, rmn.ConditionAvailable, d.instance.Generation,
+		d.getConditionStatusForTypeAvailable(), string(d.instance.Status.Phase), msg)
+	d.setProgression(rmn.ProgressionActionPaused)
+
+	return true
+}
+
 func (d *DRPCInstance) prepareForFinalSync(homeCluster string) (bool, error) {
 	d.log.Info(fmt.Sprintf("Preparing final sync on cluster %s", homeCluster))
 
@@ -1052,6 +1503,73 @@ func (d *DRPCInstance) runFinalSync(homeCluster string) (bool, error) {
 	return done, nil
 }
 
+// splitBrainDetected sets the SplitBrain condition, and pauses the DRPC, while volumes of the workload are primary on
+// two clusters. The condition is removed, and the DRPC resumes, once the operator resolves the split-brain in the
+// storage.
+func (d *DRPCInstance) splitBrainDetected() bool {
+	msg := vrgsSplitBrain(d.vrgs)
+	if msg == "" {
+		meta.RemoveStatusCondition(&d.instance.Status.Conditions, rmn.ConditionSplitBrain)
+
+		return false
+	}
+
+	msg = "Operation Paused - User Intervention Required. " + msg
+
+	d.log.Info(msg)
+	addOrUpdateCondition(&d.instance.Status.Conditions, rmn.ConditionSplitBrain, d.instance.Generation,
+		metav1.ConditionTrue, rmn.ReasonSplitBrainDetected, msg)
+	d.setProgression(rmn.ProgressionActionPaused)
+	rmnutil.ReportIfNotPresent(d.reconciler.eventRecorder, d.instance, corev1.EventTypeWarning,
+		rmnutil.EventReasonSplitBrain, msg)
+
+	return true
+}
+
+// vrgsSplitBrain returns a description of the split-brain of the volumes of a workload, or an empty string if there
+// is none. Volumes are primary on two clusters while the VRG of a cluster reports, for its current generation, that
+// the storage failed to demote them, and the VRG of another cluster is primary, for its current generation.
+func vrgsSplitBrain(vrgs map[string]*rmn.VolumeReplicationGroup) string {
+	clusterNames := make([]string, 0, len(vrgs))
+	for clusterName := range vrgs {
+		clusterNames = append(clusterNames, clusterName)
+	}
+
+	sort.Strings(clusterNames)
+
+	primaryClusterName := ""
+
+	for _, clusterName := range clusterNames {
+		vrg := vrgs[clusterName]
+		if vrg.Spec.ReplicationState == rmn.Primary && vrg.Status.State == rmn.PrimaryState &&
+			vrg.Status.ObservedGeneration == vrg.Generation {
+			primaryClusterName = clusterName
+
+			break
+		}
+	}
+
+	if primaryClusterName == "" {
+		return ""
+	}
+
+	for _, clusterName := range clusterNames {
+		vrg := vrgs[clusterName]
+
+		condition := findCondition(vrg.Status.Conditions, VRGConditionTypeVolumesNotDemoted)
+		if clusterName == primaryClusterName || condition == nil || condition.Status != metav1.ConditionTrue ||
+			condition.ObservedGeneration != vrg.Generation {
+			continue
+		}
+
+		return fmt.Sprintf("Split-brain: volumes are primary on clusters %s and %s. %s. Resolve the split-brain "+
+			"in the storage, by demoting the volumes on the cluster whose data is to be discarded",
+			primaryClusterName, clusterName, condition.Message)
+	}
+
+	return ""
+}
+
 func (d *DRPCInstance) areMultipleVRGsPrimary() bool {
 	numOfPrimaries := 0
 
@@ -1571,13 +2089,24 @@ func vrgAction(drpcAction rmn.DRAction) rmn.VRGAction {
 	}
 }
 
+// setVRGAction sets the action of a VRG, and the recovery point time of its VolSync PVCs on failover. A relocation
+// whose final sync timed out proceeds as a failover.
 func (d *DRPCInstance) setVRGAction(vrg *rmn.VolumeReplicationGroup) {
 	action := vrgAction(d.instance.Spec.Action)
 	if action == "" {
 		return
 	}
 
+	if action == rmn.VRGActionRelocate && d.finalSyncFailedOver() {
+		action = rmn.VRGActionFailover
+	}
+
 	vrg.Spec.Action = action
+
+	vrg.Spec.VolSync.RecoveryPointTime = nil
+	if d.instance.Spec.Action == rmn.ActionFailover {
+		vrg.Spec.VolSync.RecoveryPointTime = d.instance.Spec.FailoverRecoveryPointTime
+	}
 }
 
 func (d *DRPCInstance) generateVRG(dstCluster string, repState rmn.ReplicationState) rmn.VolumeReplicationGroup {
@@ -1593,15 +2122,21 @@ func (d *DRPCInstance) generateVRG(dstCluster string, repState rmn.ReplicationSt
 			},
 		},
 		Spec: rmn.VolumeReplicationGroupSpec{
-			PVCSelector:          d.instance.Spec.PVCSelector,
-			ProtectedNamespaces:  d.instance.Spec.ProtectedNamespaces,
-			ReplicationState:     repState,
-			S3Profiles:           AvailableS3Profiles(d.drClusters),
-			KubeObjectProtection: d.instance.Spec.KubeObjectProtection,
+			PVCSelector:                 d.instance.Spec.PVCSelector,
+			PVCFilter:                   d.instance.Spec.PVCFilter,
+			ProtectedNamespaces:         d.instance.Spec.ProtectedNamespaces,
+			ReplicationState:            repState,
+			S3Profiles:                  AvailableS3Profiles(d.drClusters),
+			KubeObjectProtection:        d.instance.Spec.KubeObjectProtection,
+			StorageClassMapping:         rmnutil.DRPolicyStorageClassMapping(d.drPolicy, dstCluster),
+			StaticVolumeEndpointMapping: rmnutil.DRPolicyStaticVolumeEndpointMapping(d.drPolicy, dstCluster),
+			ResyncPolicy:                d.instance.Spec.ResyncPolicy,
+			VMProtection:                d.instance.Spec.VMProtection,
 		},
 	}
 
 	d.setVRGAction(&vrg)
+	vrg.Spec.VolSync.Peers = d.volSyncPeers(dstCluster)
 	vrg.Spec.Async = d.generateVRGSpecAsync()
 	vrg.Spec.Sync = d.generateVRGSpecSync()
 
@@ -1611,9 +2146,14 @@ func (d *DRPCInstance) generateVRG(dstCluster string, repState rmn.ReplicationSt
 func (d *DRPCInstance) generateVRGSpecAsync() *rmn.VRGAsyncSpec {
 	if dRPolicySupportsRegional(d.drPolicy, d.drClusters) {
 		return &rmn.VRGAsyncSpec{
-			ReplicationClassSelector:    d.drPolicy.Spec.ReplicationClassSelector,
-			VolumeSnapshotClassSelector: d.drPolicy.Spec.VolumeSnapshotClassSelector,
-			SchedulingInterval:          d.drPolicy.Spec.SchedulingInterval,
+			ReplicationClassSelector:            d.drPolicy.Spec.ReplicationClassSelector,
+			VolumeSnapshotClassSelector:         d.drPolicy.Spec.VolumeSnapshotClassSelector,
+			SchedulingInterval:                  d.drPolicy.Spec.SchedulingInterval,
+			VolumeGroupReplicationClassSelector: d.drPolicy.Spec.VolumeGroupReplicationClassSelector,
+			Schedule:                            d.drPolicy.Spec.Schedule,
+			BlackoutWindows:                     d.drPolicy.Spec.BlackoutWindows,
+			VolSyncProfile:                      d.drPolicy.Spec.VolSyncProfile,
+			VolSyncMaxConcurrentSyncs:           d.instance.Spec.VolSyncMaxConcurrentSyncs,
 		}
 	}
 
@@ -1622,7 +2162,9 @@ func (d *DRPCInstance) generateVRGSpecAsync() *rmn.VRGAsyncSpec {
 
 func (d *DRPCInstance) generateVRGSpecSync() *rmn.VRGSyncSpec {
 	if d.drType == DRTypeSync {
-		return &rmn.VRGSyncSpec{}
+		return &rmn.VRGSyncSpec{
+			ReplicationClassSelector: d.drPolicy.Spec.ReplicationClassSelector,
+		}
 	}
 
 	return nil
@@ -2114,6 +2656,36 @@ func (d *DRPCInstance) updateVRGToRunFinalSync(clusterName string) error {
 	return nil
 }
 
+func (d *DRPCInstance) updateVRGToCancelFinalSync(clusterName string) error {
+	d.log.Info(fmt.Sprintf("Updating VRG Spec to cancel the final sync on cluster %s", clusterName))
+
+	vrg, err := d.getVRGFromManifestWork(clusterName)
+	if err != nil {
+		return fmt.Errorf("failed to update VRG state. ClusterName %s (%w)",
+			clusterName, err)
+	}
+
+	if !vrg.Spec.PrepareForFinalSync && !vrg.Spec.RunFinalSync {
+		d.log.Info(fmt.Sprintf("VRG %s on cluster %s already has the final sync flags cleared",
+			vrg.Name, clusterName))
+
+		return nil
+	}
+
+	vrg.Spec.PrepareForFinalSync = false
+	vrg.Spec.RunFinalSync = false
+
+	err = d.updateManifestWork(clusterName, vrg)
+	if err != nil {
+		return err
+	}
+
+	d.log.Info(fmt.Sprintf("Updated VRG %s running in cluster %s to cancel the final sync",
+		vrg.Name, clusterName))
+
+	return nil
+}
+
 func (d *DRPCInstance) updateManifestWork(clusterName string, vrg *rmn.VolumeReplicationGroup) error {
 	mw, err := d.mwu.FindManifestWorkByType(rmnutil.MWTypeVRG, clusterName)
 	if err != nil {
@@ -2202,6 +2774,7 @@ relocateProgressions are used to indicate progression during relocate action pro
 		rmn.ProgressionPreparingFinalSync,
 		rmn.ProgressionClearingPlacement,
 		rmn.ProgressionRunningFinalSync,
+		rmn.ProgressionFinalSyncTimedOut,
 		rmn.ProgressionFinalSyncComplete,
 		rmn.ProgressionEnsuringVolumesAreSecondary,
 		rmn.ProgressionWaitOnUserToCleanUp,
@@ -2233,6 +2806,7 @@ func IsPreRelocateProgression(status rmn.ProgressionStatus) bool {
 		rmn.ProgressionPreparingFinalSync,
 		rmn.ProgressionClearingPlacement,
 		rmn.ProgressionRunningFinalSync,
+		rmn.ProgressionFinalSyncTimedOut,
 		rmn.ProgressionFinalSyncComplete,
 		rmn.ProgressionEnsuringVolumesAreSecondary,
 	}
@@ -2280,6 +2854,11 @@ func (d *DRPCInstance) shouldUpdateStatus() bool {
 		return true
 	}
 
+	if vrg.Status.GroupSyncsInProgress != d.instance.Status.GroupSyncsInProgress ||
+		!vrg.Status.EstimatedGroupSyncCompletionTime.Equal(d.instance.Status.EstimatedGroupSyncCompletionTime) {
+		return true
+	}
+
 	if vrg.Status.KubeObjectProtection.CaptureToRecoverFrom != nil {
 		vrgKubeObjectProtectionTime := vrg.Status.KubeObjectProtection.CaptureToRecoverFrom.EndTime
 		if !vrgKubeObjectProtectionTime.Equal(d.instance.Status.LastKubeObjectProtectionTime) {
@@ -2321,6 +2900,14 @@ func (d *DRPCInstance) reportEvent(nextState rmn.DRState) {
 		eventReason = rmnutil.EventReasonRelocationSuccess
 		eventType = corev1.EventTypeNormal
 		msg = "Successfully relocated the application and VRG"
+	case rmn.TestingFailover:
+		eventReason = rmnutil.EventReasonTestingFailover
+		eventType = corev1.EventTypeNormal
+		msg = "Restoring a test copy of the application"
+	case rmn.TestedFailover:
+		eventReason = rmnutil.EventReasonTestFailoverSuccess
+		eventType = corev1.EventTypeNormal
+		msg = "Successfully restored a test copy of the application"
 	}
 
 	rmnutil.ReportIfNotPresent(d.reconciler.eventRecorder, d.instance, eventType,
@@ -2347,6 +2934,8 @@ func (d *DRPCInstance) isInFinalPhase() bool {
 	case rmn.FailedOver:
 		fallthrough
 	case rmn.Relocated:
+		fallthrough
+	case rmn.TestedFailover:
 		return true
 	default:
 		return false
@@ -2363,6 +2952,8 @@ func (d *DRPCInstance) isInProgressingPhase() bool {
 	case rmn.FailingOver:
 		fallthrough
 	case rmn.Relocating:
+		fallthrough
+	case rmn.TestingFailover:
 		return true
 	default:
 		return false
@@ -2411,7 +3002,8 @@ func (d *DRPCInstance) setStatusInitiating() {
 		d.instance.Status.Phase == rmn.WaitForUser ||
 		d.instance.Status.Phase == rmn.Deployed ||
 		d.instance.Status.Phase == rmn.FailedOver ||
-		d.instance.Status.Phase == rmn.Relocated) {
+		d.instance.Status.Phase == rmn.Relocated ||
+		d.instance.Status.Phase == rmn.TestedFailover) {
 		return
 	}
 
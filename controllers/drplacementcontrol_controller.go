@@ -1264,6 +1264,12 @@ func (r *DRPlacementControlReconciler) finalizeDRPC(ctx context.Context, drpc *r
 		}
 	}
 
+	// Cleanup volsync secret-related manifestworks
+	err = volsync.CleanupSecretPropagationManifestWorks(&mwu, rmnutil.DRPolicyClusterNames(drPolicy))
+	if err != nil {
+		return fmt.Errorf("failed to clean up volsync secret-related manifestworks (%w)", err)
+	}
+
 	if len(vrgs) != 0 {
 		return fmt.Errorf("waiting for VRGs count to go to zero")
 	}
@@ -1876,6 +1882,9 @@ func (r *DRPlacementControlReconciler) updateResourceCondition(
 		drpc.Status.LastGroupSyncBytes = vrg.Status.LastGroupSyncBytes
 	}
 
+	drpc.Status.GroupSyncsInProgress = vrg.Status.GroupSyncsInProgress
+	drpc.Status.EstimatedGroupSyncCompletionTime = vrg.Status.EstimatedGroupSyncCompletionTime
+
 	if vrg.Status.KubeObjectProtection.CaptureToRecoverFrom != nil {
 		drpc.Status.LastKubeObjectProtectionTime = &vrg.Status.KubeObjectProtection.CaptureToRecoverFrom.EndTime
 	}
@@ -1907,6 +1916,13 @@ func (r *DRPlacementControlReconciler) clusterForVRGStatus(
 			return clusterName
 		}
 
+		// An aborted relocation places the workload back on the cluster it was relocated from
+		if drpc.Status.FinalSync != nil && drpc.Status.FinalSync.Result == rmn.FinalSyncResultAborted {
+			log.Info("Using ClusterDecision, relocation aborted on a final sync timeout", "Cluster", clusterName)
+
+			return clusterName
+		}
+
 		// We will inspect VRG from the non-preferredCluster until it reports Secondary, and then switch to the
 		// preferredCluster. This is done using Status.Progression for the DRPC
 		if IsPreRelocateProgression(drpc.Status.Progression) {
@@ -2514,16 +2530,19 @@ func (r *DRPlacementControlReconciler) determineDRPCState(
 		return Stop, msg, nil
 	}
 
-	// IF 2 clusters queried, and both queries failed, then STOP
+	allClustersQueried := successfullyQueriedClusterCount == len(drClusters)
+	allButOneClusterQueried := successfullyQueriedClusterCount == len(drClusters)-1
+
+	// IF all clusters queried, and all queries failed, then STOP
 	if successfullyQueriedClusterCount == 0 {
 		msg := "Stop - Number of clusters queried is 0"
 
 		return Stop, msg, nil
 	}
 
-	// IF 2 clusters queried successfully and no VRGs, then continue with initial deployment
-	if successfullyQueriedClusterCount == 2 && len(vrgs) == 0 {
-		log.Info("Queried 2 clusters successfully")
+	// IF all clusters queried successfully and no VRGs, then continue with initial deployment
+	if allClustersQueried && len(vrgs) == 0 {
+		log.Info("Queried all clusters successfully", "count", successfullyQueriedClusterCount)
 
 		return Continue, "", nil
 	}
@@ -2536,12 +2555,12 @@ func (r *DRPlacementControlReconciler) determineDRPCState(
 		return Continue, "", nil
 	}
 
-	// IF queried 2 clusters queried, 1 failed and 0 VRG found, then check s3 store.
+	// IF all clusters queried, 1 failed and 0 VRG found, then check s3 store.
 	// IF the VRG found in the s3 store, ensure that the DRPC action and the VRG action match. IF not, stop until
 	// the action is corrected, but allow failover to take place if needed (set PeerReady)
 	// If the VRG is not found in the s3 store and the failedCluster is not the destination cluster, then continue
 	// with initial deploy
-	if successfullyQueriedClusterCount == 1 && len(vrgs) == 0 {
+	if allButOneClusterQueried && len(vrgs) == 0 {
 		vrg := GetLastKnownVRGPrimaryFromS3(ctx, r.APIReader,
 			AvailableS3Profiles(drClusters), drpc.GetName(), vrgNamespace, r.ObjStoreGetter, log)
 		if vrg == nil {
@@ -2579,10 +2598,10 @@ func (r *DRPlacementControlReconciler) determineDRPCState(
 		return AllowFailover, msg, nil
 	}
 
-	// IF 2 clusters queried, 1 failed and 1 VRG found on the failover cluster, then check the action, if they don't
+	// IF all clusters queried, 1 failed and 1 VRG found on the failover cluster, then check the action, if they don't
 	// match, stop until corrected by the user. If they do match, then also stop but allow failover if the VRG in-hand
 	// is a secondary. Othewise, continue...
-	if successfullyQueriedClusterCount == 1 && len(vrgs) == 1 {
+	if allButOneClusterQueried && len(vrgs) == 1 {
 		var clusterName string
 
 		var vrg *rmn.VolumeReplicationGroup
@@ -2617,10 +2636,10 @@ func (r *DRPlacementControlReconciler) determineDRPCState(
 		return Continue, "", nil
 	}
 
-	// Finally, IF 2 clusters queried successfully and 1 or more VRGs found, and if one of the VRGs is on the dstCluster,
+	// Finally, IF all clusters queried successfully and 1 or more VRGs found, and if one of the VRGs is on the dstCluster,
 	// then continue with action if and only if DRPC and the found VRG action match. otherwise, stop until someone
 	// investigates but allow failover to take place (set PeerReady)
-	if successfullyQueriedClusterCount == 2 && len(vrgs) >= 1 {
+	if allClustersQueried && len(vrgs) >= 1 {
 		var clusterName string
 
 		var vrg *rmn.VolumeReplicationGroup
@@ -2633,8 +2652,8 @@ func (r *DRPlacementControlReconciler) determineDRPCState(
 		}
 
 		// This can happen if a hub is recovered in the middle of a Relocate
-		if vrg.Spec.ReplicationState == rmn.Secondary && len(vrgs) == 2 {
-			msg := "Stop - Both VRGs have the same secondary state"
+		if vrg.Spec.ReplicationState == rmn.Secondary && len(vrgs) > 1 {
+			msg := "Stop - All VRGs have the same secondary state"
 
 			return Stop, msg, nil
 		}
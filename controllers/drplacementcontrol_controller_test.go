@@ -42,20 +42,22 @@ import (
 )
 
 const (
-	DRPCCommonName        = "drpc-name"
-	DefaultDRPCNamespace  = "drpc-namespace"
-	ApplicationNamespace  = "vrg-namespace"
-	DRPC2Name             = "drpc-name2"
-	DRPC2NamespaceName    = "drpc-namespace2"
-	UserPlacementRuleName = "user-placement-rule"
-	UserPlacementName     = "user-placement"
-	East1ManagedCluster   = "east1-cluster"
-	East2ManagedCluster   = "east2-cluster"
-	West1ManagedCluster   = "west1-cluster"
-	AsyncDRPolicyName     = "my-async-dr-peers"
-	SyncDRPolicyName      = "my-sync-dr-peers"
-	MModeReplicationID    = "storage-replication-id-1"
-	MModeCSIProvisioner   = "test.csi.com"
+	DRPCCommonName         = "drpc-name"
+	DefaultDRPCNamespace   = "drpc-namespace"
+	ApplicationNamespace   = "vrg-namespace"
+	DRPC2Name              = "drpc-name2"
+	DRPC2NamespaceName     = "drpc-namespace2"
+	UserPlacementRuleName  = "user-placement-rule"
+	UserPlacementName      = "user-placement"
+	East1ManagedCluster    = "east1-cluster"
+	East2ManagedCluster    = "east2-cluster"
+	West1ManagedCluster    = "west1-cluster"
+	Central1ManagedCluster = "central1-cluster"
+	AsyncDRPolicyName      = "my-async-dr-peers"
+	FanOutDRPolicyName     = "my-fan-out-dr-peers"
+	SyncDRPolicyName       = "my-sync-dr-peers"
+	MModeReplicationID     = "storage-replication-id-1"
+	MModeCSIProvisioner    = "test.csi.com"
 
 	pvcCount = 2 // Count of fake PVCs reported in the VRG status
 )
@@ -93,8 +95,19 @@ var (
 		},
 	}
 
-	asyncClusters = []*spokeClusterV1.ManagedCluster{west1Cluster, east1Cluster}
-	syncClusters  = []*spokeClusterV1.ManagedCluster{east1Cluster, east2Cluster}
+	central1Cluster = &spokeClusterV1.ManagedCluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: Central1ManagedCluster,
+			Labels: map[string]string{
+				"name": Central1ManagedCluster,
+				"key1": "central1",
+			},
+		},
+	}
+
+	asyncClusters  = []*spokeClusterV1.ManagedCluster{west1Cluster, east1Cluster}
+	syncClusters   = []*spokeClusterV1.ManagedCluster{east1Cluster, east2Cluster}
+	fanOutClusters = []*spokeClusterV1.ManagedCluster{west1Cluster, east1Cluster, central1Cluster}
 
 	east1ManagedClusterNamespace = &corev1.Namespace{
 		ObjectMeta: metav1.ObjectMeta{Name: East1ManagedCluster},
@@ -116,6 +129,10 @@ var (
 		ObjectMeta: metav1.ObjectMeta{Name: East2ManagedCluster},
 	}
 
+	central1ManagedClusterNamespace = &corev1.Namespace{
+		ObjectMeta: metav1.ObjectMeta{Name: Central1ManagedCluster},
+	}
+
 	schedulingInterval = "1h"
 
 	drClusters = []rmn.DRCluster{}
@@ -135,6 +152,16 @@ var (
 		},
 	}
 
+	fanOutDRPolicy = &rmn.DRPolicy{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: FanOutDRPolicyName,
+		},
+		Spec: rmn.DRPolicySpec{
+			DRClusters:         []string{East1ManagedCluster, West1ManagedCluster, Central1ManagedCluster},
+			SchedulingInterval: schedulingInterval,
+		},
+	}
+
 	syncDRPolicy = &rmn.DRPolicy{
 		ObjectMeta: metav1.ObjectMeta{
 			Name: SyncDRPolicyName,
@@ -379,6 +406,26 @@ func isRestorePVsComplete() bool {
 	return restorePVs
 }
 
+var finalSync = true
+
+func setFinalSyncComplete() {
+	finalSync = true
+}
+
+func setFinalSyncUncomplete() {
+	finalSync = false
+}
+
+func isFinalSyncComplete() bool {
+	return finalSync
+}
+
+var protectedByVolSync bool // default false, PVCs are protected by VolumeReplication
+
+func setProtectedByVolSync(volSync bool) {
+	protectedByVolSync = volSync
+}
+
 var ClusterIsDown string
 
 func setClusterDown(clusterName string) {
@@ -525,7 +572,7 @@ func getVRGFromManifestWork(managedCluster, resourceNamespace string) (*rmn.Volu
 	}
 
 	vrg.Status.PrepareForFinalSyncComplete = true
-	vrg.Status.FinalSyncComplete = true
+	vrg.Status.FinalSyncComplete = isFinalSyncComplete()
 	vrg.Status.ProtectedPVCs = []rmn.ProtectedPVC{}
 
 	for i := 0; i < pvcCount; i++ {
@@ -534,10 +581,22 @@ func getVRGFromManifestWork(managedCluster, resourceNamespace string) (*rmn.Volu
 		protectedPVC.StorageIdentifiers.ReplicationID.ID = MModeReplicationID
 		protectedPVC.StorageIdentifiers.StorageProvisioner = MModeCSIProvisioner
 		protectedPVC.StorageIdentifiers.ReplicationID.Modes = []rmn.MMode{rmn.MModeFailover}
+		protectedPVC.ProtectedByVolSync = protectedByVolSync
 
 		vrg.Status.ProtectedPVCs = append(vrg.Status.ProtectedPVCs, protectedPVC)
 	}
 
+	if vrg.Spec.TestFailover != nil {
+		vrg.Status.Conditions = append(vrg.Status.Conditions, metav1.Condition{
+			Type:               controllers.VRGConditionTypeTestFailover,
+			Reason:             controllers.VRGConditionReasonTestFailoverRestored,
+			Status:             metav1.ConditionTrue,
+			Message:            "Test failover restored",
+			LastTransitionTime: metav1.Now(),
+			ObservedGeneration: vrg.Generation,
+		})
+	}
+
 	// Always report conditions as a success?
 	vrg.Status.Conditions = append(vrg.Status.Conditions, metav1.Condition{
 		Type:               controllers.VRGConditionTypeClusterDataProtected,
@@ -676,6 +735,7 @@ func createDRPC(placementName, name, namespace, drPolicyName, preferredCluster s
 					"environment": "dev.AZ1",
 				},
 			},
+			PVCFilter:            &rmn.PVCFilter{ExcludedStorageClassNames: []string{"scratch"}},
 			KubeObjectProtection: &rmn.KubeObjectProtectionSpec{},
 			PreferredCluster:     preferredCluster,
 		},
@@ -748,6 +808,132 @@ func setDRPCSpecExpectationTo(namespace, preferredCluster, failoverCluster strin
 	}, timeout, interval).Should(BeTrue(), "failed to update DRPC DR action on time")
 }
 
+func setDRPCFinalSyncTimeout(namespace string, timeout *metav1.Duration, action rmn.FinalSyncTimeoutAction) {
+	drpcLookupKey := types.NamespacedName{
+		Name:      DRPCCommonName,
+		Namespace: namespace,
+	}
+	latestDRPC := &rmn.DRPlacementControl{}
+	retryErr := retry.RetryOnConflict(retry.DefaultBackoff, func() error {
+		err := k8sClient.Get(context.TODO(), drpcLookupKey, latestDRPC)
+		if err != nil {
+			return err
+		}
+
+		latestDRPC.Spec.FinalSyncTimeout = timeout
+		latestDRPC.Spec.FinalSyncTimeoutAction = action
+
+		return k8sClient.Update(context.TODO(), latestDRPC)
+	})
+
+	Expect(retryErr).NotTo(HaveOccurred())
+}
+
+func setDRPCTestFailover(namespace string, testFailover *rmn.TestFailoverSpec) {
+	drpcLookupKey := types.NamespacedName{
+		Name:      DRPCCommonName,
+		Namespace: namespace,
+	}
+	latestDRPC := &rmn.DRPlacementControl{}
+	retryErr := retry.RetryOnConflict(retry.DefaultBackoff, func() error {
+		err := k8sClient.Get(context.TODO(), drpcLookupKey, latestDRPC)
+		if err != nil {
+			return err
+		}
+
+		latestDRPC.Spec.TestFailover = testFailover
+
+		return k8sClient.Update(context.TODO(), latestDRPC)
+	})
+
+	Expect(retryErr).NotTo(HaveOccurred())
+}
+
+func verifyTestFailoverRejected(placementObj client.Object, homeCluster, testCluster string, drState rmn.DRState) {
+	Eventually(func() bool {
+		drpc := getLatestDRPC(placementObj.GetNamespace())
+		_, condition := getDRPCCondition(&drpc.Status, rmn.ConditionAvailable)
+
+		return condition != nil && condition.Reason == rmn.ReasonTestFailoverUnsupported &&
+			strings.Contains(condition.Message, "test failover is not supported")
+	}, timeout, interval).Should(BeTrue(), "failed to reject the test failover of VolumeReplication protected PVCs")
+
+	Expect(getLatestDRPC(placementObj.GetNamespace()).Status.Phase).To(Equal(drState))
+	verifyUserPlacementRuleDecisionUnchanged(placementObj.GetName(), placementObj.GetNamespace(), homeCluster)
+
+	vrg, err := getVRGFromManifestWork(testCluster, placementObj.GetNamespace())
+	Expect(err).NotTo(HaveOccurred())
+	Expect(vrg.Spec.TestFailover).To(BeNil())
+}
+
+func verifyTestFailover(placementObj client.Object, homeCluster, testCluster string,
+	testFailover *rmn.TestFailoverSpec,
+) {
+	Eventually(func() bool {
+		drpc := getLatestDRPC(placementObj.GetNamespace())
+
+		return drpc.Status.Phase == rmn.TestedFailover && drpc.Status.Progression == rmn.ProgressionCompleted
+	}, timeout, interval).Should(BeTrue(), "failed to complete the test failover")
+
+	verifyUserPlacementRuleDecisionUnchanged(placementObj.GetName(), placementObj.GetNamespace(), homeCluster)
+
+	vrg, err := getVRGFromManifestWork(testCluster, placementObj.GetNamespace())
+	Expect(err).NotTo(HaveOccurred())
+	Expect(vrg.Spec.ReplicationState).To(Equal(rmn.Secondary))
+	Expect(vrg.Spec.TestFailover).To(Equal(testFailover))
+
+	vrg, err = getVRGFromManifestWork(homeCluster, placementObj.GetNamespace())
+	Expect(err).NotTo(HaveOccurred())
+	Expect(vrg.Spec.ReplicationState).To(Equal(rmn.Primary))
+	Expect(vrg.Spec.TestFailover).To(BeNil())
+}
+
+func verifyTestFailoverCleanedUp(placementObj client.Object, testCluster string, drState rmn.DRState) {
+	Eventually(func() bool {
+		vrg, err := getVRGFromManifestWork(testCluster, placementObj.GetNamespace())
+
+		return err == nil && vrg.Spec.TestFailover == nil
+	}, timeout, interval).Should(BeTrue(), "failed to remove the test failover request from the VRG")
+
+	Eventually(func() bool {
+		drpc := getLatestDRPC(placementObj.GetNamespace())
+
+		return drpc.Status.Phase == drState
+	}, timeout, interval).Should(BeTrue(), "failed to return to the prior state after the test failover")
+}
+
+func verifyRelocationAbortedOnFinalSyncTimeout(placementObj client.Object, homeCluster string) {
+	Eventually(func() bool {
+		drpc := getLatestDRPC(placementObj.GetNamespace())
+
+		return drpc.Status.FinalSync != nil && drpc.Status.FinalSync.TimedOut &&
+			drpc.Status.FinalSync.Result == rmn.FinalSyncResultAborted &&
+			drpc.Status.Progression == rmn.ProgressionActionPaused
+	}, timeout, interval).Should(BeTrue(), "failed to abort the relocation on a final sync timeout")
+
+	verifyUserPlacementRuleDecision(placementObj.GetName(), placementObj.GetNamespace(), homeCluster)
+
+	vrg, err := getVRGFromManifestWork(homeCluster, placementObj.GetNamespace())
+	Expect(err).NotTo(HaveOccurred())
+	Expect(vrg.Spec.ReplicationState).To(Equal(rmn.Primary))
+	Expect(vrg.Spec.PrepareForFinalSync).To(BeFalse())
+	Expect(vrg.Spec.RunFinalSync).To(BeFalse())
+
+	// The workload restarts from the PVCs kept on the home cluster, and is not restored on the target cluster
+	targetVRG, err := getVRGFromManifestWork(getLatestDRPC(placementObj.GetNamespace()).Spec.PreferredCluster,
+		placementObj.GetNamespace())
+	if err == nil {
+		Expect(targetVRG.Spec.ReplicationState).To(Equal(rmn.Secondary))
+	} else {
+		Expect(errors.IsNotFound(err)).To(BeTrue())
+	}
+
+	Consistently(func() rmn.ProgressionStatus {
+		return getLatestDRPC(placementObj.GetNamespace()).Status.Progression
+	}, 2*time.Second, interval).Should(Equal(rmn.ProgressionActionPaused))
+	verifyUserPlacementRuleDecision(placementObj.GetName(), placementObj.GetNamespace(), homeCluster)
+}
+
 func getLatestDRPC(namespace string) *rmn.DRPlacementControl {
 	drpcLookupKey := types.NamespacedName{
 		Name:      DRPCCommonName,
@@ -862,6 +1048,15 @@ func populateDRClusters() {
 			}},
 			Spec: rmn.DRClusterSpec{S3ProfileName: s3Profiles[0].S3ProfileName, Region: "east", CIDRs: cidrs[1]},
 		},
+		rmn.DRCluster{
+			ObjectMeta: metav1.ObjectMeta{Name: Central1ManagedCluster, Annotations: map[string]string{
+				"drcluster.ramendr.openshift.io/storage-secret-name":      "tmp3",
+				"drcluster.ramendr.openshift.io/storage-secret-namespace": "tmp3",
+				"drcluster.ramendr.openshift.io/storage-clusterid":        "tmp3",
+				"drcluster.ramendr.openshift.io/storage-driver":           "tmp3.storage.com",
+			}},
+			Spec: rmn.DRClusterSpec{S3ProfileName: s3Profiles[0].S3ProfileName, Region: "central"},
+		},
 	)
 }
 
@@ -1212,6 +1407,7 @@ func verifyVRGManifestWorkCreatedAsPrimary(namespace, managedCluster string) {
 	// ensure DRPC copied KubeObjectProtection contents to VRG
 	drpc := getLatestDRPC(namespace)
 	Expect(vrg.Spec.KubeObjectProtection).Should(Equal(drpc.Spec.KubeObjectProtection))
+	Expect(vrg.Spec.PVCFilter).Should(Equal(drpc.Spec.PVCFilter))
 }
 
 func getManifestWorkCount(homeClusterNamespace string) int {
@@ -1864,6 +2060,9 @@ var _ = Describe("DRPlacementControl Reconciler", func() {
 	Context("DRPlacementControl Reconciler Async DR using PlacementRule (Subscription)", func() {
 		var userPlacementRule *plrv1.PlacementRule
 		var drpc *rmn.DRPlacementControl
+		testFailoverSpec := &rmn.TestFailoverSpec{
+			NamespaceMapping: map[string]string{DefaultDRPCNamespace: DefaultDRPCNamespace + "-test"},
+		}
 		When("An Application is deployed for the first time", func() {
 			It("Should deploy to East1ManagedCluster", func() {
 				By("Initial Deployment")
@@ -1914,6 +2113,18 @@ var _ = Describe("DRPlacementControl Reconciler", func() {
 				runFailoverAction(userPlacementRule, East1ManagedCluster, West1ManagedCluster, false, false)
 			})
 		})
+		When("DRAction is set to Relocate and the final sync times out", func() {
+			It("Should abort the relocation and restore the workload on Secondary (West1ManagedCluster)", func() {
+				By("\n\n*** Relocate aborted\n\n")
+				setFinalSyncUncomplete()
+				setDRPCFinalSyncTimeout(DefaultDRPCNamespace, &metav1.Duration{Duration: time.Second},
+					rmn.FinalSyncTimeoutActionAbort)
+				setDRPCSpecExpectationTo(DefaultDRPCNamespace, East1ManagedCluster, West1ManagedCluster, rmn.ActionRelocate)
+				verifyRelocationAbortedOnFinalSyncTimeout(userPlacementRule, West1ManagedCluster)
+				setFinalSyncComplete()
+				setDRPCFinalSyncTimeout(DefaultDRPCNamespace, nil, "")
+			})
+		})
 		When("DRAction is set to Relocate", func() {
 			It("Should relocate to Primary (East1ManagedCluster)", func() {
 				// ----------------------------- RELOCATION TO PRIMARY --------------------------------------
@@ -1921,6 +2132,28 @@ var _ = Describe("DRPlacementControl Reconciler", func() {
 				runRelocateAction(userPlacementRule, West1ManagedCluster, false, false)
 			})
 		})
+		When("DRAction is set to TestFailover with VolumeReplication protected PVCs", func() {
+			It("Should not start the test failover on Secondary (West1ManagedCluster)", func() {
+				setDRPCTestFailover(DefaultDRPCNamespace, testFailoverSpec)
+				setDRPCSpecExpectationTo(DefaultDRPCNamespace, East1ManagedCluster, West1ManagedCluster,
+					rmn.ActionTestFailover)
+				verifyTestFailoverRejected(userPlacementRule, East1ManagedCluster, West1ManagedCluster, rmn.Relocated)
+			})
+		})
+		When("DRAction is set to TestFailover with VolSync protected PVCs", func() {
+			It("Should restore a test copy on Secondary (West1ManagedCluster) only", func() {
+				setProtectedByVolSync(true)
+				verifyTestFailover(userPlacementRule, East1ManagedCluster, West1ManagedCluster, testFailoverSpec)
+			})
+		})
+		When("DRAction is changed from TestFailover", func() {
+			It("Should clean up the test copy on Secondary (West1ManagedCluster)", func() {
+				setDRPCSpecExpectationTo(DefaultDRPCNamespace, East1ManagedCluster, West1ManagedCluster, rmn.ActionRelocate)
+				verifyTestFailoverCleanedUp(userPlacementRule, West1ManagedCluster, rmn.Relocated)
+				setDRPCTestFailover(DefaultDRPCNamespace, nil)
+				setProtectedByVolSync(false)
+			})
+		})
 		When("Get VRG from s3 store", func() {
 			It("Should get the latest primary VRG from s3 stores", func() {
 				ensureLatestVRGDownloadedFromS3Stores()
@@ -2495,6 +2728,83 @@ var _ = Describe("DRPlacementControl Reconciler", func() {
 		})
 	})
 
+	Context("DRPlacementControl Reconciler HubRecovery with 3 clusters (Subscription)", func() {
+		var userPlacementRule1 *plrv1.PlacementRule
+		var drpc1 *rmn.DRPlacementControl
+
+		Specify("DRClusters", func() {
+			populateDRClusters()
+		})
+		When("Application deployed for the first time", func() {
+			It("Should deploy drpc", func() {
+				createNamespacesAsync(getNamespaceObj(DefaultDRPCNamespace))
+				createNamespace(central1ManagedClusterNamespace)
+				createManagedClusters(fanOutClusters)
+				createDRClusters(fanOutClusters)
+				createDRPolicy(fanOutDRPolicy.DeepCopy())
+
+				userPlacementRule1 = createPlacementRule(UserPlacementRuleName, DefaultDRPCNamespace)
+				drpc1 = createDRPC(UserPlacementRuleName, DRPCCommonName, DefaultDRPCNamespace, FanOutDRPolicyName,
+					East1ManagedCluster)
+				verifyInitialDRPCDeployment(userPlacementRule1, East1ManagedCluster)
+				uploadVRGtoS3Store(DRPCCommonName, DefaultDRPCNamespace, East1ManagedCluster, rmn.VRGAction(""))
+			})
+			It("Should report VolumeReplication protected PVCs as not protected on all peers", func() {
+				Eventually(func() bool {
+					_, condition := getDRPCCondition(&getLatestDRPC(DefaultDRPCNamespace).Status, rmn.ConditionProtected)
+
+					return condition != nil && condition.Status == metav1.ConditionFalse &&
+						condition.Reason == rmn.ReasonProtectedError
+				}, timeout, interval).Should(BeTrue())
+			})
+		})
+		When("HubRecovery: DRAction is Initial deploy -> one Secondary Down", func() {
+			It("Should reconstructs the DRPC state to completion. Primary is East1ManagedCluster", func() {
+				setClusterDown(West1ManagedCluster)
+				clearFakeUserPlacementRuleStatus(UserPlacementRuleName, DefaultDRPCNamespace)
+				clearDRPCStatus()
+				verifyDRPCStateAndProgression(rmn.DRAction(""), rmn.Deployed, rmn.ProgressionCompleted)
+				resetClusterDown()
+			})
+		})
+		When("HubRecovery: DRAction is Initial deploy -> Primary Down", func() {
+			It("Should pause and wait for user to trigger a failover, not deploy again to a Secondary", func() {
+				setClusterDown(East1ManagedCluster)
+				clearFakeUserPlacementRuleStatus(UserPlacementRuleName, DefaultDRPCNamespace)
+				clearDRPCStatus()
+				verifyDRPCStateAndProgression(rmn.DRAction(""), rmn.WaitForUser, rmn.ProgressionActionPaused)
+				checkConditionAllowFailover(DefaultDRPCNamespace)
+				for _, cluster := range []string{West1ManagedCluster, Central1ManagedCluster} {
+					_, err := getVRGFromManifestWork(cluster, DefaultDRPCNamespace)
+					Expect(errors.IsNotFound(err)).To(BeTrue())
+				}
+				resetClusterDown()
+			})
+		})
+
+		When("Deleting DRPolicy with DRPC references", func() {
+			It("Should retain the deleted DRPolicy in the API server", func() {
+				By("\n\n*** DELETE drpolicy ***\n\n")
+				Expect(k8sClient.Delete(context.TODO(), fanOutDRPolicy)).To(Succeed())
+			})
+		})
+		When("Deleting user PlacementRule", func() {
+			It("Should cleanup DRPC", func() {
+				By("\n\n*** DELETE User PlacementRule ***\n\n")
+				deleteUserPlacementRule(UserPlacementRuleName, DefaultDRPCNamespace)
+			})
+		})
+		When("Deleting DRPC", func() {
+			It("Should delete all VRGs", func() {
+				Expect(k8sClient.Delete(context.TODO(), drpc1)).Should(Succeed())
+				deleteNamespaceMWsFromAllClusters(DefaultDRPCNamespace)
+			})
+		})
+		Specify("delete drclusters", func() {
+			deleteDRClusters(fanOutClusters)
+		})
+	})
+
 	Context("DRPlacementControl Reconciler HubRecovery VRG Adoption (Subscription)", func() {
 		var userPlacementRule1 *plrv1.PlacementRule
 		var drpc1 *rmn.DRPlacementControl
@@ -0,0 +1,71 @@
+// SPDX-FileCopyrightText: The RamenDR authors
+// SPDX-License-Identifier: Apache-2.0
+
+// white box testing desired for final sync timeout handling
+package controllers //nolint: testpackage
+
+import (
+	"time"
+
+	"github.com/go-logr/logr"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	rmn "github.com/ramendr/ramen/api/v1alpha1"
+)
+
+var _ = Describe("DRPC_FinalSync", func() {
+	const homeCluster = "cluster1"
+
+	drpcInstance := func(action rmn.FinalSyncTimeoutAction, result rmn.FinalSyncResult) *DRPCInstance {
+		return &DRPCInstance{
+			instance: &rmn.DRPlacementControl{
+				Spec: rmn.DRPlacementControlSpec{
+					Action:                 rmn.ActionRelocate,
+					FinalSyncTimeout:       &metav1.Duration{Duration: time.Minute},
+					FinalSyncTimeoutAction: action,
+				},
+				Status: rmn.DRPlacementControlStatus{
+					ActionStartTime: &metav1.Time{Time: time.Now().Add(-time.Hour)},
+					FinalSync: &rmn.FinalSyncStatus{
+						StartTime: &metav1.Time{Time: time.Now().Add(-time.Hour)},
+						TimedOut:  true,
+						Result:    result,
+					},
+				},
+			},
+			vrgs: map[string]*rmn.VolumeReplicationGroup{},
+			log:  logr.Discard(),
+		}
+	}
+
+	Context("finalSyncAborting", func() {
+		It("is true once the final sync timed out with the Abort action, until it has a result", func() {
+			Expect(drpcInstance(rmn.FinalSyncTimeoutActionAbort, "").finalSyncAborting()).To(BeTrue())
+			Expect(drpcInstance(rmn.FinalSyncTimeoutActionAbort, rmn.FinalSyncResultAborted).finalSyncAborting()).
+				To(BeFalse())
+			Expect(drpcInstance(rmn.FinalSyncTimeoutActionFailover, "").finalSyncAborting()).To(BeFalse())
+			Expect(drpcInstance("", "").finalSyncAborting()).To(BeFalse())
+		})
+	})
+
+	Context("abortRelocation", func() {
+		It("continues the relocation if the final sync completed before it was canceled", func() {
+			d := drpcInstance(rmn.FinalSyncTimeoutActionAbort, "")
+			d.vrgs[homeCluster] = &rmn.VolumeReplicationGroup{
+				Spec:   rmn.VolumeReplicationGroupSpec{RunFinalSync: true},
+				Status: rmn.VolumeReplicationGroupStatus{FinalSyncComplete: true},
+			}
+
+			aborted, err := d.abortRelocation(homeCluster)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(aborted).To(BeFalse())
+			Expect(d.instance.Status.FinalSync.Result).To(Equal(rmn.FinalSyncResultCompleted))
+			Expect(d.instance.Status.Progression).To(Equal(rmn.ProgressionFinalSyncComplete))
+			Expect(d.finalSyncCompleted()).To(BeTrue())
+			Expect(d.finalSyncAborting()).To(BeFalse())
+		})
+	})
+})
@@ -9,7 +9,10 @@ import (
 	rmn "github.com/ramendr/ramen/api/v1alpha1"
 	rmnutil "github.com/ramendr/ramen/controllers/util"
 	"github.com/ramendr/ramen/controllers/volsync"
+	"golang.org/x/exp/slices"
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
 func (d *DRPCInstance) EnsureVolSyncReplicationSetup(homeCluster string) error {
@@ -44,11 +47,14 @@ func (d *DRPCInstance) ensureVolSyncReplicationCommon(srcCluster string) error {
 	// Make sure we have Source and Destination VRGs - Source should already have been created at this point
 	d.setProgression(rmn.ProgressionEnsuringVolSyncSetup)
 
-	vrgMWCount := d.mwu.GetVRGManifestWorkCount(rmnutil.DRPolicyClusterNames(d.drPolicy))
+	drClusters := rmnutil.DRPolicyClusterNames(d.drPolicy)
+	vrgMWCount := d.mwu.GetVRGManifestWorkCount(drClusters)
 
-	const maxNumberOfVRGs = 2
-	if len(d.vrgs) != maxNumberOfVRGs || vrgMWCount != maxNumberOfVRGs {
-		// Create the destination VRG
+	// Replication proceeds once the VRGs of the source and a destination are created. The VRGs of the other
+	// destinations, such as those of clusters that are down, join as they are created.
+	const minNumberOfVRGs = 2
+	if len(d.vrgs) < minNumberOfVRGs || vrgMWCount != len(drClusters) {
+		// Create the destination VRGs
 		err := d.createVolSyncDestManifestWork(srcCluster)
 		if err != nil {
 			return err
@@ -74,6 +80,17 @@ func (d *DRPCInstance) ensureVolSyncReplicationCommon(srcCluster string) error {
 		return fmt.Errorf("%w", err)
 	}
 
+	err = volsync.RotateVolSyncReplicationSecret(d.ctx, d.reconciler.Client, pskSecretHub,
+		d.ramenConfig.VolSync.KeyRotationInterval.Duration,
+		d.volSyncKeysInUse(volsync.PSKIdentities(pskSecretHub)), d.log)
+	if err != nil {
+		d.log.Error(err, "Unable to rotate psk secret on hub for VolSync")
+
+		return fmt.Errorf("%w", err)
+	}
+
+	d.instance.Status.VolSyncKeyTime = volsync.PSKKeyTime(pskSecretHub)
+
 	// Propagate the secret to all clusters
 	// Note that VRG spec will not contain the psk secret name, we're going to name based on the VRG name itself
 	pskSecretNameCluster := volsync.GetVolSyncPSKSecretNameFromVRGName(d.instance.GetName()) // VRG name == DRPC name
@@ -83,8 +100,7 @@ func (d *DRPCInstance) ensureVolSyncReplicationCommon(srcCluster string) error {
 		clustersToPropagateSecret = append(clustersToPropagateSecret, clusterName)
 	}
 
-	err = volsync.PropagateSecretToClusters(d.ctx, d.reconciler.Client, pskSecretHub,
-		d.instance, clustersToPropagateSecret, pskSecretNameCluster, d.vrgNamespace, d.log)
+	err = d.propagateVolSyncSecret(pskSecretHub, clustersToPropagateSecret, pskSecretNameCluster)
 	if err != nil {
 		d.log.Error(err, "Error propagating secret to clusters", "clustersToPropagateSecret", clustersToPropagateSecret)
 
@@ -94,6 +110,57 @@ func (d *DRPCInstance) ensureVolSyncReplicationCommon(srcCluster string) error {
 	return nil
 }
 
+// propagateVolSyncSecret propagates the VolSync psk secret to clusters with the method selected by RamenConfig,
+// and then cleans up the propagation with the other method, if any
+func (d *DRPCInstance) propagateVolSyncSecret(pskSecretHub *corev1.Secret, clusters []string,
+	pskSecretNameCluster string,
+) error {
+	if d.ramenConfig.VolSync.SecretPropagation == rmn.VolSyncSecretPropagationManifestWork {
+		err := volsync.PropagateSecretToClustersWithManifestWork(&d.mwu, pskSecretHub, clusters,
+			pskSecretNameCluster, d.vrgNamespace)
+		if err != nil {
+			return err
+		}
+
+		return volsync.CleanupSecretPropagation(d.ctx, d.reconciler.Client, d.instance, d.log)
+	}
+
+	err := volsync.PropagateSecretToClusters(d.ctx, d.reconciler.Client, pskSecretHub,
+		d.instance, clusters, pskSecretNameCluster, d.vrgNamespace, d.log)
+	if err != nil {
+		return err
+	}
+
+	return volsync.CleanupSecretPropagationManifestWorks(&d.mwu, rmnutil.DRPolicyClusterNames(d.drPolicy))
+}
+
+// volSyncKeysInUse returns true if the VRGs of all clusters have observed the keys of the VolSync pre-shared key
+// secret, and all their PVCs have synced since. The rsync TLS movers read the keys when they start, and start
+// anew after each sync, so they have restarted with the keys.
+func (d *DRPCInstance) volSyncKeysInUse(identities []string) bool {
+	var lastGroupSyncTime *metav1.Time
+
+	for _, vrg := range d.vrgs {
+		if vrg.Spec.ReplicationState == rmn.Primary {
+			lastGroupSyncTime = vrg.Status.LastGroupSyncTime
+		}
+	}
+
+	if lastGroupSyncTime == nil {
+		return false
+	}
+
+	for _, vrg := range d.vrgs {
+		keys := vrg.Status.VolSyncKeys
+		if keys == nil || !slices.Equal(keys.Identities, identities) ||
+			!keys.ObservedTime.Before(lastGroupSyncTime) {
+			return false
+		}
+	}
+
+	return true
+}
+
 func (d *DRPCInstance) ensureVolSyncReplicationDestination(srcCluster string) error {
 	d.setProgression(rmn.ProgressionSettingupVolsyncDest)
 
@@ -128,16 +195,72 @@ func (d *DRPCInstance) ensureVolSyncReplicationDestination(srcCluster string) er
 		}
 
 		d.log.Info(fmt.Sprintf("Ensured VolSync replication destination for cluster %s", dstCluster))
-		// TODO: Should we handle more than one dstVRG? For now, just settle for one.
-		break
 	}
 
+	return d.ensureVolSyncRDNodePorts(srcCluster)
+}
+
+// ensureVolSyncRDNodePorts passes the node ports of the NodePort services of the ReplicationDestinations, reported
+// by the VRGs of the destination clusters, to the VRG of the source cluster for its ReplicationSources to sync to
+// them. Those of a destination cluster whose VRG is not reported are kept.
+func (d *DRPCInstance) ensureVolSyncRDNodePorts(srcCluster string) error {
+	srcVRG, err := d.getVRGFromManifestWork(srcCluster)
+	if err != nil {
+		if errors.IsNotFound(err) {
+			return nil
+		}
+
+		return fmt.Errorf("failed to get source VolSync VRG from ManifestWork for cluster %s (%w)", srcCluster, err)
+	}
+
+	if srcVRG.Spec.ReplicationState != rmn.Primary {
+		return nil
+	}
+
+	nodePorts := []rmn.VolSyncNodePort{}
+
+	for _, dstCluster := range rmnutil.DRPolicyClusterNames(d.drPolicy) {
+		if dstCluster == srcCluster {
+			continue
+		}
+
+		dstVRG, found := d.vrgs[dstCluster]
+		if !found {
+			for _, nodePort := range srcVRG.Spec.VolSync.RDNodePorts {
+				if nodePort.Cluster == dstCluster {
+					nodePorts = append(nodePorts, nodePort)
+				}
+			}
+
+			continue
+		}
+
+		for _, nodePort := range dstVRG.Status.VolSyncRDNodePorts {
+			nodePort.Cluster = dstCluster
+			nodePorts = append(nodePorts, nodePort)
+		}
+	}
+
+	if slices.Equal(srcVRG.Spec.VolSync.RDNodePorts, nodePorts) {
+		return nil
+	}
+
+	srcVRG.Spec.VolSync.RDNodePorts = nodePorts
+
+	if err := d.updateManifestWork(srcCluster, srcVRG); err != nil {
+		return fmt.Errorf("failed to update source VolSync VRG node ports on cluster %s (%w)", srcCluster, err)
+	}
+
+	d.log.Info("Updated ReplicationDestination node ports of VRG", "cluster", srcCluster, "nodePorts", nodePorts)
+
 	return nil
 }
 
 // containsMismatchVolSyncPVCs returns true if a VolSync protected pvc in the source VRG is not
-// found in the destination VRG RDSpecs.  Since we never delete protected PVCS from the source VRG,
-// we don't check for other case - a protected PVC in destination not found in the source.
+// found in the destination VRG RDSpecs, or requests more storage than its RDSpec, as it was expanded. The
+// ReplicationDestination, and its PVC, are then expanded once the RDSpec is updated. Since we never delete
+// protected PVCS from the source VRG, we don't check for other case - a protected PVC in destination not found
+// in the source.
 func (d *DRPCInstance) containsMismatchVolSyncPVCs(srcVRG *rmn.VolumeReplicationGroup,
 	dstVRG *rmn.VolumeReplicationGroup,
 ) bool {
@@ -146,15 +269,20 @@ func (d *DRPCInstance) containsMismatchVolSyncPVCs(srcVRG *rmn.VolumeReplication
 			continue
 		}
 
-		for _, rdSpec := range dstVRG.Spec.VolSync.RDSpec {
-			if protectedPVC.Name == rdSpec.ProtectedPVC.Name &&
-				protectedPVC.Namespace == rdSpec.ProtectedPVC.Namespace {
-				return false
-			}
-		}
+		i := slices.IndexFunc(dstVRG.Spec.VolSync.RDSpec, func(rdSpec rmn.VolSyncReplicationDestinationSpec) bool {
+			return protectedPVC.Name == rdSpec.ProtectedPVC.Name &&
+				protectedPVC.Namespace == rdSpec.ProtectedPVC.Namespace
+		})
 
 		// VolSync PVC not found in destination.
-		return true
+		if i < 0 {
+			return true
+		}
+
+		rdSpecStorage := dstVRG.Spec.VolSync.RDSpec[i].ProtectedPVC.Resources.Requests.Storage()
+		if protectedPVC.Resources.Requests.Storage().Cmp(*rdSpecStorage) > 0 {
+			return true
+		}
 	}
 
 	return false
@@ -316,14 +444,30 @@ func (d *DRPCInstance) createVolSyncDestManifestWork(clusterToSkip string) error
 
 			return fmt.Errorf("failed to create or update VolumeReplicationGroup manifest in namespace %s (%w)", dstCluster, err)
 		}
-
-		// For now, assume only a pair of clusters in the DRClusterSet
-		break
 	}
 
 	return nil
 }
 
+// volSyncPeers returns the clusters that the VRG of a cluster replicates its VolSync PVCs to when primary, if the
+// DRPolicy has more than one peer for it, or nil otherwise
+func (d *DRPCInstance) volSyncPeers(cluster string) []string {
+	drClusters := rmnutil.DRPolicyClusterNames(d.drPolicy)
+	if len(drClusters) <= 2 {
+		return nil
+	}
+
+	peers := make([]string, 0, len(drClusters)-1)
+
+	for _, drCluster := range drClusters {
+		if drCluster != cluster {
+			peers = append(peers, drCluster)
+		}
+	}
+
+	return peers
+}
+
 func (d *DRPCInstance) ResetVolSyncRDOnPrimary(clusterName string) error {
 	if d.volSyncDisabled {
 		d.log.Info("VolSync is disabled")
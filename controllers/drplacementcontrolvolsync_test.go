@@ -0,0 +1,65 @@
+// SPDX-FileCopyrightText: The RamenDR authors
+// SPDX-License-Identifier: Apache-2.0
+
+// white box testing desired for VolSync destination updates
+package controllers //nolint: testpackage
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+
+	rmn "github.com/ramendr/ramen/api/v1alpha1"
+)
+
+var _ = Describe("DRPC_VolSyncDestination", func() {
+	protectedPVC := func(name, storage string) rmn.ProtectedPVC {
+		return rmn.ProtectedPVC{
+			Name:               name,
+			Namespace:          "app",
+			ProtectedByVolSync: true,
+			Resources: corev1.VolumeResourceRequirements{
+				Requests: corev1.ResourceList{corev1.ResourceStorage: resource.MustParse(storage)},
+			},
+		}
+	}
+
+	srcVRG := func(protectedPVCs ...rmn.ProtectedPVC) *rmn.VolumeReplicationGroup {
+		return &rmn.VolumeReplicationGroup{Status: rmn.VolumeReplicationGroupStatus{ProtectedPVCs: protectedPVCs}}
+	}
+
+	dstVRG := func(protectedPVCs ...rmn.ProtectedPVC) *rmn.VolumeReplicationGroup {
+		vrg := &rmn.VolumeReplicationGroup{}
+		for _, protectedPVC := range protectedPVCs {
+			vrg.Spec.VolSync.RDSpec = append(vrg.Spec.VolSync.RDSpec,
+				rmn.VolSyncReplicationDestinationSpec{ProtectedPVC: protectedPVC})
+		}
+
+		return vrg
+	}
+
+	d := &DRPCInstance{}
+
+	It("matches a destination with an RDSpec of the same size for each PVC", func() {
+		Expect(d.containsMismatchVolSyncPVCs(
+			srcVRG(protectedPVC("a", "1Gi"), protectedPVC("b", "2Gi")),
+			dstVRG(protectedPVC("b", "2Gi"), protectedPVC("a", "1024Mi")),
+		)).To(BeFalse())
+	})
+
+	It("mismatches a destination without an RDSpec for a PVC", func() {
+		Expect(d.containsMismatchVolSyncPVCs(
+			srcVRG(protectedPVC("a", "1Gi"), protectedPVC("b", "2Gi")),
+			dstVRG(protectedPVC("a", "1Gi")),
+		)).To(BeTrue())
+	})
+
+	It("mismatches a destination whose RDSpec requests less storage than an expanded PVC", func() {
+		Expect(d.containsMismatchVolSyncPVCs(
+			srcVRG(protectedPVC("a", "1Gi"), protectedPVC("b", "4Gi")),
+			dstVRG(protectedPVC("a", "1Gi"), protectedPVC("b", "2Gi")),
+		)).To(BeTrue())
+	})
+})
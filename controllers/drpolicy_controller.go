@@ -181,6 +181,22 @@ func validateDRPolicy(ctx context.Context,
 		return reason, err
 	}
 
+	if err := validateDRClusterCount(drpolicy, drclusters); err != nil {
+		return ReasonValidationFailed, err
+	}
+
+	if err := validateStorageClassMappings(drpolicy); err != nil {
+		return ReasonValidationFailed, err
+	}
+
+	if err := validateStaticVolumeEndpointMappings(drpolicy); err != nil {
+		return ReasonValidationFailed, err
+	}
+
+	if err := validateSchedule(drpolicy); err != nil {
+		return ReasonValidationFailed, err
+	}
+
 	err = validatePolicyConflicts(ctx, apiReader, drpolicy, drclusters)
 	if err != nil {
 		return ReasonValidationFailed, err
@@ -189,6 +205,77 @@ func validateDRPolicy(ctx context.Context,
 	return "", nil
 }
 
+// validateDRClusterCount ensures a DRPolicy with more than 2 clusters is async, as sync replication is between a
+// pair of clusters in a region
+func validateDRClusterCount(drpolicy *ramen.DRPolicy, drclusters *ramen.DRClusterList) error {
+	const maxSyncDRClusters = 2
+	if len(drpolicy.Spec.DRClusters) <= maxSyncDRClusters {
+		return nil
+	}
+
+	if supportsMetro, _ := dRPolicySupportsMetro(drpolicy, drclusters.Items); supportsMetro {
+		return fmt.Errorf("drClusters of a policy with clusters in the same region requires a list of %d clusters",
+			maxSyncDRClusters)
+	}
+
+	return nil
+}
+
+// validateStorageClassMappings ensures each StorageClass mapping names only clusters in the DRPolicy, and that
+// a StorageClass on a cluster is not mapped to more than one StorageClass on a peer cluster
+func validateStorageClassMappings(drpolicy *ramen.DRPolicy) error {
+	clusterNames := util.DRPolicyClusterNamesAsASet(drpolicy)
+	mapped := sets.New[string]()
+
+	for _, mapping := range drpolicy.Spec.StorageClassMappings {
+		for clusterName, storageClassName := range mapping.StorageClassNames {
+			if !clusterNames.Has(clusterName) {
+				return fmt.Errorf("storageClassMappings cluster %s is not in drClusters", clusterName)
+			}
+
+			key := clusterName + "/" + storageClassName
+			if mapped.Has(key) {
+				return fmt.Errorf("storageClassMappings contains StorageClass %s of cluster %s more than once",
+					storageClassName, clusterName)
+			}
+
+			mapped.Insert(key)
+		}
+	}
+
+	return nil
+}
+
+// validateStaticVolumeEndpointMappings ensures each static PV endpoint mapping names only clusters in the DRPolicy
+func validateStaticVolumeEndpointMappings(drpolicy *ramen.DRPolicy) error {
+	clusterNames := util.DRPolicyClusterNamesAsASet(drpolicy)
+
+	for _, mapping := range drpolicy.Spec.StaticVolumeEndpointMappings {
+		for clusterName := range mapping.Endpoints {
+			if !clusterNames.Has(clusterName) {
+				return fmt.Errorf("staticVolumeEndpointMappings cluster %s is not in drClusters", clusterName)
+			}
+		}
+	}
+
+	return nil
+}
+
+// validateSchedule ensures the schedule and blackout windows are valid
+func validateSchedule(drpolicy *ramen.DRPolicy) error {
+	if drpolicy.Spec.Schedule != "" {
+		if err := util.ValidateCronSchedule(drpolicy.Spec.Schedule); err != nil {
+			return fmt.Errorf("schedule: %w", err)
+		}
+	}
+
+	if err := util.ValidateReplicationWindows(drpolicy.Spec.BlackoutWindows); err != nil {
+		return fmt.Errorf("blackoutWindows: %w", err)
+	}
+
+	return nil
+}
+
 func (r *DRPolicyReconciler) setDRPolicyMetrics(drPolicy *ramen.DRPolicy) error {
 	r.Log.Info(fmt.Sprintf("Setting metric: (%v)", DRPolicySyncIntervalSeconds))
 
@@ -302,6 +302,15 @@ var _ = Describe("DRPolicyController", func() {
 			Expect(k8sClient.Create(context.TODO(), drp)).To(MatchError(err(drp.Spec.SchedulingInterval)))
 		})
 	})
+	When("a drpolicy is created specifying more than 2 clusters, some in the same region", func() {
+		It("should set its validated status condition's status to false", func() {
+			drp := drpolicy.DeepCopy()
+			drp.Spec.DRClusters = clusters[0:3]
+			Expect(k8sClient.Create(context.TODO(), drp)).To(Succeed())
+			validatedConditionExpect(drp, metav1.ConditionFalse, ContainSubstring("requires a list of 2 clusters"))
+			drpolicyDeleteAndConfirm(drp)
+		})
+	})
 	When("a drpolicy is created before DRClusters are created", func() {
 		It("should start as invalidated and transition to validated", func() {
 			drp := drpolicy.DeepCopy()
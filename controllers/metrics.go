@@ -24,6 +24,11 @@ const (
 	WorkloadProtectionStatus = "workload_protection_status"
 )
 
+const (
+	PVCDegradedDurationSeconds = "pvc_degraded_duration_seconds"
+	PVCResyncAttempts          = "pvc_resync_attempts"
+)
+
 type SyncTimeMetrics struct {
 	LastSyncTime prometheus.Gauge
 }
@@ -44,6 +49,11 @@ type WorkloadProtectionMetrics struct {
 	WorkloadProtectionStatus prometheus.Gauge
 }
 
+type PVCResyncMetrics struct {
+	DegradedDuration prometheus.Gauge
+	ResyncAttempts   prometheus.Gauge
+}
+
 type SyncMetrics struct {
 	SyncTimeMetrics
 	SyncDurationMetrics
@@ -56,6 +66,8 @@ const (
 	ObjNamespace       = "obj_namespace"
 	Policyname         = "policyname"
 	SchedulingInterval = "scheduling_interval"
+	PVCName            = "pvc_name"
+	PVCNamespace       = "pvc_namespace"
 )
 
 var (
@@ -90,6 +102,14 @@ var (
 		ObjName,      // Name of the resoure [drpc-name]
 		ObjNamespace, // DRPC namespace
 	}
+
+	pvcResyncMetricLabels = []string{
+		ObjType,      // Name of the type of the resource [vrg]
+		ObjName,      // Name of the resoure [vrg-name]
+		ObjNamespace, // VRG namespace
+		PVCName,      // Name of the PVC
+		PVCNamespace, // PVC namespace
+	}
 )
 
 var (
@@ -137,6 +157,24 @@ var (
 		},
 		workloadProtectionStatusLabels,
 	)
+
+	pvcDegradedDuration = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name:      PVCDegradedDurationSeconds,
+			Namespace: metricNamespace,
+			Help:      "Duration the replication of a PVC is continuously degraded in seconds",
+		},
+		pvcResyncMetricLabels,
+	)
+
+	pvcResyncAttempts = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name:      PVCResyncAttempts,
+			Namespace: metricNamespace,
+			Help:      "Number of resyncs requested for a PVC since its replication is degraded",
+		},
+		pvcResyncMetricLabels,
+	)
 )
 
 // lastSyncTime metrics reports value from lastGrpupSyncTime taken from DRPC status
@@ -234,6 +272,31 @@ func DeleteWorkloadProtectionStatusMetric(labels prometheus.Labels) bool {
 	return workloadProtectionStatus.Delete(labels)
 }
 
+// pvcDegradedDuration and pvcResyncAttempts Metrics report values from a VRG ProtectedPVC status
+func PVCResyncMetricLabels(vrg *rmn.VolumeReplicationGroup, pvcNamespace, pvcName string) prometheus.Labels {
+	return prometheus.Labels{
+		ObjType:      "VolumeReplicationGroup",
+		ObjName:      vrg.Name,
+		ObjNamespace: vrg.Namespace,
+		PVCName:      pvcName,
+		PVCNamespace: pvcNamespace,
+	}
+}
+
+func NewPVCResyncMetrics(labels prometheus.Labels) PVCResyncMetrics {
+	return PVCResyncMetrics{
+		DegradedDuration: pvcDegradedDuration.With(labels),
+		ResyncAttempts:   pvcResyncAttempts.With(labels),
+	}
+}
+
+func DeletePVCResyncMetrics(labels prometheus.Labels) bool {
+	degradedDurationDeleted := pvcDegradedDuration.Delete(labels)
+	resyncAttemptsDeleted := pvcResyncAttempts.Delete(labels)
+
+	return degradedDurationDeleted && resyncAttemptsDeleted
+}
+
 func init() {
 	// Register custom metrics with the global prometheus registry
 	metrics.Registry.MustRegister(dRPolicySyncInterval)
@@ -241,4 +304,6 @@ func init() {
 	metrics.Registry.MustRegister(lastSyncDuration)
 	metrics.Registry.MustRegister(lastSyncDataBytes)
 	metrics.Registry.MustRegister(workloadProtectionStatus)
+	metrics.Registry.MustRegister(pvcDegradedDuration)
+	metrics.Registry.MustRegister(pvcResyncAttempts)
 }
@@ -52,6 +52,10 @@ func updateDRPCProtectedCondition(
 		}
 	}
 
+	if updateVRGVolRepPeers(drpc, vrg, clusterName) {
+		return
+	}
+
 	if updateMiscVRGStatus(drpc, vrg, clusterName) {
 		return
 	}
@@ -246,6 +250,37 @@ func genericUpdateProtectedForCondition(drpc *rmn.DRPlacementControl,
 	return updated
 }
 
+// updateVRGVolRepPeers is a helper function to process VRG PVCs protected by VolRep when the VRG has more than one
+// peer, which the storage replicates to a single peer only, and update DRPC Protected condition
+//   - Returns a bool that is true if status was updated, and false otherwise
+func updateVRGVolRepPeers(drpc *rmn.DRPlacementControl,
+	vrg *rmn.VolumeReplicationGroup,
+	clusterName string,
+) bool {
+	updated := true
+
+	if len(vrg.Spec.VolSync.Peers) <= 1 {
+		return !updated
+	}
+
+	for i := range vrg.Status.ProtectedPVCs {
+		protectedPVC := &vrg.Status.ProtectedPVCs[i]
+		if protectedPVC.ProtectedByVolSync {
+			continue
+		}
+
+		addOrUpdateCondition(&drpc.Status.Conditions, rmn.ConditionProtected, drpc.Generation, metav1.ConditionFalse,
+			rmn.ReasonProtectedError, fmt.Sprintf("VolumeReplicationGroup (%s/%s) on cluster %s "+
+				"protects PVC %s/%s with VolumeReplication, which is not replicated to all of its %d peers",
+				vrg.GetNamespace(), vrg.GetName(), clusterName,
+				protectedPVC.Namespace, protectedPVC.Name, len(vrg.Spec.VolSync.Peers)))
+
+		return updated
+	}
+
+	return !updated
+}
+
 // updateMiscVRGStatus processes VRG status fields other than conditions to determine DRPC Protected condition updates
 func updateMiscVRGStatus(drpc *rmn.DRPlacementControl,
 	vrg *rmn.VolumeReplicationGroup,
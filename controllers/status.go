@@ -46,6 +46,14 @@ const (
 	VRGConditionTypeVolSyncFinalSyncInProgress = "FinalSyncInProgress"
 	VRGConditionTypeVolSyncRepDestinationSetup = "ReplicationDestinationSetup"
 	VRGConditionTypeVolSyncPVsRestored         = "PVsRestored"
+
+	// Test failover condition. This condition is only reported by a VRG that
+	// is requested to run a test failover, and is not a summary condition.
+	VRGConditionTypeTestFailover = "TestFailover"
+
+	// Split-brain condition. This condition is only reported while the storage
+	// reports a protected volume as primary on both clusters.
+	VRGConditionTypeVolumesNotDemoted = "VolumesNotDemoted"
 )
 
 // VRG condition reasons
@@ -69,6 +77,10 @@ const (
 	VRGConditionReasonVolSyncFinalSyncInProgress  = "Syncing"
 	VRGConditionReasonVolSyncFinalSyncComplete    = "Synced"
 	VRGConditionReasonClusterDataAnnotationFailed = "AnnotationFailed"
+	VRGConditionReasonTestFailoverRestored        = "Restored"
+	VRGConditionReasonTestFailoverUnsupported     = "Unsupported"
+	VRGConditionReasonNotDemoted                  = "NotDemoted"
+	VRGConditionReasonMirroringUnknown            = "MirroringUnknown"
 )
 
 const clusterDataProtectedTrueMessage = "Kube objects protected"
@@ -195,6 +207,22 @@ func newVRGAsDataNotProtectedCondition(observedGeneration int64, message string)
 	}
 }
 
+// sets conditions when the storage does not report the synchronous mirroring state of the data
+func setVRGAsDataProtectedUnknownCondition(conditions *[]metav1.Condition, observedGeneration int64, message string,
+) {
+	setStatusCondition(conditions, *newVRGAsDataProtectedUnknownCondition(observedGeneration, message))
+}
+
+func newVRGAsDataProtectedUnknownCondition(observedGeneration int64, message string) *metav1.Condition {
+	return &metav1.Condition{
+		Type:               VRGConditionTypeDataProtected,
+		Reason:             VRGConditionReasonMirroringUnknown,
+		ObservedGeneration: observedGeneration,
+		Status:             metav1.ConditionUnknown,
+		Message:            message,
+	}
+}
+
 func setVRGDataProtectionProgressCondition(conditions *[]metav1.Condition, observedGeneration int64, message string) {
 	setStatusCondition(conditions, *newVRGDataProtectionProgressCondition(observedGeneration, message))
 }
@@ -459,3 +487,29 @@ func setVRGConditionTypeVolSyncPVRestoreError(conditions *[]metav1.Condition, ob
 		Message:            message,
 	})
 }
+
+// sets conditions when a test failover has restored, is restoring or failed to restore a copy of the workload
+func setVRGConditionTypeTestFailover(conditions *[]metav1.Condition, observedGeneration int64,
+	status metav1.ConditionStatus, reason, message string,
+) {
+	setStatusCondition(conditions, metav1.Condition{
+		Type:               VRGConditionTypeTestFailover,
+		Reason:             reason,
+		ObservedGeneration: observedGeneration,
+		Status:             status,
+		Message:            message,
+	})
+}
+
+// sets condition when the storage reports that it failed to demote volumes of the VRG, which remain primary
+func setVRGConditionTypeVolumesNotDemoted(conditions *[]metav1.Condition, observedGeneration int64,
+	message string,
+) {
+	setStatusCondition(conditions, metav1.Condition{
+		Type:               VRGConditionTypeVolumesNotDemoted,
+		Reason:             VRGConditionReasonNotDemoted,
+		ObservedGeneration: observedGeneration,
+		Status:             metav1.ConditionTrue,
+		Message:            message,
+	})
+}
@@ -7,6 +7,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"regexp"
 	"strconv"
 	"time"
 
@@ -90,9 +91,23 @@ func DRPolicyS3Profiles(drpolicy *rmn.DRPolicy, drclusters []rmn.DRCluster) sets
 	return mustHaveS3Profiles
 }
 
-//nolint:gomnd
 func GetSecondsFromSchedulingInterval(drpolicy *rmn.DRPolicy) (float64, error) {
-	schedulingInterval := drpolicy.Spec.SchedulingInterval
+	return SchedulingIntervalSeconds(drpolicy.Spec.SchedulingInterval)
+}
+
+var schedulingIntervalRegexp = regexp.MustCompile(`^\d+[mhd]$`)
+
+// ValidateSchedulingInterval returns an error if the scheduling interval is not of the form <num><m,h,d>
+func ValidateSchedulingInterval(schedulingInterval string) error {
+	if !schedulingIntervalRegexp.MatchString(schedulingInterval) {
+		return fmt.Errorf("scheduling interval %q is not of the form <num><m,h,d>", schedulingInterval)
+	}
+
+	return nil
+}
+
+//nolint:gomnd
+func SchedulingIntervalSeconds(schedulingInterval string) (float64, error) {
 	if schedulingInterval == "" {
 		return 0, nil
 	}
@@ -114,6 +129,55 @@ func GetSecondsFromSchedulingInterval(drpolicy *rmn.DRPolicy) (float64, error) {
 	}
 }
 
+// DRPolicyStorageClassMapping returns the DRPolicy StorageClass mappings as seen from the named cluster, mapping
+// the StorageClass names on its peer clusters to the equivalent StorageClass names on the cluster
+func DRPolicyStorageClassMapping(drpolicy *rmn.DRPolicy, clusterName string) map[string]string {
+	mappings := make([]map[string]string, 0, len(drpolicy.Spec.StorageClassMappings))
+	for _, mapping := range drpolicy.Spec.StorageClassMappings {
+		mappings = append(mappings, mapping.StorageClassNames)
+	}
+
+	return clusterMappingsAsSeenFrom(mappings, clusterName, false)
+}
+
+// DRPolicyStaticVolumeEndpointMapping returns the DRPolicy static PV endpoint mappings as seen from the named
+// cluster, mapping the endpoints on its peer clusters to the equivalent endpoints on the cluster. An endpoint
+// shared by the clusters maps to itself, as static PVs with unmapped endpoints are not restored.
+func DRPolicyStaticVolumeEndpointMapping(drpolicy *rmn.DRPolicy, clusterName string) map[string]string {
+	mappings := make([]map[string]string, 0, len(drpolicy.Spec.StaticVolumeEndpointMappings))
+	for _, mapping := range drpolicy.Spec.StaticVolumeEndpointMappings {
+		mappings = append(mappings, mapping.Endpoints)
+	}
+
+	return clusterMappingsAsSeenFrom(mappings, clusterName, true)
+}
+
+// clusterMappingsAsSeenFrom merges mappings, each of a cluster name to a value on that cluster, into a map of the
+// values on the peer clusters of the named cluster to the equivalent values on the cluster. Values that are the
+// same on the cluster and a peer are mapped only if shared is set.
+func clusterMappingsAsSeenFrom(mappings []map[string]string, clusterName string, shared bool) map[string]string {
+	clusterMapping := map[string]string{}
+
+	for _, mapping := range mappings {
+		targetValue, ok := mapping[clusterName]
+		if !ok {
+			continue
+		}
+
+		for peerClusterName, sourceValue := range mapping {
+			if peerClusterName != clusterName && (shared || sourceValue != targetValue) {
+				clusterMapping[sourceValue] = targetValue
+			}
+		}
+	}
+
+	if len(clusterMapping) == 0 {
+		return nil
+	}
+
+	return clusterMapping
+}
+
 func DrpolicyContainsDrcluster(drpolicy *rmn.DRPolicy, drcluster string) bool {
 	for _, managedCluster := range DRPolicyClusterNames(drpolicy) {
 		if managedCluster == drcluster {
@@ -0,0 +1,82 @@
+// SPDX-FileCopyrightText: The RamenDR authors
+// SPDX-License-Identifier: Apache-2.0
+
+package util_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	rmn "github.com/ramendr/ramen/api/v1alpha1"
+	"github.com/ramendr/ramen/controllers/util"
+)
+
+var _ = Describe("SchedulingInterval", func() {
+	DescribeTable("validates and converts scheduling intervals",
+		func(schedulingInterval string, valid bool, seconds float64) {
+			err := util.ValidateSchedulingInterval(schedulingInterval)
+			if !valid {
+				Expect(err).To(HaveOccurred())
+
+				return
+			}
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(util.SchedulingIntervalSeconds(schedulingInterval)).To(Equal(seconds))
+		},
+		Entry("minutes", "5m", true, float64(300)),
+		Entry("hours", "2h", true, float64(7200)),
+		Entry("days", "1d", true, float64(86400)),
+		Entry("seconds unit", "30s", false, float64(0)),
+		Entry("missing unit", "10", false, float64(0)),
+		Entry("empty", "", false, float64(0)),
+	)
+})
+
+var _ = Describe("StorageClassMapping", func() {
+	drpolicy := &rmn.DRPolicy{
+		Spec: rmn.DRPolicySpec{
+			DRClusters: []string{"east", "west"},
+			StorageClassMappings: []rmn.StorageClassMapping{
+				{StorageClassNames: map[string]string{"east": "ceph-rbd", "west": "gp3"}},
+				{StorageClassNames: map[string]string{"east": "cephfs", "west": "efs"}},
+				{StorageClassNames: map[string]string{"east": "same", "west": "same"}},
+			},
+		},
+	}
+
+	DescribeTable("maps peer StorageClass names to the cluster's StorageClass names",
+		func(clusterName string, expected map[string]string) {
+			Expect(util.DRPolicyStorageClassMapping(drpolicy, clusterName)).To(Equal(expected))
+		},
+		Entry("east", "east", map[string]string{"gp3": "ceph-rbd", "efs": "cephfs"}),
+		Entry("west", "west", map[string]string{"ceph-rbd": "gp3", "cephfs": "efs"}),
+		Entry("cluster not in any mapping", "north", nil),
+	)
+})
+
+var _ = Describe("StaticVolumeEndpointMapping", func() {
+	drpolicy := &rmn.DRPolicy{
+		Spec: rmn.DRPolicySpec{
+			DRClusters: []string{"east", "west"},
+			StaticVolumeEndpointMappings: []rmn.StaticVolumeEndpointMapping{
+				{Endpoints: map[string]string{"east": "nfs.east.example.com", "west": "nfs.west.example.com"}},
+				{Endpoints: map[string]string{"east": "worker-0", "west": "worker-a"}},
+				{Endpoints: map[string]string{"east": "nfs.example.com", "west": "nfs.example.com"}},
+			},
+		},
+	}
+
+	DescribeTable("maps peer static PV endpoints to the cluster's endpoints",
+		func(clusterName string, expected map[string]string) {
+			Expect(util.DRPolicyStaticVolumeEndpointMapping(drpolicy, clusterName)).To(Equal(expected))
+		},
+		Entry("east", "east", map[string]string{
+			"nfs.west.example.com": "nfs.east.example.com", "worker-a": "worker-0", "nfs.example.com": "nfs.example.com",
+		}),
+		Entry("west", "west", map[string]string{
+			"nfs.east.example.com": "nfs.west.example.com", "worker-0": "worker-a", "nfs.example.com": "nfs.example.com",
+		}),
+		Entry("cluster not in any mapping", "north", nil),
+	)
+})
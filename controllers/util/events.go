@@ -55,6 +55,27 @@ const (
 	// EventReasonSecondarySuccess is an event generated when VRG is successfully
 	// processed as Primary.
 	EventReasonDeleteSuccess = "VRGDeleteSuccess"
+
+	// EventReasonVRDegraded is used when a VolumeReplication reports its volume as degraded
+	EventReasonVRDegraded = "VRDegraded"
+
+	// EventReasonVRResyncRequested is used when VRG requests a resync of a degraded VolumeReplication
+	EventReasonVRResyncRequested = "VRResyncRequested"
+
+	// EventReasonVolumesNotDemoted is used when the storage fails to demote volumes, which remain primary
+	EventReasonVolumesNotDemoted = "VolumesNotDemoted"
+
+	// EventReasonConsistentSyncCompleted is used when VRG completes a consistent sync request
+	EventReasonConsistentSyncCompleted = "ConsistentSyncCompleted"
+
+	// EventReasonConsistentSyncFailed is used when VRG fails a consistent sync request
+	EventReasonConsistentSyncFailed = "ConsistentSyncFailed"
+
+	// EventReasonStaticVolumeQuiesceFailed is used when VRG cannot quiesce a pod using a static volume due to sync
+	EventReasonStaticVolumeQuiesceFailed = "StaticVolumeQuiesceFailed"
+
+	// EventReasonStaticVolumeRestoreFailed is used when VRG cannot restore the PV of a static volume
+	EventReasonStaticVolumeRestoreFailed = "StaticVolumeRestoreFailed"
 	// TODO: Add any additional events (or remove one of existing ones above) if necessary.
 
 	// Events for DRPC Reconciler
@@ -87,9 +108,23 @@ const (
 	// relocates an application along with ramen managed cluster component(s)
 	EventReasonRelocationSuccess = "DRPCRelocationSuccess"
 
+	// EventReasonTestingFailover is an event generated when DRPC starts a test failover
+	EventReasonTestingFailover = "DRPCTestingFailover"
+
+	// EventReasonTestFailoverSuccess is an event generated when DRPC successfully
+	// restored a test copy of the application
+	EventReasonTestFailoverSuccess = "DRPCTestFailoverSuccess"
+
+	// EventReasonFinalSyncTimedOut is generated when the final sync of a DRPC relocation
+	// does not complete by its deadline
+	EventReasonFinalSyncTimedOut = "DRPCFinalSyncTimedOut"
+
 	// EventReasonSwitchFailed is generated when DRPC fails to switch the cluster
 	// where the app is placed
 	EventReasonSwitchFailed = "DRPCClusterSwitchFailed"
+
+	// EventReasonSplitBrain is generated when volumes of a DRPC workload are primary on two clusters
+	EventReasonSplitBrain = "DRPCSplitBrain"
 )
 
 // EventReporter is custom events reporter type which allows user to limit the events
@@ -44,6 +44,8 @@ const (
 	MWTypeNS    string = "ns"
 	MWTypeNF    string = "nf"
 	MWTypeMMode string = "mmode"
+
+	MWTypeVolSyncSecret string = "vssecret"
 )
 
 type MWUtil struct {
@@ -291,6 +293,28 @@ func (mwu *MWUtil) CreateOrUpdateNamespaceManifest(
 	return mwu.createOrUpdateManifestWork(manifestWork, managedClusterNamespace)
 }
 
+// CreateOrUpdateVolSyncSecretManifestWork creates or updates the ManifestWork of the VolSync pre-shared key secret
+// on a managed cluster
+func (mwu *MWUtil) CreateOrUpdateVolSyncSecretManifestWork(cluster string, secret corev1.Secret) error {
+	manifest, err := mwu.GenerateManifest(secret)
+	if err != nil {
+		return err
+	}
+
+	manifests := []ocmworkv1.Manifest{
+		*manifest,
+	}
+
+	manifestWork := mwu.newManifestWork(
+		mwu.BuildManifestWorkName(MWTypeVolSyncSecret),
+		cluster,
+		map[string]string{"velero.io/exclude-from-backup": "true"},
+		manifests,
+		nil)
+
+	return mwu.createOrUpdateManifestWork(manifestWork, cluster)
+}
+
 func Namespace(name string) *corev1.Namespace {
 	return &corev1.Namespace{
 		TypeMeta:   metav1.TypeMeta{Kind: "Namespace", APIVersion: "v1"},
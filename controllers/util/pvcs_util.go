@@ -8,10 +8,12 @@ import (
 	"fmt"
 
 	"github.com/go-logr/logr"
+	rmn "github.com/ramendr/ramen/api/v1alpha1"
 	"golang.org/x/exp/slices"
 	corev1 "k8s.io/api/core/v1"
 	storagev1 "k8s.io/api/storage/v1"
 	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/selection"
@@ -88,6 +90,83 @@ func ListPVCsByPVCSelector(
 	return pvcList, nil
 }
 
+// PVCMatchesFilter returns true if the pvc satisfies every criterion specified in the filter. A nil
+// filter matches every pvc.
+func PVCMatchesFilter(pvc *corev1.PersistentVolumeClaim, filter *rmn.PVCFilter) (bool, error) {
+	if filter == nil {
+		return true, nil
+	}
+
+	storageClassName := ""
+	if pvc.Spec.StorageClassName != nil {
+		storageClassName = *pvc.Spec.StorageClassName
+	}
+
+	if len(filter.StorageClassNames) > 0 && !slices.Contains(filter.StorageClassNames, storageClassName) {
+		return false, nil
+	}
+
+	if slices.Contains(filter.ExcludedStorageClassNames, storageClassName) {
+		return false, nil
+	}
+
+	if len(filter.AccessModes) > 0 && !slices.ContainsFunc(pvc.Spec.AccessModes,
+		func(accessMode corev1.PersistentVolumeAccessMode) bool {
+			return slices.Contains(filter.AccessModes, accessMode)
+		}) {
+		return false, nil
+	}
+
+	size := pvc.Spec.Resources.Requests[corev1.ResourceStorage]
+
+	if filter.MinSize != nil && size.Cmp(*filter.MinSize) < 0 {
+		return false, nil
+	}
+
+	if filter.MaxSize != nil && size.Cmp(*filter.MaxSize) > 0 {
+		return false, nil
+	}
+
+	if filter.AnnotationSelector == nil {
+		return true, nil
+	}
+
+	annotationSelector, err := metav1.LabelSelectorAsSelector(filter.AnnotationSelector)
+	if err != nil {
+		return false, fmt.Errorf("error with PVC annotation selector, %w", err)
+	}
+
+	return annotationSelector.Matches(labels.Set(pvc.GetAnnotations())), nil
+}
+
+// FilterPVCs removes the pvcs in the list that do not satisfy the filter, except those that keep, if not nil,
+// returns true for. The filter matches properties of a claim that may change, such as its size or annotations,
+// so keep retains a pvc that is already protected.
+func FilterPVCs(pvcList *corev1.PersistentVolumeClaimList, filter *rmn.PVCFilter,
+	keep func(*corev1.PersistentVolumeClaim) bool,
+) error {
+	if filter == nil {
+		return nil
+	}
+
+	pvcs := make([]corev1.PersistentVolumeClaim, 0, len(pvcList.Items))
+
+	for i := range pvcList.Items {
+		match, err := PVCMatchesFilter(&pvcList.Items[i], filter)
+		if err != nil {
+			return err
+		}
+
+		if match || keep != nil && keep(&pvcList.Items[i]) {
+			pvcs = append(pvcs, pvcList.Items[i])
+		}
+	}
+
+	pvcList.Items = pvcs
+
+	return nil
+}
+
 // IsPVCInUseByPod determines if there are any pod resources that reference the pvcName in the current
 // pvcNamespace and returns true if found. Further if inUsePodMustBeReady is true, returns true only if
 // the pod is in Ready state.
@@ -230,6 +309,26 @@ func isPodReady(podConditions []corev1.PodCondition) bool {
 	return false
 }
 
+// PVCExpandStorageRequest raises the storage request of the PVC to capacity, if it is currently smaller.
+// PVCs cannot be shrunk, hence a smaller capacity is ignored. Returns true if the request was updated.
+func PVCExpandStorageRequest(pvc *corev1.PersistentVolumeClaim, capacity *resource.Quantity) bool {
+	if capacity == nil || capacity.IsZero() {
+		return false
+	}
+
+	if request, ok := pvc.Spec.Resources.Requests[corev1.ResourceStorage]; ok && request.Cmp(*capacity) >= 0 {
+		return false
+	}
+
+	if pvc.Spec.Resources.Requests == nil {
+		pvc.Spec.Resources.Requests = corev1.ResourceList{}
+	}
+
+	pvc.Spec.Resources.Requests[corev1.ResourceStorage] = capacity.DeepCopy()
+
+	return true
+}
+
 func DeletePVC(ctx context.Context,
 	k8sClient client.Client,
 	pvcName, namespace string,
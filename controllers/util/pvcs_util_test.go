@@ -10,6 +10,7 @@ import (
 	. "github.com/onsi/gomega"
 	gomegatypes "github.com/onsi/gomega/types"
 
+	rmn "github.com/ramendr/ramen/api/v1alpha1"
 	"github.com/ramendr/ramen/controllers/util"
 
 	corev1 "k8s.io/api/core/v1"
@@ -227,6 +228,117 @@ var _ = Describe("PVCS_Util", func() {
 	})
 })
 
+var _ = Describe("PVCMatchesFilter", func() {
+	storageClassName := "fast"
+	pvc := &corev1.PersistentVolumeClaim{
+		ObjectMeta: metav1.ObjectMeta{
+			Annotations: map[string]string{"scratch": "true"},
+		},
+		Spec: corev1.PersistentVolumeClaimSpec{
+			StorageClassName: &storageClassName,
+			AccessModes:      []corev1.PersistentVolumeAccessMode{corev1.ReadWriteOnce},
+			Resources: corev1.VolumeResourceRequirements{
+				Requests: corev1.ResourceList{corev1.ResourceStorage: resource.MustParse("10Gi")},
+			},
+		},
+	}
+	quantity := func(s string) *resource.Quantity {
+		q := resource.MustParse(s)
+
+		return &q
+	}
+
+	DescribeTable("matches PVCs by claim properties",
+		func(filter *rmn.PVCFilter, expected bool) {
+			match, err := util.PVCMatchesFilter(pvc, filter)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(match).To(Equal(expected))
+		},
+		Entry("nil filter", nil, true),
+		Entry("included storage class", &rmn.PVCFilter{StorageClassNames: []string{"fast"}}, true),
+		Entry("other storage class", &rmn.PVCFilter{StorageClassNames: []string{"slow"}}, false),
+		Entry("excluded storage class", &rmn.PVCFilter{ExcludedStorageClassNames: []string{"fast"}}, false),
+		Entry("access mode", &rmn.PVCFilter{
+			AccessModes: []corev1.PersistentVolumeAccessMode{corev1.ReadWriteMany, corev1.ReadWriteOnce},
+		}, true),
+		Entry("other access mode", &rmn.PVCFilter{
+			AccessModes: []corev1.PersistentVolumeAccessMode{corev1.ReadWriteMany},
+		}, false),
+		Entry("size in range", &rmn.PVCFilter{MinSize: quantity("1Gi"), MaxSize: quantity("10Gi")}, true),
+		Entry("size below minimum", &rmn.PVCFilter{MinSize: quantity("11Gi")}, false),
+		Entry("size above maximum", &rmn.PVCFilter{MaxSize: quantity("1Gi")}, false),
+		Entry("annotation excluded", &rmn.PVCFilter{AnnotationSelector: &metav1.LabelSelector{
+			MatchExpressions: []metav1.LabelSelectorRequirement{
+				{Key: "scratch", Operator: metav1.LabelSelectorOpNotIn, Values: []string{"true"}},
+			},
+		}}, false),
+	)
+})
+
+var _ = Describe("FilterPVCs", func() {
+	storageClassName := func(name string) *string { return &name }
+	pvcList := func() *corev1.PersistentVolumeClaimList {
+		return &corev1.PersistentVolumeClaimList{Items: []corev1.PersistentVolumeClaim{
+			{
+				ObjectMeta: metav1.ObjectMeta{Name: "fast"},
+				Spec:       corev1.PersistentVolumeClaimSpec{StorageClassName: storageClassName("fast")},
+			},
+			{
+				ObjectMeta: metav1.ObjectMeta{Name: "slow"},
+				Spec:       corev1.PersistentVolumeClaimSpec{StorageClassName: storageClassName("slow")},
+			},
+		}}
+	}
+	filter := &rmn.PVCFilter{StorageClassNames: []string{"fast"}}
+	names := func(pvcList *corev1.PersistentVolumeClaimList) []string {
+		names := []string{}
+		for _, pvc := range pvcList.Items {
+			names = append(names, pvc.Name)
+		}
+
+		return names
+	}
+
+	It("removes the PVCs that do not satisfy the filter", func() {
+		pvcs := pvcList()
+		Expect(util.FilterPVCs(pvcs, filter, nil)).To(Succeed())
+		Expect(names(pvcs)).To(Equal([]string{"fast"}))
+	})
+
+	It("keeps the PVCs that are protected already", func() {
+		pvcs := pvcList()
+		Expect(util.FilterPVCs(pvcs, filter, func(pvc *corev1.PersistentVolumeClaim) bool {
+			return pvc.Name == "slow"
+		})).To(Succeed())
+		Expect(names(pvcs)).To(Equal([]string{"fast", "slow"}))
+	})
+})
+
+var _ = Describe("PVCExpandStorageRequest", func() {
+	quantity := func(s string) *resource.Quantity {
+		q := resource.MustParse(s)
+
+		return &q
+	}
+
+	DescribeTable("expands but never shrinks the storage request",
+		func(request string, capacity *resource.Quantity, expanded bool, expected string) {
+			pvc := &corev1.PersistentVolumeClaim{}
+			if request != "" {
+				pvc.Spec.Resources.Requests = corev1.ResourceList{corev1.ResourceStorage: resource.MustParse(request)}
+			}
+
+			Expect(util.PVCExpandStorageRequest(pvc, capacity)).To(Equal(expanded))
+			Expect(pvc.Spec.Resources.Requests.Storage().String()).To(Equal(expected))
+		},
+		Entry("nil capacity", "1Gi", nil, false, "1Gi"),
+		Entry("larger capacity", "1Gi", quantity("2Gi"), true, "2Gi"),
+		Entry("equal capacity", "2Gi", quantity("2Gi"), false, "2Gi"),
+		Entry("smaller capacity", "2Gi", quantity("1Gi"), false, "2Gi"),
+		Entry("no request", "", quantity("1Gi"), true, "1Gi"),
+	)
+})
+
 func createTestPVC(ctx context.Context, namespace string, labels map[string]string) *corev1.PersistentVolumeClaim {
 	pvcCapacity := resource.MustParse("1Gi")
 
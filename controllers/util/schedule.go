@@ -0,0 +1,178 @@
+// SPDX-FileCopyrightText: The RamenDR authors
+// SPDX-License-Identifier: Apache-2.0
+
+package util
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/robfig/cron/v3"
+
+	ramen "github.com/ramendr/ramen/api/v1alpha1"
+)
+
+const (
+	// ScheduleAnnotation on a VolumeReplicationClass is the cron schedule it replicates on
+	ScheduleAnnotation = "ramendr.openshift.io/schedule"
+
+	// BlackoutWindowsAnnotation on a VolumeReplicationClass is the blackout windows it does not replicate in, in
+	// the form returned by ReplicationWindowsString
+	BlackoutWindowsAnnotation = "ramendr.openshift.io/blackout-windows"
+
+	replicationWindowTimeLayout = "15:04"
+	daysPerWeek                 = 7
+)
+
+// volSyncScheduleRegexp matches the cron schedules the VolSync API accepts: five fields of a number or an
+// asterisk, each optionally followed by a step
+var volSyncScheduleRegexp = regexp.MustCompile(`^(\d+|\*)(/\d+)?(\s+(\d+|\*)(/\d+)?){4}$`)
+
+// cronParser parses standard cron expressions of five fields, without descriptors such as @hourly
+var cronParser = cron.NewParser(cron.Minute | cron.Hour | cron.Dom | cron.Month | cron.Dow)
+
+var replicationWindowDays = map[string]time.Weekday{
+	"Sun": time.Sunday,
+	"Mon": time.Monday,
+	"Tue": time.Tuesday,
+	"Wed": time.Wednesday,
+	"Thu": time.Thursday,
+	"Fri": time.Friday,
+	"Sat": time.Saturday,
+}
+
+// ParseCronSchedule parses a standard cron expression of five fields, each of which may have ranges, lists and
+// steps. Schedules are in UTC, so a time zone prefix is an error.
+func ParseCronSchedule(schedule string) (cron.Schedule, error) {
+	if strings.HasPrefix(schedule, "TZ=") || strings.HasPrefix(schedule, "CRON_TZ=") {
+		return nil, fmt.Errorf("schedule %q has a time zone, whereas schedules are in UTC", schedule)
+	}
+
+	cronSchedule, err := cronParser.Parse(schedule)
+	if err != nil {
+		return nil, fmt.Errorf("schedule %q is not a cron expression: %w", schedule, err)
+	}
+
+	return cronSchedule, nil
+}
+
+// ValidateCronSchedule returns an error if a schedule is not a standard cron expression of five fields
+func ValidateCronSchedule(schedule string) error {
+	_, err := ParseCronSchedule(schedule)
+
+	return err
+}
+
+// VolSyncScheduleSupported returns true if the VolSync API accepts a cron schedule, which has only numbers,
+// asterisks and steps in its fields
+func VolSyncScheduleSupported(schedule string) bool {
+	return volSyncScheduleRegexp.MatchString(schedule)
+}
+
+// ValidateReplicationWindows returns an error if a window has an unknown day, or a time that is not HH:MM
+func ValidateReplicationWindows(windows []ramen.ReplicationWindow) error {
+	for i, window := range windows {
+		for _, day := range window.Days {
+			if _, ok := replicationWindowDays[day]; !ok {
+				return fmt.Errorf("window %d day %q is not one of Mon, Tue, Wed, Thu, Fri, Sat or Sun", i, day)
+			}
+		}
+
+		if _, err := time.Parse(replicationWindowTimeLayout, window.StartTime); err != nil {
+			return fmt.Errorf("window %d start time %q is not HH:MM", i, window.StartTime)
+		}
+
+		if _, err := time.Parse(replicationWindowTimeLayout, window.EndTime); err != nil {
+			return fmt.Errorf("window %d end time %q is not HH:MM", i, window.EndTime)
+		}
+	}
+
+	return nil
+}
+
+// ReplicationWindowsString returns windows in the form "Mon,Tue 09:00-17:00;22:00-02:00", where a window
+// without days starts every day
+func ReplicationWindowsString(windows []ramen.ReplicationWindow) string {
+	windowStrings := make([]string, 0, len(windows))
+
+	for _, window := range windows {
+		windowString := window.StartTime + "-" + window.EndTime
+		if len(window.Days) != 0 {
+			windowString = strings.Join(window.Days, ",") + " " + windowString
+		}
+
+		windowStrings = append(windowStrings, windowString)
+	}
+
+	return strings.Join(windowStrings, ";")
+}
+
+// ReplicationWindowActive returns true if a time is within any of the windows, and the time this next changes:
+// the latest end of the active windows, or else the earliest start of the windows in the coming week. A window
+// whose end time is not after its start time ends on the day after it starts.
+func ReplicationWindowActive(windows []ramen.ReplicationWindow, now time.Time) (bool, time.Time) {
+	now = now.UTC()
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+
+	var end, nextStart time.Time
+
+	for _, window := range windows {
+		startTime, err := time.Parse(replicationWindowTimeLayout, window.StartTime)
+		if err != nil {
+			continue
+		}
+
+		endTime, err := time.Parse(replicationWindowTimeLayout, window.EndTime)
+		if err != nil {
+			continue
+		}
+
+		// From yesterday, as a window that started yesterday may end today, until a week from today
+		for days := -1; days <= daysPerWeek; days++ {
+			day := today.AddDate(0, 0, days)
+			if !replicationWindowStartsOn(window, day.Weekday()) {
+				continue
+			}
+
+			windowStart := day.Add(time.Duration(startTime.Hour())*time.Hour +
+				time.Duration(startTime.Minute())*time.Minute)
+			windowEnd := day.Add(time.Duration(endTime.Hour())*time.Hour +
+				time.Duration(endTime.Minute())*time.Minute)
+
+			if !windowEnd.After(windowStart) {
+				windowEnd = windowEnd.AddDate(0, 0, 1)
+			}
+
+			switch {
+			case windowStart.After(now):
+				if nextStart.IsZero() || windowStart.Before(nextStart) {
+					nextStart = windowStart
+				}
+			case now.Before(windowEnd) && windowEnd.After(end):
+				end = windowEnd
+			}
+		}
+	}
+
+	if !end.IsZero() {
+		return true, end
+	}
+
+	return false, nextStart
+}
+
+func replicationWindowStartsOn(window ramen.ReplicationWindow, weekday time.Weekday) bool {
+	if len(window.Days) == 0 {
+		return true
+	}
+
+	for _, day := range window.Days {
+		if replicationWindowDays[day] == weekday {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,88 @@
+// SPDX-FileCopyrightText: The RamenDR authors
+// SPDX-License-Identifier: Apache-2.0
+
+package util_test
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	rmn "github.com/ramendr/ramen/api/v1alpha1"
+	"github.com/ramendr/ramen/controllers/util"
+)
+
+var _ = Describe("Schedule", func() {
+	DescribeTable("validates cron schedules",
+		func(schedule string, valid bool) {
+			err := util.ValidateCronSchedule(schedule)
+			if valid {
+				Expect(err).NotTo(HaveOccurred())
+			} else {
+				Expect(err).To(HaveOccurred())
+			}
+		},
+		Entry("every 5 minutes", "*/5 * * * *", true),
+		Entry("nightly", "30 2 * * *", true),
+		Entry("weekly", "0 0 * * 0", true),
+		Entry("hour out of bounds", "0 24 * * *", false),
+		Entry("zero step", "*/0 * * * *", false),
+		Entry("weekday business hours", "0 9-17 * * 1-5", true),
+		Entry("list", "0,30 * * * *", true),
+		Entry("range with a step", "0 8-20/4 * * *", true),
+		Entry("day of week names", "0 0 * * MON-FRI", true),
+		Entry("descriptor", "@hourly", false),
+		Entry("time zone", "TZ=Europe/Paris 0 0 * * *", false),
+		Entry("four fields", "* * * *", false),
+	)
+
+	DescribeTable("reports whether the VolSync API accepts cron schedules",
+		func(schedule string, supported bool) {
+			Expect(util.VolSyncScheduleSupported(schedule)).To(Equal(supported))
+		},
+		Entry("every 5 minutes", "*/5 * * * *", true),
+		Entry("nightly", "30 2 * * *", true),
+		Entry("range", "0 9-17 * * *", false),
+		Entry("list", "0,30 * * * *", false),
+	)
+
+	// 2024-01-01 is a Monday
+	windows := []rmn.ReplicationWindow{
+		{Days: []string{"Mon", "Tue", "Wed", "Thu", "Fri"}, StartTime: "09:00", EndTime: "17:00"},
+		{Days: []string{"Sat"}, StartTime: "22:00", EndTime: "02:00"},
+	}
+	at := func(day, hour, minute int) time.Time {
+		return time.Date(2024, time.January, day, hour, minute, 0, 0, time.UTC)
+	}
+
+	DescribeTable("reports whether blackout windows are active",
+		func(now time.Time, activeExpected bool, nextExpected time.Time) {
+			active, next := util.ReplicationWindowActive(windows, now)
+			Expect(active).To(Equal(activeExpected))
+			Expect(next).To(Equal(nextExpected))
+		},
+		Entry("before a weekday window", at(1, 8, 0), false, at(1, 9, 0)),
+		Entry("within a weekday window", at(1, 9, 0), true, at(1, 17, 0)),
+		Entry("at the end of a weekday window", at(1, 17, 0), false, at(2, 9, 0)),
+		Entry("on Friday evening", at(5, 18, 0), false, at(6, 22, 0)),
+		Entry("within an overnight window on its day", at(6, 23, 0), true, at(7, 2, 0)),
+		Entry("within an overnight window on the next day", at(7, 1, 0), true, at(7, 2, 0)),
+		Entry("after an overnight window", at(7, 3, 0), false, at(8, 9, 0)),
+	)
+
+	It("formats blackout windows", func() {
+		Expect(util.ReplicationWindowsString(windows)).To(Equal("Mon,Tue,Wed,Thu,Fri 09:00-17:00;Sat 22:00-02:00"))
+		Expect(util.ReplicationWindowsString(nil)).To(BeEmpty())
+	})
+
+	It("validates blackout windows", func() {
+		Expect(util.ValidateReplicationWindows(windows)).To(Succeed())
+		Expect(util.ValidateReplicationWindows([]rmn.ReplicationWindow{
+			{Days: []string{"Monday"}, StartTime: "09:00", EndTime: "17:00"},
+		})).NotTo(Succeed())
+		Expect(util.ValidateReplicationWindows([]rmn.ReplicationWindow{
+			{StartTime: "9:00", EndTime: "25:00"},
+		})).NotTo(Succeed())
+	})
+})
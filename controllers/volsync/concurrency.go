@@ -0,0 +1,168 @@
+// SPDX-FileCopyrightText: The RamenDR authors
+// SPDX-License-Identifier: Apache-2.0
+
+package volsync
+
+import (
+	"sort"
+	"strings"
+
+	volsyncv1alpha1 "github.com/backube/volsync/api/v1alpha1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
+)
+
+// rsSyncState is the sync state of the ReplicationSource of a PVC
+type rsSyncState struct {
+	pvc          types.NamespacedName
+	due          bool
+	syncing      bool
+	lastSyncTime *metav1.Time
+}
+
+// getMaxConcurrentSyncs returns the cap of the number of ReplicationSources that sync at the same time, which is 0
+// if there is no cap
+func (v *VSHandler) getMaxConcurrentSyncs() int {
+	if v.maxConcurrentSyncs != nil {
+		return int(*v.maxConcurrentSyncs)
+	}
+
+	if v.profile == nil {
+		return 0
+	}
+
+	return int(v.profile.MaxConcurrentSyncs)
+}
+
+// StaggerReplicationSources selects which of the ReplicationSources of PVCs may sync, if the number that sync
+// at the same time is capped. Those that are syncing keep syncing, and those that are due to sync, or that are
+// yet to be created for an initial sync, are then selected least recently synced first, up to the cap. The others
+// are paused when they are created or updated, until a later call selects them. A ReplicationSource due to sync
+// on its schedule within a blackout window is not selected. It returns the number of ReplicationSources due to
+// sync that are not selected.
+func (v *VSHandler) StaggerReplicationSources(pvcs []types.NamespacedName) (int, error) {
+	v.throttledPVCs = nil
+
+	maxConcurrentSyncs := v.getMaxConcurrentSyncs()
+	if maxConcurrentSyncs == 0 {
+		return 0, nil
+	}
+
+	blackout, _ := v.BlackoutWindowActive()
+	states := make([]rsSyncState, 0, len(pvcs))
+	syncing := 0
+
+	for _, pvc := range pvcs {
+		state, err := v.rsSyncStateGet(pvc, blackout)
+		if err != nil {
+			return 0, err
+		}
+
+		if state.syncing {
+			syncing++
+		}
+
+		states = append(states, state)
+	}
+
+	sort.SliceStable(states, func(i, j int) bool {
+		if states[i].lastSyncTime == nil || states[j].lastSyncTime == nil {
+			return states[i].lastSyncTime == nil && states[j].lastSyncTime != nil
+		}
+
+		return states[i].lastSyncTime.Before(states[j].lastSyncTime)
+	})
+
+	v.throttledPVCs = sets.New[types.NamespacedName]()
+	waiting := 0
+
+	for _, state := range states {
+		switch {
+		case state.syncing:
+		case state.due && syncing < maxConcurrentSyncs:
+			syncing++
+		default:
+			v.throttledPVCs.Insert(state.pvc)
+
+			if state.due {
+				waiting++
+			}
+		}
+	}
+
+	v.log.V(1).Info("ReplicationSources staggered", "maxConcurrentSyncs", maxConcurrentSyncs, "syncing", syncing,
+		"waiting", waiting)
+
+	return waiting, nil
+}
+
+// rsSyncStateGet returns the sync state of the ReplicationSources of a PVC, one per peer. A PVC is syncing if any
+// of them is, and due to sync if any of them is. One that is not created yet is due to sync, as it syncs when it is
+// created.
+func (v *VSHandler) rsSyncStateGet(pvc types.NamespacedName, blackout bool) (rsSyncState, error) {
+	state := rsSyncState{pvc: pvc}
+
+	for i, peer := range v.rsPeers() {
+		peerState, err := v.rsPeerSyncStateGet(pvc, peer, blackout)
+		if err != nil {
+			return state, err
+		}
+
+		state.due = state.due || peerState.due
+		state.syncing = state.syncing || peerState.syncing
+
+		if i == 0 || (state.lastSyncTime != nil &&
+			(peerState.lastSyncTime == nil || peerState.lastSyncTime.Before(state.lastSyncTime))) {
+			state.lastSyncTime = peerState.lastSyncTime
+		}
+	}
+
+	return state, nil
+}
+
+// rsPeerSyncStateGet returns the sync state of the ReplicationSource of a PVC that syncs to a peer
+func (v *VSHandler) rsPeerSyncStateGet(pvc types.NamespacedName, peer string, blackout bool) (rsSyncState, error) {
+	state := rsSyncState{pvc: pvc}
+
+	rs, err := v.getRS(getReplicationSourceNameForPeer(pvc.Name, peer), pvc.Namespace)
+	if err != nil {
+		if !kerrors.IsNotFound(err) {
+			return state, err
+		}
+
+		state.due = !blackout
+
+		return state, nil
+	}
+
+	if rs.Status == nil {
+		state.due = !blackout || !isScheduled(rs)
+
+		return state, nil
+	}
+
+	state.lastSyncTime = rs.Status.LastSyncTime
+	state.due = meta.IsStatusConditionTrue(rs.Status.Conditions, volsyncv1alpha1.ConditionSynchronizing) &&
+		meta.FindStatusCondition(rs.Status.Conditions, volsyncv1alpha1.ConditionSynchronizing).Reason ==
+			volsyncv1alpha1.SynchronizingReasonSync
+	state.syncing = state.due && !rs.Spec.Paused
+
+	if blackout && isScheduled(rs) && !state.syncing {
+		state.due = false
+	}
+
+	return state, nil
+}
+
+func isScheduled(rs *volsyncv1alpha1.ReplicationSource) bool {
+	return rs.Spec.Trigger != nil &&
+		(rs.Spec.Trigger.Schedule != nil || strings.HasPrefix(rs.Spec.Trigger.Manual, ScheduleTriggerPrefix))
+}
+
+// replicationSourceThrottled returns true if the ReplicationSource of a PVC is to be paused while others sync
+func (v *VSHandler) replicationSourceThrottled(pvcName, pvcNamespace string) bool {
+	return v.throttledPVCs.Has(types.NamespacedName{Namespace: pvcNamespace, Name: pvcName})
+}
@@ -0,0 +1,124 @@
+// SPDX-FileCopyrightText: The RamenDR authors
+// SPDX-License-Identifier: Apache-2.0
+
+package volsync
+
+import (
+	"fmt"
+
+	volsyncv1alpha1 "github.com/backube/volsync/api/v1alpha1"
+	"golang.org/x/exp/slices"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+)
+
+// SetPeerClusters sets the name of the cluster of the VRG, and the clusters its PVCs are replicated to. With more
+// than one peer, an rsync TLS ReplicationSource of a PVC syncs to the ReplicationDestination of each peer at the
+// address of its cluster.
+func (v *VSHandler) SetPeerClusters(localCluster string, peerClusters []string) {
+	v.localCluster = localCluster
+	v.peerClusters = peerClusters
+}
+
+// isFanOut returns true if PVCs are replicated to more than one peer
+func (v *VSHandler) isFanOut() bool {
+	return len(v.peerClusters) > 1
+}
+
+// rsPeers returns the peer clusters that a PVC has a ReplicationSource for, or a single empty name for the
+// ReplicationSource of a PVC that syncs to any peer. A restic ReplicationSource backs a PVC up to a repository
+// that the ReplicationDestinations of all peers restore from.
+func (v *VSHandler) rsPeers() []string {
+	if !v.isFanOut() || v.IsMoverRestic() {
+		return []string{""}
+	}
+
+	return v.peerClusters
+}
+
+func getReplicationSourceNameForPeer(pvcName, peer string) string {
+	if peer == "" {
+		return getReplicationSourceName(pvcName)
+	}
+
+	return fmt.Sprintf("%s-%s", getReplicationSourceName(pvcName), peer)
+}
+
+// rsNames returns the names of the ReplicationSources of a PVC, one per peer
+func (v *VSHandler) rsNames(pvcName string) []string {
+	names := make([]string, 0, len(v.rsPeers()))
+
+	for _, peer := range v.rsPeers() {
+		names = append(names, getReplicationSourceNameForPeer(pvcName, peer))
+	}
+
+	return names
+}
+
+// deleteUnpeeredRS deletes the ReplicationSource of a PVC that syncs to any peer, which it had before it was
+// replicated to more than one peer, as it would otherwise keep syncing to the ReplicationDestination of one of them
+func (v *VSHandler) deleteUnpeeredRS(pvcName, pvcNamespace string) error {
+	if slices.Contains(v.rsNames(pvcName), getReplicationSourceName(pvcName)) {
+		return nil
+	}
+
+	rs, err := v.getRS(getReplicationSourceName(pvcName), pvcNamespace)
+	if err != nil {
+		if kerrors.IsNotFound(err) {
+			return nil
+		}
+
+		return err
+	}
+
+	if rs.GetLabels()[VRGOwnerNameLabel] != v.owner.GetName() ||
+		rs.GetLabels()[VRGOwnerNamespaceLabel] != v.owner.GetNamespace() {
+		return nil
+	}
+
+	if err := v.client.Delete(v.ctx, rs); err != nil && !kerrors.IsNotFound(err) {
+		return fmt.Errorf("failed to delete ReplicationSource %s/%s: %w", pvcNamespace, rs.GetName(), err)
+	}
+
+	v.log.Info("Deleted ReplicationSource replaced by one per peer", "name", rs.GetName())
+
+	return nil
+}
+
+// getRSs returns the ReplicationSources of a PVC, one per peer, or nil if any of them does not exist
+func (v *VSHandler) getRSs(pvcName, pvcNamespace string) ([]*volsyncv1alpha1.ReplicationSource, error) {
+	rss := make([]*volsyncv1alpha1.ReplicationSource, 0, len(v.rsPeers()))
+
+	for _, peer := range v.rsPeers() {
+		rs, err := v.getRS(getReplicationSourceNameForPeer(pvcName, peer), pvcNamespace)
+		if err != nil {
+			if kerrors.IsNotFound(err) {
+				return nil, nil
+			}
+
+			return nil, err
+		}
+
+		rss = append(rss, rs)
+	}
+
+	return rss, nil
+}
+
+// leastRecentlySyncedRS returns the ReplicationSource that synced least recently, which reports the data of a PVC
+// protected on all peers
+func leastRecentlySyncedRS(rss []*volsyncv1alpha1.ReplicationSource) *volsyncv1alpha1.ReplicationSource {
+	var leastRecentlySynced *volsyncv1alpha1.ReplicationSource
+
+	for _, rs := range rss {
+		if !isRSLastSyncTimeReady(rs.Status) {
+			return rs
+		}
+
+		if leastRecentlySynced == nil ||
+			rs.Status.LastSyncTime.Before(leastRecentlySynced.Status.LastSyncTime) {
+			leastRecentlySynced = rs
+		}
+	}
+
+	return leastRecentlySynced
+}
@@ -0,0 +1,260 @@
+// SPDX-FileCopyrightText: The RamenDR authors
+// SPDX-License-Identifier: Apache-2.0
+
+package volsync
+
+import (
+	"crypto/md5"
+	"encoding/binary"
+	"fmt"
+
+	volsyncv1alpha1 "github.com/backube/volsync/api/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+
+	ramendrv1alpha1 "github.com/ramendr/ramen/api/v1alpha1"
+	"github.com/ramendr/ramen/controllers/util"
+)
+
+const (
+	// VolSyncPrivilegedMoversAnnotation on a namespace allows VolSync to run privileged movers in it
+	VolSyncPrivilegedMoversAnnotation    = "volsync.backube/privileged-movers"
+	VolSyncPrivilegedMoversAnnotationVal = "true"
+
+	// ExternalDNSHostnameAnnotation requests external-dns to publish a host name for a LoadBalancer service, or
+	// for the nodes of a NodePort service
+	ExternalDNSHostnameAnnotation = "external-dns.alpha.kubernetes.io/hostname"
+
+	// The default node port range of a cluster
+	nodePortMin = 30000
+	nodePortMax = 32767
+
+	// The number of node ports tried for the service of a ReplicationDestination, from the one derived from its PVC
+	nodePortProbes = 16
+)
+
+// SetProfile sets the VolSync profile of the ReplicationSources and ReplicationDestinations. A Direct copy method
+// replaces the destination copy method.
+func (v *VSHandler) SetProfile(profile *ramendrv1alpha1.VolSyncProfile) {
+	v.profile = profile
+
+	if profile != nil && profile.CopyMethod == ramendrv1alpha1.VolSyncCopyMethodDirect {
+		v.destinationCopyMethod = volsyncv1alpha1.CopyMethodDirect
+	}
+}
+
+func (v *VSHandler) serviceTypeIsClusterIP() bool {
+	return *v.getRsyncServiceType() == corev1.ServiceTypeClusterIP
+}
+
+func (v *VSHandler) serviceTypeIsNodePort() bool {
+	return *v.getRsyncServiceType() == corev1.ServiceTypeNodePort
+}
+
+// rdServiceNodePort returns the node port of the NodePort service of the ReplicationDestination of a PVC, which
+// the ReplicationSource of the PVC on a peer cluster derives alike, from the default node port range, unless the
+// VRG of the peer reports another
+func rdServiceNodePort(pvcName, pvcNamespace string) int32 {
+	hash := md5.Sum([]byte(pvcNamespace + "/" + pvcName))
+
+	return nodePortMin + int32(binary.BigEndian.Uint32(hash[:4])%(nodePortMax-nodePortMin+1))
+}
+
+// rdServiceNodePortCandidate returns the node port of the NodePort service of the ReplicationDestination of a PVC
+// to try next, once the previous one is allocated to another service, wrapping around the default node port range
+func rdServiceNodePortCandidate(nodePort int32, probe int32) int32 {
+	return nodePortMin + (nodePort-nodePortMin+probe)%(nodePortMax-nodePortMin+1)
+}
+
+// SetPeerRDNodePorts sets the node ports of the NodePort services of the ReplicationDestinations of the PVCs on the
+// peer clusters, which the ReplicationSources sync to instead of those derived from the PVCs
+func (v *VSHandler) SetPeerRDNodePorts(nodePorts []ramendrv1alpha1.VolSyncNodePort) {
+	v.peerRDNodePorts = nodePorts
+}
+
+// RDNodePorts returns the node ports of the NodePort services of the ReplicationDestinations reconciled, for the
+// VRG to report them
+func (v *VSHandler) RDNodePorts() []ramendrv1alpha1.VolSyncNodePort {
+	return v.rdNodePorts
+}
+
+// getRsyncPort returns the port the ReplicationSource of a PVC syncs to on a peer, or on any peer if the peer name
+// is empty, if other than the default service port
+func (v *VSHandler) getRsyncPort(pvcName, pvcNamespace, peer string) *int32 {
+	if !v.serviceTypeIsNodePort() {
+		return nil
+	}
+
+	for _, nodePort := range v.peerRDNodePorts {
+		if nodePort.Name == pvcName && nodePort.Namespace == pvcNamespace && (peer == "" || nodePort.Cluster == peer) {
+			port := nodePort.NodePort
+
+			return &port
+		}
+	}
+
+	port := rdServiceNodePort(pvcName, pvcNamespace)
+
+	return &port
+}
+
+// ensureRDServiceNodePort sets the node port of the NodePort service of the ReplicationDestination of a PVC, as
+// VolSync lets the service be allocated any node port, which the ReplicationSource on the peer cluster cannot know.
+// The node port derived from the PVC is set, unless it is allocated to another service, in which case the next
+// free one is, which is reported for the ReplicationSource to sync to it instead.
+func (v *VSHandler) ensureRDServiceNodePort(pvcName, pvcNamespace string) error {
+	service := &corev1.Service{}
+	serviceName := getLocalServiceNameForRDFromPVCName(pvcName)
+
+	if err := v.client.Get(v.ctx, types.NamespacedName{Name: serviceName, Namespace: pvcNamespace},
+		service); err != nil {
+		if kerrors.IsNotFound(err) {
+			// VolSync has yet to create the service; the ReplicationDestination is not ready until it does
+			return nil
+		}
+
+		return fmt.Errorf("failed to get service %s/%s (%w)", pvcNamespace, serviceName, err)
+	}
+
+	if service.Spec.Type != corev1.ServiceTypeNodePort || len(service.Spec.Ports) != 1 {
+		return nil
+	}
+
+	nodePort := rdServiceNodePort(pvcName, pvcNamespace)
+
+	for probe := int32(0); probe < nodePortProbes; probe++ {
+		candidate := rdServiceNodePortCandidate(nodePort, probe)
+		if service.Spec.Ports[0].NodePort == candidate {
+			v.rdNodePortReport(pvcName, pvcNamespace, candidate)
+
+			return nil
+		}
+	}
+
+	var err error
+
+	for probe := int32(0); probe < nodePortProbes; probe++ {
+		candidate := rdServiceNodePortCandidate(nodePort, probe)
+		service.Spec.Ports[0].NodePort = candidate
+
+		// A node port allocated to another service is invalid
+		if err = v.client.Update(v.ctx, service); err == nil {
+			v.log.Info("Set node port of ReplicationDestination service", "service", serviceName,
+				"nodePort", candidate)
+			v.rdNodePortReport(pvcName, pvcNamespace, candidate)
+
+			return nil
+		}
+
+		if !kerrors.IsInvalid(err) {
+			break
+		}
+
+		v.log.Info("Node port of ReplicationDestination service is allocated to another service", "service",
+			serviceName, "nodePort", candidate)
+	}
+
+	return fmt.Errorf("failed to set a node port from %d of service %s/%s (%w)", nodePort, pvcNamespace,
+		serviceName, err)
+}
+
+func (v *VSHandler) rdNodePortReport(pvcName, pvcNamespace string, nodePort int32) {
+	v.rdNodePorts = append(v.rdNodePorts, ramendrv1alpha1.VolSyncNodePort{
+		Namespace: pvcNamespace,
+		Name:      pvcName,
+		NodePort:  nodePort,
+	})
+}
+
+// getLoadBalancerHostnameForRDFromPVCName returns the host name of the LoadBalancer service of the
+// ReplicationDestination of a PVC on a cluster, which is the same on each cluster if the cluster name is empty
+func (v *VSHandler) getLoadBalancerHostnameForRDFromPVCName(pvcName, rdNamespace, cluster string) string {
+	if cluster != "" {
+		return fmt.Sprintf("%s.%s.%s.%s", getLocalServiceNameForRDFromPVCName(pvcName), rdNamespace, cluster,
+			v.profile.LoadBalancerDomain)
+	}
+
+	return fmt.Sprintf("%s.%s.%s", getLocalServiceNameForRDFromPVCName(pvcName), rdNamespace,
+		v.profile.LoadBalancerDomain)
+}
+
+// getRemoteAddressForRDFromPVCName returns the address the ReplicationSource of a PVC syncs to on a peer, or on
+// any peer if the peer name is empty
+func (v *VSHandler) getRemoteAddressForRDFromPVCName(pvcName, rdNamespace, peer string) string {
+	if v.serviceTypeIsClusterIP() {
+		return getRemoteServiceNameForRDFromPVCName(pvcName, rdNamespace, peer)
+	}
+
+	return v.getLoadBalancerHostnameForRDFromPVCName(pvcName, rdNamespace, peer)
+}
+
+// getRsyncServiceAnnotations returns the annotations of the service of the ReplicationDestination of a PVC
+func (v *VSHandler) getRsyncServiceAnnotations(pvcName, rdNamespace string) *map[string]string {
+	if v.profile == nil {
+		return nil
+	}
+
+	annotations := make(map[string]string, len(v.profile.ServiceAnnotations)+1)
+	for key, value := range v.profile.ServiceAnnotations {
+		annotations[key] = value
+	}
+
+	if !v.serviceTypeIsClusterIP() {
+		cluster := ""
+		if v.isFanOut() {
+			cluster = v.localCluster
+		}
+
+		annotations[ExternalDNSHostnameAnnotation] = v.getLoadBalancerHostnameForRDFromPVCName(pvcName, rdNamespace,
+			cluster)
+	}
+
+	if len(annotations) == 0 {
+		return nil
+	}
+
+	return &annotations
+}
+
+func (v *VSHandler) getMoverSecurityContext() *corev1.PodSecurityContext {
+	if v.profile == nil {
+		return nil
+	}
+
+	return v.profile.MoverSecurityContext
+}
+
+func (v *VSHandler) getMoverServiceAccount() *string {
+	if v.profile == nil {
+		return nil
+	}
+
+	return v.profile.MoverServiceAccount
+}
+
+// ensurePrivilegedMovers annotates the namespace of a protected PVC for VolSync to run privileged movers in it, if
+// the profile requests them. The annotation is left in place when they are no longer requested, as other
+// applications in the namespace may rely on it.
+func (v *VSHandler) ensurePrivilegedMovers(namespaceName string) error {
+	if v.profile == nil || !v.profile.PrivilegedMovers {
+		return nil
+	}
+
+	namespace := &corev1.Namespace{}
+	if err := v.client.Get(v.ctx, types.NamespacedName{Name: namespaceName}, namespace); err != nil {
+		return fmt.Errorf("failed to get namespace %s (%w)", namespaceName, err)
+	}
+
+	if !util.AddAnnotation(namespace, VolSyncPrivilegedMoversAnnotation, VolSyncPrivilegedMoversAnnotationVal) {
+		return nil
+	}
+
+	if err := v.client.Update(v.ctx, namespace); err != nil {
+		return fmt.Errorf("failed to annotate namespace %s for privileged movers (%w)", namespaceName, err)
+	}
+
+	v.log.Info("Annotated namespace for privileged movers", "namespace", namespaceName)
+
+	return nil
+}
@@ -0,0 +1,244 @@
+// SPDX-FileCopyrightText: The RamenDR authors
+// SPDX-License-Identifier: Apache-2.0
+
+package volsync
+
+import (
+	"fmt"
+	"sort"
+
+	snapv1 "github.com/kubernetes-csi/external-snapshotter/client/v4/apis/volumesnapshot/v1"
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	ramendrv1alpha1 "github.com/ramendr/ramen/api/v1alpha1"
+	"github.com/ramendr/ramen/controllers/util"
+)
+
+const (
+	// RecoveryPointPVCLabel on a VolumeSnapshot retained as a recovery point is the name of its PVC
+	RecoveryPointPVCLabel = "ramendr.openshift.io/volsync-recovery-point-pvc"
+
+	defaultRetainedSnapshots = 1
+)
+
+// SetRecoveryPointTime sets the time of the recovery points to restore PVCs from, instead of the latest images of
+// their ReplicationDestinations
+func (v *VSHandler) SetRecoveryPointTime(recoveryPointTime *metav1.Time) {
+	v.recoveryPointTime = recoveryPointTime
+}
+
+func (v *VSHandler) getRetainedSnapshots() int {
+	if v.profile == nil || v.profile.RetainedSnapshots < defaultRetainedSnapshots {
+		return defaultRetainedSnapshots
+	}
+
+	return int(v.profile.RetainedSnapshots)
+}
+
+// ReconcileRecoveryPoints retains the latest image of the ReplicationDestination of a PVC as a recovery point,
+// deletes the recovery points beyond the number the profile retains, and returns the others, latest first. VolSync
+// does not delete a VolumeSnapshot labeled do-not-delete when its ReplicationDestination takes the next one.
+func (v *VSHandler) ReconcileRecoveryPoints(rdSpec ramendrv1alpha1.VolSyncReplicationDestinationSpec,
+) ([]ramendrv1alpha1.VolSyncRecoveryPoint, error) {
+	retainedSnapshots := v.getRetainedSnapshots()
+	if retainedSnapshots == defaultRetainedSnapshots || IsStaticVolume(rdSpec.ProtectedPVC) {
+		return nil, nil
+	}
+
+	pvcName, pvcNamespace := rdSpec.ProtectedPVC.Name, rdSpec.ProtectedPVC.Namespace
+
+	latestImage, err := v.getRDLatestImage(pvcName, pvcNamespace)
+	if err != nil {
+		return nil, err
+	}
+
+	if isLatestImageReady(latestImage) {
+		if err := v.recoveryPointRetain(latestImage.Name, pvcName, pvcNamespace); err != nil {
+			return nil, err
+		}
+	}
+
+	snapshots, err := v.recoveryPointSnapshotsList(pvcName, pvcNamespace)
+	if err != nil {
+		return nil, err
+	}
+
+	recoveryPoints := make([]ramendrv1alpha1.VolSyncRecoveryPoint, 0, retainedSnapshots)
+
+	for i := range snapshots {
+		snapshot := &snapshots[i]
+
+		if len(recoveryPoints) < retainedSnapshots ||
+			(latestImage != nil && snapshot.GetName() == latestImage.Name) {
+			recoveryPoints = append(recoveryPoints, ramendrv1alpha1.VolSyncRecoveryPoint{
+				SnapshotName: snapshot.GetName(),
+				Time:         snapshotTime(snapshot),
+			})
+
+			continue
+		}
+
+		// A recovery point a test failover restored from is pruned once the test failover releases it
+		if snapshot.GetLabels()[TestFailoverLabel] != "" {
+			continue
+		}
+
+		if err := v.client.Delete(v.ctx, snapshot); err != nil && !kerrors.IsNotFound(err) {
+			return nil, fmt.Errorf("failed to delete recovery point VolumeSnapshot %s/%s (%w)", pvcNamespace,
+				snapshot.GetName(), err)
+		}
+
+		v.log.Info("Deleted recovery point VolumeSnapshot", "name", snapshot.GetName(), "pvc", pvcName)
+	}
+
+	return recoveryPoints, nil
+}
+
+// recoveryPointRetain labels a VolumeSnapshot of a ReplicationDestination for VolSync not to delete it, and as a
+// recovery point of its PVC, and sets the VRG as its owner to delete it with the VRG
+func (v *VSHandler) recoveryPointRetain(snapshotName, pvcName, pvcNamespace string) error {
+	snapshot := &snapv1.VolumeSnapshot{}
+	if err := v.client.Get(v.ctx, types.NamespacedName{Name: snapshotName, Namespace: pvcNamespace},
+		snapshot); err != nil {
+		return fmt.Errorf("failed to get VolumeSnapshot %s/%s (%w)", pvcNamespace, snapshotName, err)
+	}
+
+	if util.HasLabelWithValue(snapshot, RecoveryPointPVCLabel, pvcName) {
+		return nil
+	}
+
+	updater := util.NewResourceUpdater(snapshot)
+	if !v.vrgInAdminNamespace {
+		updater.AddOwner(v.owner, v.client.Scheme())
+	}
+
+	if err := updater.AddLabel(VRGOwnerNameLabel, v.owner.GetName()).
+		AddLabel(VRGOwnerNamespaceLabel, v.owner.GetNamespace()).
+		AddLabel(VolSyncDoNotDeleteLabel, VolSyncDoNotDeleteLabelVal).
+		AddLabel(RecoveryPointPVCLabel, pvcName).
+		Update(v.ctx, v.client); err != nil {
+		return fmt.Errorf("failed to retain VolumeSnapshot %s/%s as a recovery point (%w)", pvcNamespace,
+			snapshotName, err)
+	}
+
+	v.log.Info("Retained VolumeSnapshot as a recovery point", "name", snapshotName, "pvc", pvcName)
+
+	return nil
+}
+
+// recoveryPointSnapshotsList returns the VolumeSnapshots retained as recovery points of a PVC, latest first
+func (v *VSHandler) recoveryPointSnapshotsList(pvcName, pvcNamespace string) ([]snapv1.VolumeSnapshot, error) {
+	snapshotList := &snapv1.VolumeSnapshotList{}
+	if err := v.client.List(v.ctx, snapshotList, client.InNamespace(pvcNamespace), client.MatchingLabels{
+		VRGOwnerNameLabel:      v.owner.GetName(),
+		VRGOwnerNamespaceLabel: v.owner.GetNamespace(),
+		RecoveryPointPVCLabel:  pvcName,
+	}); err != nil {
+		return nil, fmt.Errorf("failed to list recovery point VolumeSnapshots of PVC %s/%s (%w)", pvcNamespace,
+			pvcName, err)
+	}
+
+	snapshots := snapshotList.Items
+	sort.SliceStable(snapshots, func(i, j int) bool {
+		return snapshotTime(&snapshots[j]).Time.Before(snapshotTime(&snapshots[i]).Time)
+	})
+
+	return snapshots, nil
+}
+
+// snapshotTime returns the time the storage took a VolumeSnapshot, if known, or else the time it was created
+func snapshotTime(snapshot *snapv1.VolumeSnapshot) metav1.Time {
+	if snapshot.Status != nil && snapshot.Status.CreationTime != nil {
+		return *snapshot.Status.CreationTime
+	}
+
+	return snapshot.GetCreationTimestamp()
+}
+
+// getRDRecoveryImage returns the image to restore the PVC of a ReplicationDestination from: the latest image, or,
+// if a recovery point time is set, the latest of the recovery points and the latest image at or before it
+func (v *VSHandler) getRDRecoveryImage(pvcName, pvcNamespace string) (*corev1.TypedLocalObjectReference, error) {
+	latestImage, err := v.getRDLatestImage(pvcName, pvcNamespace)
+	if err != nil || v.recoveryPointTime == nil {
+		return latestImage, err
+	}
+
+	snapshots, err := v.recoveryPointSnapshotsList(pvcName, pvcNamespace)
+	if err != nil {
+		return nil, err
+	}
+
+	if isLatestImageReady(latestImage) {
+		snapshot := &snapv1.VolumeSnapshot{}
+		if err := v.client.Get(v.ctx, types.NamespacedName{Name: latestImage.Name, Namespace: pvcNamespace},
+			snapshot); err != nil {
+			return nil, fmt.Errorf("failed to get VolumeSnapshot %s/%s (%w)", pvcNamespace, latestImage.Name, err)
+		}
+
+		snapshots = append([]snapv1.VolumeSnapshot{*snapshot}, snapshots...)
+	}
+
+	var recoveryImage *snapv1.VolumeSnapshot
+
+	for i := range snapshots {
+		snapshot := &snapshots[i]
+		takenAt := snapshotTime(snapshot).Time
+
+		if takenAt.After(v.recoveryPointTime.Time) {
+			continue
+		}
+
+		if recoveryImage == nil || snapshotTime(recoveryImage).Time.Before(takenAt) {
+			recoveryImage = snapshot
+		}
+	}
+
+	if recoveryImage == nil {
+		return nil, fmt.Errorf("no recovery point of PVC %s/%s at or before %s", pvcNamespace, pvcName,
+			v.recoveryPointTime.UTC().Format(metav1.RFC3339Micro))
+	}
+
+	v.log.Info("Recovery point selected", "pvc", pvcName, "snapshot", recoveryImage.GetName(),
+		"recoveryPointTime", v.recoveryPointTime)
+
+	apiGroup := snapv1.GroupName
+
+	return &corev1.TypedLocalObjectReference{
+		APIGroup: &apiGroup,
+		Kind:     VolumeSnapshotKind,
+		Name:     recoveryImage.GetName(),
+	}, nil
+}
+
+// DeleteRecoveryPoints deletes the recovery points of a bound PVC, except the one it was restored from
+func (v *VSHandler) DeleteRecoveryPoints(pvc *corev1.PersistentVolumeClaim) error {
+	if pvc.Status.Phase != corev1.ClaimBound {
+		return nil
+	}
+
+	snapshots, err := v.recoveryPointSnapshotsList(pvc.GetName(), pvc.GetNamespace())
+	if err != nil {
+		return err
+	}
+
+	for i := range snapshots {
+		snapshot := &snapshots[i]
+
+		if pvc.Spec.DataSource != nil && pvc.Spec.DataSource.Name == snapshot.GetName() {
+			continue
+		}
+
+		if err := v.client.Delete(v.ctx, snapshot); err != nil && !kerrors.IsNotFound(err) {
+			return fmt.Errorf("failed to delete recovery point VolumeSnapshot %s/%s (%w)", pvc.GetNamespace(),
+				snapshot.GetName(), err)
+		}
+
+		v.log.Info("Deleted recovery point VolumeSnapshot", "name", snapshot.GetName(), "pvc", pvc.GetName())
+	}
+
+	return nil
+}
@@ -0,0 +1,223 @@
+// SPDX-FileCopyrightText: The RamenDR authors
+// SPDX-License-Identifier: Apache-2.0
+
+package volsync
+
+import (
+	"fmt"
+	"strings"
+
+	volsyncv1alpha1 "github.com/backube/volsync/api/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	ctrlutil "sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	ramendrv1alpha1 "github.com/ramendr/ramen/api/v1alpha1"
+	"github.com/ramendr/ramen/controllers/util"
+)
+
+const (
+	// ResticRestoreTriggerString is the manual trigger of a ReplicationDestination to pull the latest snapshot
+	// of its restic repository before its PVC is restored
+	ResticRestoreTriggerString string = "vrg-restore"
+
+	// ResticRetainHourly is the number of hours whose latest snapshot is kept in a restic repository. The latest
+	// is the one restored, and the previous one is kept while a ReplicationDestination may still be pulling it.
+	ResticRetainHourly int32 = 2
+
+	resticCACertificatesKey = "ca.crt"
+	pskSecretKey            = "psk.txt"
+)
+
+// ResticRepository has the S3 store location and credentials of the restic repositories of a VRG's PVCs. The
+// repository of a PVC is under the URL, in a path of its namespace and name.
+type ResticRepository struct {
+	// URL of the repositories, in restic's s3:<endpoint>/<bucket>/<path> form
+	URL             string
+	Region          string
+	AccessKeyID     []byte
+	SecretAccessKey []byte
+	CACertificates  []byte
+}
+
+// SetResticRepository sets the repository of the Restic mover. A nil repository fails the reconcile of the
+// ReplicationSources and ReplicationDestinations that use the Restic mover.
+func (v *VSHandler) SetResticRepository(repository *ResticRepository) {
+	v.resticRepository = repository
+}
+
+// IsMoverRestic returns true if the profile replicates PVCs with the Restic mover
+func (v *VSHandler) IsMoverRestic() bool {
+	return v.profile != nil && v.profile.Mover == ramendrv1alpha1.VolSyncMoverRestic
+}
+
+func getResticSecretName(pvcName string) string {
+	return fmt.Sprintf("volsync-restic-%s", pvcName)
+}
+
+// reconcileResticSecret creates or updates the secret of the restic repository of a PVC. Its password is the
+// VolSync pre-shared key the VRG's secret was created with, which is the same on its peer clusters.
+func (v *VSHandler) reconcileResticSecret(pvcName, pvcNamespace, pskSecretName string) (string, error) {
+	if v.resticRepository == nil {
+		return "", fmt.Errorf("restic repository for PVC %s/%s not configured", pvcNamespace, pvcName)
+	}
+
+	pskSecret := &corev1.Secret{}
+	if err := v.client.Get(v.ctx, types.NamespacedName{Name: pskSecretName, Namespace: v.owner.GetNamespace()},
+		pskSecret); err != nil {
+		return "", fmt.Errorf("error getting secret %s (%w)", pskSecretName, err)
+	}
+
+	password := pskSecret.Data[resticPasswordSecretKey]
+	if len(password) == 0 {
+		password = pskSecret.Data[pskSecretKey]
+	}
+
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      getResticSecretName(pvcName),
+			Namespace: pvcNamespace,
+		},
+	}
+
+	op, err := ctrlutil.CreateOrUpdate(v.ctx, v.client, secret, func() error {
+		if !v.vrgInAdminNamespace {
+			if err := ctrl.SetControllerReference(v.owner, secret, v.client.Scheme()); err != nil {
+				return fmt.Errorf("%w", err)
+			}
+		}
+
+		util.AddLabel(secret, VRGOwnerNameLabel, v.owner.GetName())
+		util.AddLabel(secret, VRGOwnerNamespaceLabel, v.owner.GetNamespace())
+
+		secret.Data = map[string][]byte{
+			"RESTIC_REPOSITORY": []byte(strings.TrimSuffix(v.resticRepository.URL, "/") + "/" +
+				pvcNamespace + "/" + pvcName),
+			"RESTIC_PASSWORD":       password,
+			"AWS_ACCESS_KEY_ID":     v.resticRepository.AccessKeyID,
+			"AWS_SECRET_ACCESS_KEY": v.resticRepository.SecretAccessKey,
+			"AWS_DEFAULT_REGION":    []byte(v.resticRepository.Region),
+		}
+
+		if len(v.resticRepository.CACertificates) != 0 {
+			secret.Data[resticCACertificatesKey] = v.resticRepository.CACertificates
+		}
+
+		return nil
+	})
+	if err != nil {
+		return "", fmt.Errorf("error creating or updating restic secret for PVC %s/%s (%w)", pvcNamespace, pvcName,
+			err)
+	}
+
+	v.log.V(1).Info("Restic secret createOrUpdate Complete", "op", op, "pvc", pvcName)
+
+	return secret.GetName(), nil
+}
+
+func (v *VSHandler) resticCustomCASecretName(resticSecretName string) string {
+	if len(v.resticRepository.CACertificates) == 0 {
+		return ""
+	}
+
+	return resticSecretName
+}
+
+func (v *VSHandler) resticCustomCAKey() string {
+	if len(v.resticRepository.CACertificates) == 0 {
+		return ""
+	}
+
+	return resticCACertificatesKey
+}
+
+// rdResticSpecSet sets a ReplicationDestination to pull the snapshots of its PVC's restic repository on the
+// PVC's schedule, outside of blackout windows, unless it is pulling the latest snapshot for a restore
+func (v *VSHandler) rdResticSpecSet(rd *volsyncv1alpha1.ReplicationDestination,
+	rdSpec ramendrv1alpha1.VolSyncReplicationDestinationSpec, resticSecretName string,
+	volumeOptions volsyncv1alpha1.ReplicationDestinationVolumeOptions,
+) error {
+	if rd.Spec.Trigger == nil || rd.Spec.Trigger.Manual != ResticRestoreTriggerString {
+		scheduleCronSpec, err := v.getScheduleCronSpec(rdSpec.ProtectedPVC.SchedulingInterval)
+		if err != nil {
+			return err
+		}
+
+		manual := ""
+		if rd.Spec.Trigger != nil {
+			manual = rd.Spec.Trigger.Manual
+		}
+
+		schedule, manual, err := v.scheduleTrigger(*scheduleCronSpec, manual)
+		if err != nil {
+			return err
+		}
+
+		rd.Spec.Trigger = &volsyncv1alpha1.ReplicationDestinationTriggerSpec{Schedule: schedule, Manual: manual}
+		rd.Spec.Paused, _ = v.BlackoutWindowActive()
+	}
+
+	rd.Spec.RsyncTLS = nil
+	rd.Spec.Restic = &volsyncv1alpha1.ReplicationDestinationResticSpec{
+		ReplicationDestinationVolumeOptions: volumeOptions,
+		Repository:                          resticSecretName,
+		CustomCA: volsyncv1alpha1.ReplicationDestinationResticCA{
+			SecretName: v.resticCustomCASecretName(resticSecretName),
+			Key:        v.resticCustomCAKey(),
+		},
+		MoverSecurityContext: v.getMoverSecurityContext(),
+		MoverServiceAccount:  v.getMoverServiceAccount(),
+	}
+
+	return nil
+}
+
+// rsResticSpecSet sets a ReplicationSource to push the snapshots of its PVC to the PVC's restic repository
+func (v *VSHandler) rsResticSpecSet(rs *volsyncv1alpha1.ReplicationSource, resticSecretName string,
+	volumeOptions volsyncv1alpha1.ReplicationSourceVolumeOptions,
+) {
+	retainHourly := ResticRetainHourly
+
+	rs.Spec.RsyncTLS = nil
+	rs.Spec.Restic = &volsyncv1alpha1.ReplicationSourceResticSpec{
+		ReplicationSourceVolumeOptions: volumeOptions,
+		Repository:                     resticSecretName,
+		CustomCA: volsyncv1alpha1.ReplicationSourceResticCA{
+			SecretName: v.resticCustomCASecretName(resticSecretName),
+			Key:        v.resticCustomCAKey(),
+		},
+		Retain:               &volsyncv1alpha1.ResticRetainPolicy{Hourly: &retainHourly},
+		MoverSecurityContext: v.getMoverSecurityContext(),
+		MoverServiceAccount:  v.getMoverServiceAccount(),
+	}
+}
+
+// resticRDPullLatest triggers the ReplicationDestination of a PVC to pull the latest snapshot of its restic
+// repository, which includes the final sync of a relocation, before its PVC is restored. It returns an error
+// until the pull completes.
+func (v *VSHandler) resticRDPullLatest(rdSpec ramendrv1alpha1.VolSyncReplicationDestinationSpec) error {
+	rd, err := v.getRD(rdSpec.ProtectedPVC.Name, rdSpec.ProtectedPVC.Namespace)
+	if err != nil || rd == nil || rd.Spec.Restic == nil {
+		return err
+	}
+
+	if rd.Status != nil && rd.Status.LastManualSync == ResticRestoreTriggerString {
+		return nil
+	}
+
+	if rd.Spec.Trigger == nil || rd.Spec.Trigger.Manual != ResticRestoreTriggerString || rd.Spec.Paused {
+		rd.Spec.Trigger = &volsyncv1alpha1.ReplicationDestinationTriggerSpec{Manual: ResticRestoreTriggerString}
+		rd.Spec.Paused = false
+
+		if err := v.client.Update(v.ctx, rd); err != nil {
+			return fmt.Errorf("failed to trigger ReplicationDestination %s to pull latest snapshot (%w)", rd.GetName(),
+				err)
+		}
+
+		v.log.Info("Triggered ReplicationDestination to pull latest snapshot", "rd", rd.GetName())
+	}
+
+	return fmt.Errorf("waiting for ReplicationDestination %s to pull latest snapshot", rd.GetName())
+}
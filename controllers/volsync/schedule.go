@@ -0,0 +1,69 @@
+// SPDX-FileCopyrightText: The RamenDR authors
+// SPDX-License-Identifier: Apache-2.0
+
+package volsync
+
+import (
+	"strings"
+	"time"
+
+	"github.com/ramendr/ramen/controllers/util"
+)
+
+const (
+	// ScheduleTriggerPrefix prefixes the manual trigger of a ReplicationSource, or restic ReplicationDestination,
+	// whose cron schedule the VolSync API does not accept, followed by the time the sync was due
+	ScheduleTriggerPrefix     = "vrg-schedule-"
+	scheduleTriggerTimeLayout = "20060102150405"
+)
+
+// scheduleTrigger returns the trigger of a ReplicationSource, or restic ReplicationDestination, syncing on a cron
+// schedule: the schedule itself, if the VolSync API accepts it, or else a manual trigger, for the latest time the
+// schedule was due since its current manual trigger, or for now if it has none. The next time the schedule is due
+// is tracked for the VRG to be requeued then.
+func (v *VSHandler) scheduleTrigger(schedule, manual string) (*string, string, error) {
+	if util.VolSyncScheduleSupported(schedule) {
+		return &schedule, "", nil
+	}
+
+	manual, err := v.scheduleManualTrigger(schedule, manual)
+	if err != nil {
+		return nil, "", err
+	}
+
+	return nil, manual, nil
+}
+
+// scheduleManualTrigger returns the manual trigger of a cron schedule: for the latest time the schedule was due
+// since the current manual trigger, or for now if there is none
+func (v *VSHandler) scheduleManualTrigger(schedule, manual string) (string, error) {
+	cronSchedule, err := util.ParseCronSchedule(schedule)
+	if err != nil {
+		return "", err
+	}
+
+	now := time.Now().UTC()
+	due := now
+
+	if dueString, ok := strings.CutPrefix(manual, ScheduleTriggerPrefix); ok {
+		if previous, err := time.Parse(scheduleTriggerTimeLayout, dueString); err == nil {
+			due = previous
+
+			for next := cronSchedule.Next(due); !next.After(now); next = cronSchedule.Next(next) {
+				due = next
+			}
+		}
+	}
+
+	if next := cronSchedule.Next(now); v.scheduledSyncNext.IsZero() || next.Before(v.scheduledSyncNext) {
+		v.scheduledSyncNext = next
+	}
+
+	return ScheduleTriggerPrefix + due.Format(scheduleTriggerTimeLayout), nil
+}
+
+// ScheduledSyncNext returns the next time a ReplicationSource, or restic ReplicationDestination, that is triggered
+// manually on its schedule is due to sync, which is zero if there is none
+func (v *VSHandler) ScheduledSyncNext() time.Time {
+	return v.scheduledSyncNext
+}
@@ -10,6 +10,7 @@ import (
 
 	"github.com/go-logr/logr"
 	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	ctrl "sigs.k8s.io/controller-runtime"
@@ -62,6 +63,59 @@ func CleanupSecretPropagation(ctx context.Context, k8sClient client.Client,
 	return sp.cleanup()
 }
 
+// PropagateSecretToClustersWithManifestWork propagates a secret on the hub to destClusters with a ManifestWork in
+// the namespace of each, which does not require the OCM governance policy add-on. The ManifestWorks are named for
+// the MWUtil's instance, and embed the data of the secret, unencrypted, in their spec.
+func PropagateSecretToClustersWithManifestWork(mwu *util.MWUtil, sourceSecret *corev1.Secret,
+	destClusters []string, destSecretName, destSecretNamespace string,
+) error {
+	secret := corev1.Secret{
+		TypeMeta: metav1.TypeMeta{Kind: "Secret", APIVersion: "v1"},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      destSecretName,
+			Namespace: destSecretNamespace,
+		},
+		Type: corev1.SecretTypeOpaque,
+		Data: sourceSecret.Data,
+	}
+
+	for _, clusterName := range destClusters {
+		if err := mwu.CreateOrUpdateVolSyncSecretManifestWork(clusterName, secret); err != nil {
+			mwu.Log.Error(err, "Error creating or updating secret propagation ManifestWork", "cluster", clusterName)
+
+			return fmt.Errorf("error creating or updating secret propagation ManifestWork on cluster %s (%w)",
+				clusterName, err)
+		}
+	}
+
+	mwu.Log.V(1).Info("Secret propagation ManifestWorks createOrUpdate Complete", "destinationClusters",
+		destClusters)
+
+	return nil
+}
+
+// Cleans up the ManifestWorks used to replicate the volsync secret to clusters (if they exist)
+// does not throw an error if they do not exist. As it runs on each reconcile of a DRPC that propagates the secret
+// with a Policy, a ManifestWork is looked up in the cache, and deleted only if it exists.
+func CleanupSecretPropagationManifestWorks(mwu *util.MWUtil, clusters []string) error {
+	for _, clusterName := range clusters {
+		mw, err := mwu.FindManifestWorkByType(util.MWTypeVolSyncSecret, clusterName)
+		if err != nil {
+			if kerrors.IsNotFound(err) {
+				continue
+			}
+
+			return err
+		}
+
+		if err := mwu.DeleteManifestWork(mw.GetName(), clusterName); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
 type secretPropagator struct {
 	Context              context.Context
 	Client               client.Client
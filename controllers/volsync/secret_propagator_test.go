@@ -17,6 +17,7 @@ import (
 	"k8s.io/client-go/kubernetes/scheme"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
+	ocmworkv1 "github.com/open-cluster-management/api/work/v1"
 	"github.com/ramendr/ramen/controllers/util"
 	"github.com/ramendr/ramen/controllers/volsync"
 	plrulev1 "github.com/stolostron/multicloud-operators-placementrule/pkg/apis/apps/v1"
@@ -302,6 +303,136 @@ var _ = Describe("Secret_propagator", func() {
 			})
 		})
 	})
+
+	Describe("Secret propagation from hub with ManifestWork", func() {
+		var testSecret *corev1.Secret
+		var mwu *util.MWUtil
+		var destClusters []string
+
+		destSecName := "my-secret-on-mgd"
+		destSecNamespace := "managed-cluster-ns-1"
+
+		BeforeEach(func() {
+			testSecret = &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{
+					GenerateName: "dummy-hub-vs-secret-",
+					Namespace:    testNamespace.GetName(),
+				},
+				StringData: map[string]string{
+					"abc": "def",
+				},
+			}
+			Expect(k8sClient.Create(ctx, testSecret)).To(Succeed())
+			Eventually(func() error {
+				return k8sClient.Get(ctx, client.ObjectKeyFromObject(testSecret), testSecret)
+			}, maxWait, interval).Should(Succeed())
+
+			// Managed cluster namespaces on the hub
+			destClusters = nil
+			for i := 0; i < 2; i++ {
+				clusterNamespace := &corev1.Namespace{
+					ObjectMeta: metav1.ObjectMeta{GenerateName: "sec-prop-cluster-"},
+				}
+				Expect(k8sClient.Create(ctx, clusterNamespace)).To(Succeed())
+				destClusters = append(destClusters, clusterNamespace.GetName())
+			}
+
+			mwu = &util.MWUtil{
+				Client:          k8sClient,
+				APIReader:       k8sClient,
+				Ctx:             ctx,
+				Log:             logger,
+				InstName:        owner.GetName(),
+				TargetNamespace: destSecNamespace,
+			}
+
+			Expect(volsync.PropagateSecretToClustersWithManifestWork(mwu, testSecret, destClusters,
+				destSecName, destSecNamespace)).To(Succeed())
+		})
+
+		AfterEach(func() {
+			for _, clusterName := range destClusters {
+				Expect(k8sClient.Delete(ctx, &corev1.Namespace{
+					ObjectMeta: metav1.ObjectMeta{Name: clusterName},
+				})).To(Succeed())
+			}
+		})
+
+		getManifestWork := func(clusterName string) (*ocmworkv1.ManifestWork, error) {
+			mw := &ocmworkv1.ManifestWork{}
+			err := k8sClient.Get(ctx, types.NamespacedName{
+				Name:      mwu.BuildManifestWorkName(util.MWTypeVolSyncSecret),
+				Namespace: clusterName,
+			}, mw)
+
+			return mw, err
+		}
+
+		It("Should create a ManifestWork of the secret on each cluster", func() {
+			for _, clusterName := range destClusters {
+				var mw *ocmworkv1.ManifestWork
+				Eventually(func() error {
+					var err error
+					mw, err = getManifestWork(clusterName)
+
+					return err
+				}, maxWait, interval).Should(Succeed())
+
+				Expect(mw.GetLabels()["velero.io/exclude-from-backup"]).Should(Equal("true"))
+				Expect(mw.Spec.Workload.Manifests).To(HaveLen(1))
+
+				embeddedSecret := &corev1.Secret{}
+				Expect(json.Unmarshal(mw.Spec.Workload.Manifests[0].Raw, embeddedSecret)).To(Succeed())
+				Expect(embeddedSecret.Kind).To(Equal("Secret"))
+				Expect(embeddedSecret.GetName()).To(Equal(destSecName))
+				Expect(embeddedSecret.GetNamespace()).To(Equal(destSecNamespace))
+				Expect(embeddedSecret.Data).To(Equal(testSecret.Data))
+			}
+		})
+
+		It("Should update the ManifestWorks when the secret changes", func() {
+			testSecret.Data["abc"] = []byte("ghi")
+			Expect(volsync.PropagateSecretToClustersWithManifestWork(mwu, testSecret, destClusters,
+				destSecName, destSecNamespace)).To(Succeed())
+
+			Eventually(func() []byte {
+				mw, err := getManifestWork(destClusters[0])
+				if err != nil {
+					return nil
+				}
+
+				embeddedSecret := &corev1.Secret{}
+				if err := json.Unmarshal(mw.Spec.Workload.Manifests[0].Raw, embeddedSecret); err != nil {
+					return nil
+				}
+
+				return embeddedSecret.Data["abc"]
+			}, maxWait, interval).Should(Equal([]byte("ghi")))
+		})
+
+		It("Should cleanup the ManifestWorks", func() {
+			Eventually(func() error {
+				_, err := getManifestWork(destClusters[1])
+
+				return err
+			}, maxWait, interval).Should(Succeed())
+
+			Expect(volsync.CleanupSecretPropagationManifestWorks(mwu, destClusters)).To(Succeed())
+
+			Eventually(func() bool {
+				for _, clusterName := range destClusters {
+					if _, err := getManifestWork(clusterName); !kerrors.IsNotFound(err) {
+						return false
+					}
+				}
+
+				return true
+			}, maxWait, interval).Should(BeTrue())
+
+			// Cleanup again with no ManifestWorks
+			Expect(volsync.CleanupSecretPropagationManifestWorks(mwu, destClusters)).To(Succeed())
+		})
+	})
 })
 
 func verifyPlacementRuleClusters(placementRuleClusters []plrulev1.GenericClusterReference,
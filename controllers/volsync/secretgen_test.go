@@ -4,6 +4,8 @@
 package volsync_test
 
 import (
+	"time"
+
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 
@@ -120,4 +122,54 @@ var _ = Describe("Secretgen", func() {
 			})
 		})
 	})
+
+	Describe("Rotate volsync rsync secret", func() {
+		testSecretName := "test-secret-rotate"
+
+		var testSecret *corev1.Secret
+		var originalPSK []byte
+
+		JustBeforeEach(func() {
+			var err error
+			testSecret, err = volsync.ReconcileVolSyncReplicationSecret(ctx, k8sClient, owner,
+				testSecretName, testNamespace.GetName(), logger)
+			Expect(err).NotTo(HaveOccurred())
+
+			Eventually(func() error {
+				return k8sClient.Get(ctx, client.ObjectKeyFromObject(testSecret), testSecret)
+			}, maxWait, interval).Should(Succeed())
+
+			originalPSK = testSecret.Data["psk.txt"]
+		})
+
+		rotate := func(rotationInterval time.Duration, keysInUse bool) []string {
+			Expect(volsync.RotateVolSyncReplicationSecret(ctx, k8sClient, testSecret, rotationInterval, keysInUse,
+				logger)).To(Succeed())
+
+			return volsync.PSKIdentities(testSecret)
+		}
+
+		It("Should not rotate the key before the rotation interval", func() {
+			Expect(rotate(time.Hour, true)).To(Equal([]string{"volsyncramen"}))
+			Expect(rotate(0, true)).To(Equal([]string{"volsyncramen"}))
+		})
+
+		It("Should not rotate the key while the movers are not using the keys", func() {
+			Expect(rotate(time.Nanosecond, false)).To(Equal([]string{"volsyncramen"}))
+		})
+
+		It("Should add, switch to, and then retire a new key, and keep the restic password", func() {
+			identities := rotate(time.Nanosecond, true)
+			Expect(identities).To(HaveLen(2))
+			Expect(identities[0]).To(Equal("volsyncramen"))
+
+			newIdentity := identities[1]
+			Expect(rotate(time.Nanosecond, false)).To(Equal([]string{"volsyncramen", newIdentity}))
+			Expect(rotate(time.Nanosecond, true)).To(Equal([]string{newIdentity, "volsyncramen"}))
+			Expect(rotate(time.Nanosecond, true)).To(Equal([]string{newIdentity}))
+
+			Expect(testSecret.Data["restic-password"]).To(Equal(originalPSK))
+			Expect(volsync.PSKKeyTime(testSecret).Unix()).To(BeNumerically("~", time.Now().Unix(), 60))
+		})
+	})
 })
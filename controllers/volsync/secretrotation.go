@@ -0,0 +1,167 @@
+// SPDX-FileCopyrightText: The RamenDR authors
+// SPDX-License-Identifier: Apache-2.0
+
+package volsync
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	tlsPSKIdentity = "volsyncramen"
+
+	// resticPasswordSecretKey of a VolSync PSK secret is the password of the restic repositories, which is the
+	// key the secret was created with, as the password of a restic repository is not rotated with the key
+	resticPasswordSecretKey = "restic-password"
+)
+
+// PSKIdentities returns the identities of the keys of a VolSync PSK secret. The rsync TLS movers connect with the
+// key of the first identity, and accept connections with the keys of all of them.
+func PSKIdentities(secret *corev1.Secret) []string {
+	identities := []string{}
+
+	for _, line := range pskLines(secret) {
+		identity, _, _ := strings.Cut(line, ":")
+		identities = append(identities, identity)
+	}
+
+	return identities
+}
+
+// PSKKeyTime returns when the key that the rsync TLS movers connect with was generated
+func PSKKeyTime(secret *corev1.Secret) *metav1.Time {
+	identities := PSKIdentities(secret)
+	if len(identities) == 0 {
+		return nil
+	}
+
+	keyTime := pskIdentityTime(secret, identities[0])
+
+	return &keyTime
+}
+
+func pskLines(secret *corev1.Secret) []string {
+	lines := []string{}
+
+	for _, line := range strings.Split(string(secret.Data[pskSecretKey]), "\n") {
+		if line != "" {
+			lines = append(lines, line)
+		}
+	}
+
+	return lines
+}
+
+// pskIdentityTime returns when the key of an identity was generated, which is the time the secret was created for
+// the identity the secret is created with
+func pskIdentityTime(secret *corev1.Secret, identity string) metav1.Time {
+	seconds := pskIdentitySeconds(identity)
+	if seconds == 0 {
+		return secret.GetCreationTimestamp()
+	}
+
+	return metav1.Unix(seconds, 0)
+}
+
+// pskIdentitySeconds returns the Unix time in seconds that the key of an identity was generated, or 0 for the
+// identity the secret is created with, whose key is older than the keys of the others
+func pskIdentitySeconds(identity string) int64 {
+	seconds, err := strconv.ParseInt(strings.TrimPrefix(identity, tlsPSKIdentity+"-"), 10, 64)
+	if err != nil {
+		return 0
+	}
+
+	return seconds
+}
+
+// RotateVolSyncReplicationSecret rotates the key of a VolSync PSK secret on the hub once it is older than the
+// rotation interval. So that the rsync TLS movers of the peer clusters share a key throughout, it does so in
+// steps, each taken once keysInUse reports that the movers of all clusters have restarted with the keys of the
+// previous step:
+//  1. A new key is added after the old one, which movers accept, but do not connect with.
+//  2. The new key is moved first, which movers connect with, while accepting the old one.
+//  3. The old key is removed.
+func RotateVolSyncReplicationSecret(ctx context.Context, k8sClient client.Client, secret *corev1.Secret,
+	rotationInterval time.Duration, keysInUse bool, log logr.Logger,
+) error {
+	lines := pskLines(secret)
+	if len(lines) == 0 || !keysInUse {
+		return nil
+	}
+
+	identities := PSKIdentities(secret)
+
+	switch {
+	case len(lines) == 1:
+		keyTime := pskIdentityTime(secret, identities[0])
+		if rotationInterval == 0 || time.Since(keyTime.Time) < rotationInterval {
+			return nil
+		}
+
+		tlsKey, err := genTLSPreSharedKey(log)
+		if err != nil {
+			return err
+		}
+
+		// The identity of the new key is unique, and orders after the old one
+		seconds := time.Now().Unix()
+		if seconds <= pskIdentitySeconds(identities[0]) {
+			seconds = pskIdentitySeconds(identities[0]) + 1
+		}
+
+		lines = append(lines, fmt.Sprintf("%s-%d:%s", tlsPSKIdentity, seconds, tlsKey))
+
+		log.Info("Adding a new VolSync pre-shared key", "secretName", secret.GetName())
+	case pskIdentitySeconds(identities[1]) > pskIdentitySeconds(identities[0]):
+		lines[0], lines[1] = lines[1], lines[0]
+
+		log.Info("Switching to the new VolSync pre-shared key", "secretName", secret.GetName())
+	default:
+		lines = lines[:1]
+
+		log.Info("Removing the old VolSync pre-shared key", "secretName", secret.GetName())
+	}
+
+	if len(secret.Data[resticPasswordSecretKey]) == 0 {
+		secret.Data[resticPasswordSecretKey] = secret.Data[pskSecretKey]
+	}
+
+	secret.Data[pskSecretKey] = []byte(strings.Join(lines, "\n"))
+
+	if err := k8sClient.Update(ctx, secret); err != nil {
+		return fmt.Errorf("failed to rotate VolSync pre-shared key secret %s (%w)", secret.GetName(), err)
+	}
+
+	return nil
+}
+
+// GetPSKIdentities returns the identities of the keys of the VolSync PSK secret of the VRG, or nil if the secret
+// does not exist yet
+func (v *VSHandler) GetPSKIdentities() ([]string, error) {
+	secret := &corev1.Secret{}
+
+	err := v.client.Get(v.ctx, types.NamespacedName{
+		Name:      GetVolSyncPSKSecretNameFromVRGName(v.owner.GetName()),
+		Namespace: v.owner.GetNamespace(),
+	}, secret)
+	if err != nil {
+		if kerrors.IsNotFound(err) {
+			return nil, nil
+		}
+
+		return nil, fmt.Errorf("error getting VolSync pre-shared key secret (%w)", err)
+	}
+
+	return PSKIdentities(secret), nil
+}
@@ -0,0 +1,116 @@
+// SPDX-FileCopyrightText: The RamenDR authors
+// SPDX-License-Identifier: Apache-2.0
+
+package volsync
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+
+	volsyncv1alpha1 "github.com/backube/volsync/api/v1alpha1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+var (
+	// rsyncSentRegex matches the stats line of each rsync of an rsync TLS mover, such as
+	// "sent 833.81K bytes  received 397.18K bytes  30.39K bytes/sec" or "sent 1,003 bytes  received 5,657 bytes"
+	rsyncSentRegex = regexp.MustCompile(`[sS]ent\s+([0-9.,]+)([KMGTP]?)\s+bytes`)
+
+	// resticAddedRegex matches the stats line of a restic mover, such as
+	// "Added to the repository: 12.941 MiB (12.529 MiB stored)"
+	resticAddedRegex = regexp.MustCompile(
+		`[aA]dded to the repository:\s+([0-9.]+)\s+([KMGTP]?i?B)(?:\s+\(([0-9.]+)\s+([KMGTP]?i?B)\s+stored\))?`)
+)
+
+// ReplicationSourceLastSyncBytes returns the number of bytes that the mover of a ReplicationSource transferred in
+// its most recent successful sync, parsed from the mover logs that VolSync reports in its status, or nil if it is
+// unknown
+func ReplicationSourceLastSyncBytes(rs *volsyncv1alpha1.ReplicationSource) *int64 {
+	if rs.Status == nil || rs.Status.LatestMoverStatus == nil ||
+		rs.Status.LatestMoverStatus.Result != volsyncv1alpha1.MoverResultSuccessful {
+		return nil
+	}
+
+	return moverLogsBytes(rs.Status.LatestMoverStatus.Logs)
+}
+
+// moverLogsBytes returns the sum of the bytes sent by the rsyncs of an rsync TLS mover, or the bytes stored by a
+// restic mover, in its logs
+func moverLogsBytes(logs string) *int64 {
+	var bytes *int64
+
+	add := func(value float64) {
+		if bytes == nil {
+			bytes = new(int64)
+		}
+
+		*bytes += int64(value)
+	}
+
+	for _, match := range rsyncSentRegex.FindAllStringSubmatch(logs, -1) {
+		value, err := strconv.ParseFloat(strings.ReplaceAll(match[1], ",", ""), 64)
+		if err != nil {
+			continue
+		}
+
+		// rsync -h scales by 1000
+		add(value * unitMultiplier(match[2], 1000))
+	}
+
+	for _, match := range resticAddedRegex.FindAllStringSubmatch(logs, -1) {
+		valueString, unit := match[1], match[2]
+		if match[3] != "" {
+			valueString, unit = match[3], match[4]
+		}
+
+		value, err := strconv.ParseFloat(valueString, 64)
+		if err != nil {
+			continue
+		}
+
+		// restic scales by 1024
+		add(value * unitMultiplier(strings.TrimSuffix(strings.TrimSuffix(unit, "B"), "i"), 1024))
+	}
+
+	return bytes
+}
+
+// unitMultiplier returns the multiplier of a unit prefix, such as K or M, scaled by base
+func unitMultiplier(prefix string, base float64) float64 {
+	multiplier := 1.0
+
+	for i := 0; i <= strings.Index("KMGTP", prefix) && prefix != ""; i++ {
+		multiplier *= base
+	}
+
+	return multiplier
+}
+
+// ReplicationSourceSyncProgress returns whether a sync of a ReplicationSource is in progress, and if so, its start
+// time and its estimated completion time, which is its start time plus the duration of the most recent successful
+// sync, if known
+func ReplicationSourceSyncProgress(rs *volsyncv1alpha1.ReplicationSource,
+) (inProgress bool, startTime, estimatedCompletionTime *metav1.Time) {
+	if rs.Status == nil || rs.Spec.Paused {
+		return false, nil, nil
+	}
+
+	condition := meta.FindStatusCondition(rs.Status.Conditions, volsyncv1alpha1.ConditionSynchronizing)
+	if condition == nil || condition.Status != metav1.ConditionTrue ||
+		condition.Reason != volsyncv1alpha1.SynchronizingReasonSync {
+		return false, nil, nil
+	}
+
+	startTime = rs.Status.LastSyncStartTime
+	if startTime == nil {
+		return true, nil, nil
+	}
+
+	if rs.Status.LastSyncDuration != nil {
+		estimatedCompletionTime = &metav1.Time{Time: startTime.Add(rs.Status.LastSyncDuration.Duration)}
+	}
+
+	return true, startTime, estimatedCompletionTime
+}
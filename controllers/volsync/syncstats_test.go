@@ -0,0 +1,80 @@
+// SPDX-FileCopyrightText: The RamenDR authors
+// SPDX-License-Identifier: Apache-2.0
+
+package volsync_test
+
+import (
+	"time"
+
+	volsyncv1alpha1 "github.com/backube/volsync/api/v1alpha1"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/ramendr/ramen/controllers/volsync"
+)
+
+var _ = Describe("Sync stats", func() {
+	rsWithMoverStatus := func(result volsyncv1alpha1.MoverResult, logs string) *volsyncv1alpha1.ReplicationSource {
+		return &volsyncv1alpha1.ReplicationSource{
+			Status: &volsyncv1alpha1.ReplicationSourceStatus{
+				LatestMoverStatus: &volsyncv1alpha1.MoverStatus{Result: result, Logs: logs},
+			},
+		}
+	}
+
+	DescribeTable("parses the bytes transferred by a mover",
+		func(result volsyncv1alpha1.MoverResult, logs string, expected *int64) {
+			Expect(volsync.ReplicationSourceLastSyncBytes(rsWithMoverStatus(result, logs))).To(Equal(expected))
+		},
+		Entry("rsync TLS", volsyncv1alpha1.MoverResultSuccessful, `
+sent 833.81K bytes  received 397.18K bytes  30.39K bytes/sec
+total size is 1.07M  speedup is 0.87
+sent 1,003 bytes  received 5,657 bytes  13,320.00 bytes/sec
+total size is 1,065,432  speedup is 159.98
+rsync completed in 41s`, ptr(int64(833810+1003))),
+		Entry("restic", volsyncv1alpha1.MoverResultSuccessful, `
+Processed 2 files, 12.941 MiB in 0:01
+Added to the repository: 12.941 MiB (12.529 MiB stored)
+Restic completed in 3s`, ptr(int64(13137608))),
+		Entry("restic without stored size", volsyncv1alpha1.MoverResultSuccessful,
+			"Added to the repository: 923 B", ptr(int64(923))),
+		Entry("no stats", volsyncv1alpha1.MoverResultSuccessful, "rsync completed in 1s", nil),
+		Entry("failed sync", volsyncv1alpha1.MoverResultFailed, "sent 1,003 bytes  received 5,657 bytes", nil),
+	)
+
+	It("reports the progress of a sync", func() {
+		startTime := metav1.NewTime(time.Now().Add(-time.Minute))
+		rs := &volsyncv1alpha1.ReplicationSource{
+			Status: &volsyncv1alpha1.ReplicationSourceStatus{
+				LastSyncStartTime: &startTime,
+				LastSyncDuration:  &metav1.Duration{Duration: 5 * time.Minute},
+				Conditions: []metav1.Condition{{
+					Type:   volsyncv1alpha1.ConditionSynchronizing,
+					Status: metav1.ConditionTrue,
+					Reason: volsyncv1alpha1.SynchronizingReasonSync,
+				}},
+			},
+		}
+
+		inProgress, syncStartTime, estimatedCompletionTime := volsync.ReplicationSourceSyncProgress(rs)
+		Expect(inProgress).To(BeTrue())
+		Expect(syncStartTime).To(Equal(&startTime))
+		Expect(estimatedCompletionTime.Time).To(Equal(startTime.Add(5 * time.Minute)))
+
+		rs.Spec.Paused = true
+		inProgress, syncStartTime, estimatedCompletionTime = volsync.ReplicationSourceSyncProgress(rs)
+		Expect(inProgress).To(BeFalse())
+		Expect(syncStartTime).To(BeNil())
+		Expect(estimatedCompletionTime).To(BeNil())
+
+		rs.Spec.Paused = false
+		rs.Status.Conditions[0].Reason = volsyncv1alpha1.SynchronizingReasonSched
+		inProgress, _, _ = volsync.ReplicationSourceSyncProgress(rs)
+		Expect(inProgress).To(BeFalse())
+	})
+})
+
+func ptr[T any](v T) *T {
+	return &v
+}
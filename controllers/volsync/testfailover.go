@@ -0,0 +1,304 @@
+// SPDX-FileCopyrightText: The RamenDR authors
+// SPDX-License-Identifier: Apache-2.0
+
+package volsync
+
+import (
+	"fmt"
+
+	snapv1 "github.com/kubernetes-csi/external-snapshotter/client/v4/apis/volumesnapshot/v1"
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	ramendrv1alpha1 "github.com/ramendr/ramen/api/v1alpha1"
+	"github.com/ramendr/ramen/controllers/util"
+)
+
+const (
+	// TestFailoverLabel marks the resources created, or protected, for a test failover
+	TestFailoverLabel = "ramendr.openshift.io/test-failover"
+
+	// testFailoverPVCAnnotation records the protected PVC on the snapshots protected for a test failover
+	testFailoverPVCAnnotation = "ramendr.openshift.io/test-failover-pvc"
+)
+
+// TestFailoverLabels returns the labels of the resources created, or protected, for a test failover run by owner
+func TestFailoverLabels(owner metav1.Object) map[string]string {
+	return map[string]string{
+		TestFailoverLabel:      "true",
+		VRGOwnerNameLabel:      owner.GetName(),
+		VRGOwnerNamespaceLabel: owner.GetNamespace(),
+	}
+}
+
+func (v *VSHandler) testFailoverLabels() map[string]string {
+	return TestFailoverLabels(v.owner)
+}
+
+// EnsurePVCForTestFailover restores a copy of the latest image replicated for the protected PVC into the
+// target namespace. The latest image is exposed in the target namespace using a pre-provisioned
+// VolumeSnapshotContent, so that the ReplicationDestination continues to receive data undisturbed.
+// Returns true once the PVC is created.
+func (v *VSHandler) EnsurePVCForTestFailover(rdSpec ramendrv1alpha1.VolSyncReplicationDestinationSpec,
+	targetNamespace string,
+) (bool, error) {
+	l := v.log.WithValues("pvcName", rdSpec.ProtectedPVC.Name, "targetNamespace", targetNamespace)
+
+	if IsStaticVolume(rdSpec.ProtectedPVC) {
+		return false, fmt.Errorf("test failover of pvc %s bound to static PV %s is not supported, as it is synced "+
+			"without snapshots", rdSpec.ProtectedPVC.Name, rdSpec.ProtectedPVC.StaticPVName)
+	}
+
+	pvc, err := v.getPVC(types.NamespacedName{Name: rdSpec.ProtectedPVC.Name, Namespace: targetNamespace})
+	if err != nil && !kerrors.IsNotFound(err) {
+		return false, err
+	}
+
+	if pvc != nil {
+		if pvc.GetLabels()[TestFailoverLabel] == "" {
+			return false, fmt.Errorf("pvc %s/%s exists and was not restored for a test failover",
+				targetNamespace, pvc.GetName())
+		}
+
+		return true, nil
+	}
+
+	latestImage, err := v.getRDLatestImage(rdSpec.ProtectedPVC.Name, rdSpec.ProtectedPVC.Namespace)
+	if err != nil {
+		return false, err
+	}
+
+	if !isLatestImageReady(latestImage) {
+		l.Info("Latest image for PVC not yet available")
+
+		return false, nil
+	}
+
+	volSnap, err := v.protectSnapshotForTestFailover(latestImage.Name, rdSpec.ProtectedPVC.Namespace,
+		rdSpec.ProtectedPVC.Name)
+	if err != nil {
+		return false, err
+	}
+
+	snapCopy, err := v.ensureSnapshotCopy(volSnap, targetNamespace)
+	if err != nil {
+		return false, err
+	}
+
+	if err := v.createPVCFromSnapshotCopy(rdSpec, snapCopy); err != nil {
+		return false, err
+	}
+
+	l.Info("PVC restored for test failover", "snapshot", volSnap.GetName())
+
+	return true, nil
+}
+
+// protectSnapshotForTestFailover prevents VolSync from deleting the snapshot when it rotates to a newer image,
+// while a copy of it is in use by a test failover
+func (v *VSHandler) protectSnapshotForTestFailover(name, namespace, pvcName string,
+) (*snapv1.VolumeSnapshot, error) {
+	volSnap := &snapv1.VolumeSnapshot{}
+	if err := v.client.Get(v.ctx, types.NamespacedName{Name: name, Namespace: namespace}, volSnap); err != nil {
+		return nil, fmt.Errorf("error getting volumesnapshot %s/%s (%w)", namespace, name, err)
+	}
+
+	if volSnap.Status == nil || volSnap.Status.BoundVolumeSnapshotContentName == nil {
+		return nil, fmt.Errorf("volumesnapshot %s/%s is not bound to a volumesnapshotcontent", namespace, name)
+	}
+
+	util.AddAnnotation(volSnap, testFailoverPVCAnnotation, pvcName)
+
+	updater := util.NewResourceUpdater(volSnap).
+		AddLabel(VolSyncDoNotDeleteLabel, VolSyncDoNotDeleteLabelVal)
+
+	for key, value := range v.testFailoverLabels() {
+		updater = updater.AddLabel(key, value)
+	}
+
+	if err := updater.Update(v.ctx, v.client); err != nil {
+		return nil, fmt.Errorf("failed to add labels to volumesnapshot %s/%s (%w)", namespace, name, err)
+	}
+
+	return volSnap, nil
+}
+
+func testFailoverSnapshotContentName(targetNamespace, snapshotName string) string {
+	return fmt.Sprintf("%s-%s", targetNamespace, snapshotName)
+}
+
+// ensureSnapshotCopy creates a VolumeSnapshot in the target namespace that refers to the same storage snapshot
+// as the passed in VolumeSnapshot
+func (v *VSHandler) ensureSnapshotCopy(volSnap *snapv1.VolumeSnapshot, targetNamespace string,
+) (*snapv1.VolumeSnapshot, error) {
+	content := &snapv1.VolumeSnapshotContent{}
+	if err := v.client.Get(v.ctx, types.NamespacedName{Name: *volSnap.Status.BoundVolumeSnapshotContentName},
+		content); err != nil {
+		return nil, fmt.Errorf("error getting volumesnapshotcontent %s (%w)",
+			*volSnap.Status.BoundVolumeSnapshotContentName, err)
+	}
+
+	if content.Status == nil || content.Status.SnapshotHandle == nil {
+		return nil, fmt.Errorf("volumesnapshotcontent %s has no snapshot handle", content.GetName())
+	}
+
+	contentCopy := &snapv1.VolumeSnapshotContent{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:   testFailoverSnapshotContentName(targetNamespace, volSnap.GetName()),
+			Labels: v.testFailoverLabels(),
+		},
+		Spec: snapv1.VolumeSnapshotContentSpec{
+			// Retain, as the storage snapshot is owned by the VolumeSnapshot of the ReplicationDestination
+			DeletionPolicy:          snapv1.VolumeSnapshotContentRetain,
+			Driver:                  content.Spec.Driver,
+			VolumeSnapshotClassName: content.Spec.VolumeSnapshotClassName,
+			Source: snapv1.VolumeSnapshotContentSource{
+				SnapshotHandle: content.Status.SnapshotHandle,
+			},
+			VolumeSnapshotRef: corev1.ObjectReference{
+				Name:      volSnap.GetName(),
+				Namespace: targetNamespace,
+			},
+		},
+	}
+
+	if err := v.client.Create(v.ctx, contentCopy); err != nil && !kerrors.IsAlreadyExists(err) {
+		return nil, fmt.Errorf("failed to create volumesnapshotcontent %s (%w)", contentCopy.GetName(), err)
+	}
+
+	snapCopy := &snapv1.VolumeSnapshot{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      volSnap.GetName(),
+			Namespace: targetNamespace,
+			Labels:    v.testFailoverLabels(),
+		},
+		Spec: snapv1.VolumeSnapshotSpec{
+			Source: snapv1.VolumeSnapshotSource{
+				VolumeSnapshotContentName: &contentCopy.Name,
+			},
+		},
+	}
+
+	if err := v.client.Create(v.ctx, snapCopy); err != nil && !kerrors.IsAlreadyExists(err) {
+		return nil, fmt.Errorf("failed to create volumesnapshot %s/%s (%w)", targetNamespace, snapCopy.GetName(), err)
+	}
+
+	// The restore size is taken from the original, as the copy may not be ready yet
+	snapCopy.Status = volSnap.Status
+
+	return snapCopy, nil
+}
+
+func (v *VSHandler) createPVCFromSnapshotCopy(rdSpec ramendrv1alpha1.VolSyncReplicationDestinationSpec,
+	snapCopy *snapv1.VolumeSnapshot,
+) error {
+	accessModes := []corev1.PersistentVolumeAccessMode{corev1.ReadWriteOnce} // Default value
+	if len(rdSpec.ProtectedPVC.AccessModes) > 0 {
+		accessModes = rdSpec.ProtectedPVC.AccessModes
+	}
+
+	capacity := rdSpec.ProtectedPVC.Resources.Requests.Storage()
+	if snapCopy.Status.RestoreSize != nil && snapCopy.Status.RestoreSize.Cmp(*capacity) > 0 {
+		capacity = snapCopy.Status.RestoreSize
+	}
+
+	labels := map[string]string{}
+	util.UpdateStringMap(&labels, rdSpec.ProtectedPVC.Labels)
+	util.UpdateStringMap(&labels, v.testFailoverLabels())
+
+	apiGroup := snapv1.SchemeGroupVersion.Group
+
+	pvc := &corev1.PersistentVolumeClaim{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      rdSpec.ProtectedPVC.Name,
+			Namespace: snapCopy.GetNamespace(),
+			Labels:    labels,
+		},
+		Spec: corev1.PersistentVolumeClaimSpec{
+			AccessModes:      accessModes,
+			StorageClassName: rdSpec.ProtectedPVC.StorageClassName,
+			Resources: corev1.VolumeResourceRequirements{
+				Requests: corev1.ResourceList{corev1.ResourceStorage: *capacity},
+			},
+			DataSource: &corev1.TypedLocalObjectReference{
+				APIGroup: &apiGroup,
+				Kind:     VolumeSnapshotKind,
+				Name:     snapCopy.GetName(),
+			},
+		},
+	}
+
+	if err := v.client.Create(v.ctx, pvc); err != nil && !kerrors.IsAlreadyExists(err) {
+		return fmt.Errorf("failed to create pvc %s/%s (%w)", pvc.GetNamespace(), pvc.GetName(), err)
+	}
+
+	return nil
+}
+
+// CleanupTestFailover deletes the PVCs and snapshot copies restored for a test failover in the target namespaces,
+// and releases the snapshots of the ReplicationDestinations that were protected for it
+func (v *VSHandler) CleanupTestFailover(targetNamespaces []string) error {
+	labels := client.MatchingLabels(v.testFailoverLabels())
+
+	for _, namespace := range targetNamespaces {
+		if err := v.client.DeleteAllOf(v.ctx, &corev1.PersistentVolumeClaim{}, client.InNamespace(namespace),
+			labels); err != nil && !kerrors.IsNotFound(err) {
+			return fmt.Errorf("failed to delete test failover pvcs in namespace %s (%w)", namespace, err)
+		}
+
+		if err := v.client.DeleteAllOf(v.ctx, &snapv1.VolumeSnapshot{}, client.InNamespace(namespace),
+			labels); err != nil && !kerrors.IsNotFound(err) {
+			return fmt.Errorf("failed to delete test failover volumesnapshots in namespace %s (%w)", namespace, err)
+		}
+	}
+
+	if err := v.client.DeleteAllOf(v.ctx, &snapv1.VolumeSnapshotContent{}, labels); err != nil &&
+		!kerrors.IsNotFound(err) {
+		return fmt.Errorf("failed to delete test failover volumesnapshotcontents (%w)", err)
+	}
+
+	return v.releaseSnapshotsForTestFailover()
+}
+
+// releaseSnapshotsForTestFailover removes the test failover protection from the snapshots of the
+// ReplicationDestinations. Snapshots that VolSync has since rotated away from are deleted, unless they are retained
+// as recovery points, which stay protected from VolSync until they are pruned.
+func (v *VSHandler) releaseSnapshotsForTestFailover() error {
+	volSnaps := &snapv1.VolumeSnapshotList{}
+	if err := v.client.List(v.ctx, volSnaps, client.MatchingLabels(v.testFailoverLabels())); err != nil {
+		return fmt.Errorf("failed to list test failover volumesnapshots (%w)", err)
+	}
+
+	for i := range volSnaps.Items {
+		volSnap := &volSnaps.Items[i]
+		recoveryPoint := volSnap.GetLabels()[RecoveryPointPVCLabel] != ""
+
+		latestImage, err := v.getRDLatestImage(volSnap.GetAnnotations()[testFailoverPVCAnnotation],
+			volSnap.GetNamespace())
+		if !recoveryPoint && err == nil && latestImage != nil && latestImage.Name != volSnap.GetName() {
+			if err := v.client.Delete(v.ctx, volSnap); err != nil && !kerrors.IsNotFound(err) {
+				return fmt.Errorf("failed to delete volumesnapshot %s/%s (%w)", volSnap.GetNamespace(),
+					volSnap.GetName(), err)
+			}
+
+			continue
+		}
+
+		delete(volSnap.Labels, TestFailoverLabel)
+		delete(volSnap.Annotations, testFailoverPVCAnnotation)
+
+		if !recoveryPoint {
+			delete(volSnap.Labels, VolSyncDoNotDeleteLabel)
+		}
+
+		if err := v.client.Update(v.ctx, volSnap); err != nil {
+			return fmt.Errorf("failed to update volumesnapshot %s/%s (%w)", volSnap.GetNamespace(),
+				volSnap.GetName(), err)
+		}
+	}
+
+	return nil
+}
@@ -6,11 +6,14 @@ package volsync
 import (
 	"context"
 	"fmt"
+	"reflect"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/go-logr/logr"
 	snapv1 "github.com/kubernetes-csi/external-snapshotter/client/v4/apis/volumesnapshot/v1"
+	"golang.org/x/exp/slices"
 	corev1 "k8s.io/api/core/v1"
 	storagev1 "k8s.io/api/storage/v1"
 	kerrors "k8s.io/apimachinery/pkg/api/errors"
@@ -20,6 +23,7 @@ import (
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/client-go/tools/reference"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -49,10 +53,23 @@ const (
 
 	SchedulingIntervalMinLength int = 2
 	CronSpecMaxDayOfMonth       int = 28
+	CronSpecMaxHour             int = 23
+	CronSpecMaxMinute           int = 59
+	MinutesPerHour              int = 60
+	HoursPerDay                 int = 24
 
 	VolSyncDoNotDeleteLabel    = "volsync.backube/do-not-delete" // TODO: point to volsync constant once it is available
 	VolSyncDoNotDeleteLabelVal = "true"
 
+	// VolumeSnapshotPVCLabel labels a PVC restored from a VolumeSnapshot, for VolSync to replicate the snapshot's
+	// data. Its value is the name of the VolumeSnapshot. No application mounts the PVC, so its ReplicationSource
+	// copies it directly, its mover being its first consumer.
+	VolumeSnapshotPVCLabel = "ramendr.openshift.io/volumesnapshot-name"
+
+	// Label of the pods that VolSync creates, such as its movers
+	volSyncOwnedByLabelKey   = "app.kubernetes.io/created-by"
+	volSyncOwnedByLabelValue = "volsync"
+
 	// See: https://issues.redhat.com/browse/ACM-1256
 	// https://github.com/stolostron/backlog/issues/21824
 	ACMAppSubDoNotDeleteAnnotation    = "apps.open-cluster-management.io/do-not-delete"
@@ -68,11 +85,28 @@ type VSHandler struct {
 	log                         logr.Logger
 	owner                       metav1.Object
 	schedulingInterval          string
+	schedule                    string // if set, replaces the schedulingInterval of PVCs that do not override it
+	blackoutWindows             []ramendrv1alpha1.ReplicationWindow
 	volumeSnapshotClassSelector metav1.LabelSelector // volume snapshot classes to be filtered label selector
 	defaultCephFSCSIDriverName  string
 	destinationCopyMethod       volsyncv1alpha1.CopyMethodType
 	volumeSnapshotClassList     *snapv1.VolumeSnapshotClassList
 	vrgInAdminNamespace         bool
+	manualSyncTrigger           string // if set, replaces the schedule of ReplicationSources other than final syncs
+	profile                     *ramendrv1alpha1.VolSyncProfile
+	resticRepository            *ResticRepository
+	maxConcurrentSyncs          *int32 // if set, overrides the profile's
+	throttledPVCs               sets.Set[types.NamespacedName]
+	recoveryPointTime           *metav1.Time // if set, PVCs are restored from recovery points at or before it
+	localCluster                string
+	peerClusters                []string  // if more than one, a PVC has an rsync TLS ReplicationSource per peer
+	scheduledSyncNext           time.Time // if set, the next time a manually triggered schedule is due
+	// static volumes whose applications are to be quiesced, as a sync of each is due or running
+	staticVolumesQuiesce []types.NamespacedName
+	// node ports of the ReplicationDestination services, reported by the VRGs of the peers
+	peerRDNodePorts []ramendrv1alpha1.VolSyncNodePort
+	// node ports of the ReplicationDestination services reconciled
+	rdNodePorts []ramendrv1alpha1.VolSyncNodePort
 }
 
 func NewVSHandler(ctx context.Context, client client.Client, log logr.Logger, owner metav1.Object,
@@ -92,6 +126,9 @@ func NewVSHandler(ctx context.Context, client client.Client, log logr.Logger, ow
 
 	if asyncSpec != nil {
 		vsHandler.schedulingInterval = asyncSpec.SchedulingInterval
+		vsHandler.schedule = asyncSpec.Schedule
+		vsHandler.blackoutWindows = asyncSpec.BlackoutWindows
+		vsHandler.maxConcurrentSyncs = asyncSpec.VolSyncMaxConcurrentSyncs
 		vsHandler.volumeSnapshotClassSelector = asyncSpec.VolumeSnapshotClassSelector
 	}
 
@@ -135,6 +172,10 @@ func (v *VSHandler) ReconcileRD(
 		return nil, err
 	}
 
+	if err := v.ensurePrivilegedMovers(rdSpec.ProtectedPVC.Namespace); err != nil {
+		return nil, err
+	}
+
 	dstPVC, err := v.PrecreateDestPVCIfEnabled(rdSpec)
 	if err != nil {
 		return nil, err
@@ -147,9 +188,19 @@ func (v *VSHandler) ReconcileRD(
 		return nil, err
 	}
 
-	err = v.reconcileServiceExportForRD(rd)
-	if err != nil {
-		return nil, err
+	// A LoadBalancer or NodePort service is reached by its host name instead, and the Restic mover has no service
+	if v.serviceTypeIsClusterIP() && !v.IsMoverRestic() {
+		err = v.reconcileServiceExportForRD(rd)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if v.serviceTypeIsNodePort() && !v.IsMoverRestic() {
+		err = v.ensureRDServiceNodePort(rdSpec.ProtectedPVC.Name, rdSpec.ProtectedPVC.Namespace)
+		if err != nil {
+			return nil, err
+		}
 	}
 
 	if !rdStatusReady(rd, l) {
@@ -166,6 +217,11 @@ func (v *VSHandler) ReconcileRD(
 // - rsync address should be filled out in the status
 // - latest image should be set properly in the status (at least one sync cycle has completed and we have a snapshot)
 func rdStatusReady(rd *volsyncv1alpha1.ReplicationDestination, log logr.Logger) bool {
+	// A Restic mover pulls from its repository, and has no address
+	if rd.Spec.Restic != nil {
+		return true
+	}
+
 	if rd.Status == nil {
 		return false
 	}
@@ -185,7 +241,7 @@ func (v *VSHandler) createOrUpdateRD(
 ) {
 	l := v.log.WithValues("rdSpec", rdSpec)
 
-	volumeSnapshotClassName, err := v.GetVolumeSnapshotClassFromPVCStorageClass(rdSpec.ProtectedPVC.StorageClassName)
+	copyMethod, volumeSnapshotClassName, err := v.destinationCopyMethodAndVolumeSnapshotClass(rdSpec)
 	if err != nil {
 		return nil, err
 	}
@@ -195,6 +251,25 @@ func (v *VSHandler) createOrUpdateRD(
 		pvcAccessModes = rdSpec.ProtectedPVC.AccessModes
 	}
 
+	volumeOptions := volsyncv1alpha1.ReplicationDestinationVolumeOptions{
+		CopyMethod:              copyMethod,
+		Capacity:                rdSpec.ProtectedPVC.Resources.Requests.Storage(),
+		StorageClassName:        rdSpec.ProtectedPVC.StorageClassName,
+		AccessModes:             pvcAccessModes,
+		VolumeSnapshotClassName: volumeSnapshotClassName,
+		DestinationPVC:          dstPVC,
+	}
+
+	var resticSecretName string
+
+	if v.IsMoverRestic() {
+		resticSecretName, err = v.reconcileResticSecret(rdSpec.ProtectedPVC.Name, rdSpec.ProtectedPVC.Namespace,
+			pskSecretName)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	rd := &volsyncv1alpha1.ReplicationDestination{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:      getReplicationDestinationName(rdSpec.ProtectedPVC.Name),
@@ -216,18 +291,21 @@ func (v *VSHandler) createOrUpdateRD(
 		util.AddAnnotation(rd, OwnerNameAnnotation, v.owner.GetName())
 		util.AddAnnotation(rd, OwnerNamespaceAnnotation, v.owner.GetNamespace())
 
+		if v.IsMoverRestic() {
+			return v.rdResticSpecSet(rd, rdSpec, resticSecretName, volumeOptions)
+		}
+
+		rd.Spec.Trigger = nil
+		rd.Spec.Restic = nil
+
 		rd.Spec.RsyncTLS = &volsyncv1alpha1.ReplicationDestinationRsyncTLSSpec{
-			ServiceType: v.getRsyncServiceType(),
-			KeySecret:   &pskSecretName,
+			ServiceType:          v.getRsyncServiceType(),
+			ServiceAnnotations:   v.getRsyncServiceAnnotations(rdSpec.ProtectedPVC.Name, rdSpec.ProtectedPVC.Namespace),
+			KeySecret:            &pskSecretName,
+			MoverSecurityContext: v.getMoverSecurityContext(),
+			MoverServiceAccount:  v.getMoverServiceAccount(),
 
-			ReplicationDestinationVolumeOptions: volsyncv1alpha1.ReplicationDestinationVolumeOptions{
-				CopyMethod:              volsyncv1alpha1.CopyMethodSnapshot,
-				Capacity:                rdSpec.ProtectedPVC.Resources.Requests.Storage(),
-				StorageClassName:        rdSpec.ProtectedPVC.StorageClassName,
-				AccessModes:             pvcAccessModes,
-				VolumeSnapshotClassName: &volumeSnapshotClassName,
-				DestinationPVC:          dstPVC,
-			},
+			ReplicationDestinationVolumeOptions: volumeOptions,
 		}
 
 		return nil
@@ -241,6 +319,34 @@ func (v *VSHandler) createOrUpdateRD(
 	return rd, nil
 }
 
+// destinationCopyMethodAndVolumeSnapshotClass returns the copy method, and the VolumeSnapshotClass if any, of the
+// ReplicationDestination of a PVC. A static volume is synced directly into its PVC, without snapshots, as its PV
+// is not provisioned by a CSI driver.
+func (v *VSHandler) destinationCopyMethodAndVolumeSnapshotClass(
+	rdSpec ramendrv1alpha1.VolSyncReplicationDestinationSpec,
+) (volsyncv1alpha1.CopyMethodType, *string, error) {
+	if IsStaticVolume(rdSpec.ProtectedPVC) {
+		return volsyncv1alpha1.CopyMethodDirect, nil, nil
+	}
+
+	volumeSnapshotClassName, err := v.GetVolumeSnapshotClassFromPVCStorageClass(rdSpec.ProtectedPVC.StorageClassName)
+	if err != nil {
+		return "", nil, err
+	}
+
+	return volsyncv1alpha1.CopyMethodSnapshot, &volumeSnapshotClassName, nil
+}
+
+// IsStaticVolume returns true if the protected PVC is bound to a statically provisioned PV
+func IsStaticVolume(protectedPVC ramendrv1alpha1.ProtectedPVC) bool {
+	return protectedPVC.StaticPVName != ""
+}
+
+// IsVolumeSnapshotPVC returns true if a protected PVC was restored from a VolumeSnapshot to replicate its data
+func IsVolumeSnapshotPVC(protectedPVC ramendrv1alpha1.ProtectedPVC) bool {
+	return protectedPVC.Labels[VolumeSnapshotPVCLabel] != ""
+}
+
 func (v *VSHandler) isPVCInUseByNonRDPod(pvcNamespacedName types.NamespacedName) (bool, error) {
 	rd := &volsyncv1alpha1.ReplicationDestination{}
 
@@ -311,30 +417,50 @@ func (v *VSHandler) ReconcileRS(rsSpec ramendrv1alpha1.VolSyncReplicationSourceS
 		return false, nil, err
 	}
 
+	err = v.deleteUnpeeredRS(rsSpec.ProtectedPVC.Name, rsSpec.ProtectedPVC.Namespace)
+	if err != nil {
+		return false, nil, err
+	}
+
 	pvcOk, err := v.validatePVCBeforeRS(rsSpec, runFinalSync)
 	if !pvcOk || err != nil {
 		// Return the replicationSource if it already exists
-		existingRS, getRSErr := v.getRS(getReplicationSourceName(rsSpec.ProtectedPVC.Name), rsSpec.ProtectedPVC.Namespace)
-		if getRSErr != nil {
+		existingRSs, getRSErr := v.getRSs(rsSpec.ProtectedPVC.Name, rsSpec.ProtectedPVC.Namespace)
+		if getRSErr != nil || existingRSs == nil {
 			return false, nil, err
 		}
 		// Return the RS here - allows status updates to understand that prev RS syncs may have completed
 		// (i.e. data protected == true), even though we may be indicating that finalSync has not yet completed
 		// because the PVC is still in-use
-		return false, existingRS, err
+		return false, leastRecentlySyncedRS(existingRSs), err
 	}
 
-	replicationSource, err := v.createOrUpdateRS(rsSpec, pskSecretName, runFinalSync)
-	if err != nil {
-		return false, replicationSource, err
+	if err := v.ensurePrivilegedMovers(rsSpec.ProtectedPVC.Namespace); err != nil {
+		return false, nil, err
 	}
 
+	replicationSources := make([]*volsyncv1alpha1.ReplicationSource, 0, len(v.rsPeers()))
+	finalSyncComplete := runFinalSync
+
+	for _, peer := range v.rsPeers() {
+		replicationSource, err := v.createOrUpdateRS(rsSpec, pskSecretName, runFinalSync, peer)
+		if err != nil {
+			return false, replicationSource, err
+		}
+
+		replicationSources = append(replicationSources, replicationSource)
+		finalSyncComplete = finalSyncComplete && isFinalSyncComplete(replicationSource, l)
+	}
+
+	replicationSource := leastRecentlySyncedRS(replicationSources)
+
 	//
-	// For final sync only - check status to make sure the final sync is complete
-	// and also run cleanup (removes PVC we just ran the final sync from)
+	// For final sync only - check status to make sure the final sync is complete to all peers. The PVC is removed by
+	// CleanupAfterFinalSync once the final sync of all PVCs is complete, so that the workload can still be restored
+	// from the PVCs if the relocation is aborted
 	//
-	if runFinalSync && isFinalSyncComplete(replicationSource, l) {
-		return true, replicationSource, v.cleanupAfterRSFinalSync(rsSpec)
+	if finalSyncComplete {
+		return true, replicationSource, nil
 	}
 
 	l.V(1).Info("ReplicationSource Reconcile Complete")
@@ -367,11 +493,16 @@ func (v *VSHandler) validatePVCBeforeRS(rsSpec ramendrv1alpha1.VolSyncReplicatio
 		return true, nil // Good to proceed - PVC is not in use, not mounted to node (or does not exist-should not happen)
 	}
 
+	// A VolumeSnapshot PVC is mounted by the mover of its ReplicationSource only
+	if IsVolumeSnapshotPVC(rsSpec.ProtectedPVC) {
+		return true, nil
+	}
+
 	// Not running final sync - if we have not yet created an RS for this PVC, then make sure a pod has mounted
 	// the PVC and is in "Running" state before attempting to create an RS.
 	// This is a best effort to confirm the app that is using the PVC is started before trying to replicate the PVC.
-	_, err := v.getRS(getReplicationSourceName(rsSpec.ProtectedPVC.Name), rsSpec.ProtectedPVC.Namespace)
-	if err != nil && kerrors.IsNotFound(err) {
+	rss, err := v.getRSs(rsSpec.ProtectedPVC.Name, rsSpec.ProtectedPVC.Namespace)
+	if err == nil && rss == nil {
 		l.Info("ReplicationSource does not exist yet. " +
 			"validating that the PVC to be protected is in use by a ready pod ...")
 		// RS does not yet exist - consider PVC is ok if it's mounted and in use by running pod
@@ -401,6 +532,89 @@ func (v *VSHandler) validatePVCBeforeRS(rsSpec ramendrv1alpha1.VolSyncReplicatio
 	return true, nil
 }
 
+// staticVolumeInUse returns true if a static volume, whose scheduled syncs copy the volume itself rather than a
+// snapshot of it, is in use by an application pod. Its final and manual syncs run once the application is removed
+// or frozen.
+func (v *VSHandler) staticVolumeInUse(rsSpec ramendrv1alpha1.VolSyncReplicationSourceSpec, runFinalSync bool,
+) (bool, error) {
+	if !IsStaticVolume(rsSpec.ProtectedPVC) || runFinalSync || v.manualSyncTrigger != "" {
+		return false, nil
+	}
+
+	return v.pvcInUseByApplicationPod(util.ProtectedPVCNamespacedName(rsSpec.ProtectedPVC))
+}
+
+// staticVolumeSyncTrigger triggers each scheduled sync of a static volume manually, once its application is
+// quiesced, so that a live volume is not copied. The volume is listed to quiesce its application from the time a
+// sync is due until the sync completes, see StaticVolumesQuiesce, and its ReplicationSource is paused until then.
+// A sync is not due while the ReplicationSource is paused otherwise.
+func (v *VSHandler) staticVolumeSyncTrigger(rs *volsyncv1alpha1.ReplicationSource,
+	rsSpec ramendrv1alpha1.VolSyncReplicationSourceSpec, inUse bool,
+) error {
+	scheduleCronSpec, err := v.getScheduleCronSpec(rsSpec.ProtectedPVC.SchedulingInterval)
+	if err != nil {
+		return err
+	}
+
+	manual := ""
+	if rs.Spec.Trigger != nil {
+		manual = rs.Spec.Trigger.Manual
+	}
+
+	due, err := v.scheduleManualTrigger(*scheduleCronSpec, manual)
+	if err != nil {
+		return err
+	}
+
+	if blackout, _ := v.BlackoutWindowActive(); blackout {
+		rs.Spec.Paused = true
+	}
+
+	if rs.Status != nil && rs.Status.LastManualSync == due || rs.Spec.Paused {
+		return nil
+	}
+
+	v.staticVolumesQuiesce = append(v.staticVolumesQuiesce, util.ProtectedPVCNamespacedName(rsSpec.ProtectedPVC))
+
+	if inUse && manual != due {
+		v.log.Info("Static volume sync due, waiting for its application to be quiesced", "pvcName",
+			rs.Spec.SourcePVC, "trigger", due)
+
+		rs.Spec.Paused = true
+
+		return nil
+	}
+
+	rs.Spec.Trigger = &volsyncv1alpha1.ReplicationSourceTriggerSpec{Manual: due}
+
+	return nil
+}
+
+// StaticVolumesQuiesce returns the static volumes whose applications are to be quiesced, as a sync of each is due
+// or running. The application of any other static volume may resume.
+func (v *VSHandler) StaticVolumesQuiesce() []types.NamespacedName {
+	return v.staticVolumesQuiesce
+}
+
+// pvcInUseByApplicationPod returns true if a PVC is mounted by a pod other than a VolSync mover
+func (v *VSHandler) pvcInUseByApplicationPod(pvcNamespacedName types.NamespacedName) (bool, error) {
+	pods := &corev1.PodList{}
+
+	if err := v.client.List(v.ctx, pods,
+		client.MatchingFields{util.PodVolumePVCClaimIndexName: pvcNamespacedName.Name},
+		client.InNamespace(pvcNamespacedName.Namespace)); err != nil {
+		return false, fmt.Errorf("unable to lookup pods to check if pvc is in use (%w)", err)
+	}
+
+	for i := range pods.Items {
+		if pods.Items[i].GetLabels()[volSyncOwnedByLabelKey] != volSyncOwnedByLabelValue {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
 func isFinalSyncComplete(replicationSource *volsyncv1alpha1.ReplicationSource, log logr.Logger) bool {
 	if replicationSource.Status == nil || replicationSource.Status.LastManualSync != FinalSyncTriggerString {
 		log.V(1).Info("ReplicationSource running final sync - waiting for status ...")
@@ -413,9 +627,11 @@ func isFinalSyncComplete(replicationSource *volsyncv1alpha1.ReplicationSource, l
 	return true
 }
 
-func (v *VSHandler) cleanupAfterRSFinalSync(rsSpec ramendrv1alpha1.VolSyncReplicationSourceSpec) error {
-	// Final sync is done, make sure PVC is cleaned up, Skip if we are using CopyMethodDirect
-	if v.IsCopyMethodDirect() {
+// CleanupAfterFinalSync removes the PVC that a final sync completed for
+func (v *VSHandler) CleanupAfterFinalSync(rsSpec ramendrv1alpha1.VolSyncReplicationSourceSpec) error {
+	// Final sync is done, make sure PVC is cleaned up, Skip if we are using CopyMethodDirect or the PVC is bound
+	// to a static PV, as a new PVC would not bind to it
+	if v.IsCopyMethodDirect() || IsStaticVolume(rsSpec.ProtectedPVC) {
 		v.log.Info("Preserving PVC to use for CopyMethodDirect", "pvcName", rsSpec.ProtectedPVC.Name)
 
 		return nil
@@ -426,35 +642,50 @@ func (v *VSHandler) cleanupAfterRSFinalSync(rsSpec ramendrv1alpha1.VolSyncReplic
 	return util.DeletePVC(v.ctx, v.client, rsSpec.ProtectedPVC.Name, rsSpec.ProtectedPVC.Namespace, v.log)
 }
 
+// createOrUpdateRS creates or updates the ReplicationSource of a PVC that syncs to a peer, or to any peer if the
+// peer name is empty
+//
 //nolint:funlen
 func (v *VSHandler) createOrUpdateRS(rsSpec ramendrv1alpha1.VolSyncReplicationSourceSpec,
-	pskSecretName string, runFinalSync bool) (*volsyncv1alpha1.ReplicationSource, error,
+	pskSecretName string, runFinalSync bool, peer string) (*volsyncv1alpha1.ReplicationSource, error,
 ) {
-	l := v.log.WithValues("rsSpec", rsSpec, "runFinalSync", runFinalSync)
+	l := v.log.WithValues("rsSpec", rsSpec, "runFinalSync", runFinalSync, "peer", peer)
 
-	storageClass, err := v.getStorageClass(rsSpec.ProtectedPVC.StorageClassName)
+	copyMethod, volumeSnapshotClassName, err := v.sourceCopyMethodAndVolumeSnapshotClass(&rsSpec)
 	if err != nil {
 		return nil, err
 	}
 
-	volumeSnapshotClassName, err := v.getVolumeSnapshotClassFromPVCStorageClass(storageClass)
-	if err != nil {
-		return nil, err
+	// Remote service address created for the ReplicationDestination on the secondary
+	// The secondary namespace will be the same as primary namespace so use the vrg.Namespace
+	remoteAddress := v.getRemoteAddressForRDFromPVCName(rsSpec.ProtectedPVC.Name, rsSpec.ProtectedPVC.Namespace,
+		peer)
+
+	volumeOptions := volsyncv1alpha1.ReplicationSourceVolumeOptions{
+		CopyMethod:              copyMethod,
+		VolumeSnapshotClassName: volumeSnapshotClassName,
+		StorageClassName:        rsSpec.ProtectedPVC.StorageClassName,
+		AccessModes:             rsSpec.ProtectedPVC.AccessModes,
 	}
 
-	// Fix for CephFS (replication source only) - may need different storageclass and access modes
-	err = v.ModifyRSSpecForCephFS(&rsSpec, storageClass)
+	inUse, err := v.staticVolumeInUse(rsSpec, runFinalSync)
 	if err != nil {
 		return nil, err
 	}
 
-	// Remote service address created for the ReplicationDestination on the secondary
-	// The secondary namespace will be the same as primary namespace so use the vrg.Namespace
-	remoteAddress := getRemoteServiceNameForRDFromPVCName(rsSpec.ProtectedPVC.Name, rsSpec.ProtectedPVC.Namespace)
+	var resticSecretName string
+
+	if v.IsMoverRestic() {
+		resticSecretName, err = v.reconcileResticSecret(rsSpec.ProtectedPVC.Name, rsSpec.ProtectedPVC.Namespace,
+			pskSecretName)
+		if err != nil {
+			return nil, err
+		}
+	}
 
 	rs := &volsyncv1alpha1.ReplicationSource{
 		ObjectMeta: metav1.ObjectMeta{
-			Name:      getReplicationSourceName(rsSpec.ProtectedPVC.Name),
+			Name:      getReplicationSourceNameForPeer(rsSpec.ProtectedPVC.Name, peer),
 			Namespace: rsSpec.ProtectedPVC.Namespace,
 		},
 	}
@@ -472,6 +703,7 @@ func (v *VSHandler) createOrUpdateRS(rsSpec ramendrv1alpha1.VolSyncReplicationSo
 		util.AddLabel(rs, VRGOwnerNamespaceLabel, v.owner.GetNamespace())
 
 		rs.Spec.SourcePVC = rsSpec.ProtectedPVC.Name
+		rs.Spec.Paused = v.replicationSourceThrottled(rsSpec.ProtectedPVC.Name, rsSpec.ProtectedPVC.Namespace)
 
 		if runFinalSync {
 			l.V(1).Info("ReplicationSource - final sync")
@@ -480,31 +712,59 @@ func (v *VSHandler) createOrUpdateRS(rsSpec ramendrv1alpha1.VolSyncReplicationSo
 			rs.Spec.Trigger = &volsyncv1alpha1.ReplicationSourceTriggerSpec{
 				Manual: FinalSyncTriggerString,
 			}
+		} else if v.manualSyncTrigger != "" {
+			l.V(1).Info("ReplicationSource - manual sync", "trigger", v.manualSyncTrigger)
+			rs.Spec.Trigger = &volsyncv1alpha1.ReplicationSourceTriggerSpec{
+				Manual: v.manualSyncTrigger,
+			}
+		} else if IsStaticVolume(rsSpec.ProtectedPVC) {
+			if err := v.staticVolumeSyncTrigger(rs, rsSpec, inUse); err != nil {
+				return err
+			}
 		} else {
 			// Set schedule
-			scheduleCronSpec, err := v.getScheduleCronSpec()
+			scheduleCronSpec, err := v.getScheduleCronSpec(rsSpec.ProtectedPVC.SchedulingInterval)
 			if err != nil {
 				l.Error(err, "unable to parse schedulingInterval")
 
 				return err
 			}
+
+			manual := ""
+			if rs.Spec.Trigger != nil {
+				manual = rs.Spec.Trigger.Manual
+			}
+
+			schedule, manual, err := v.scheduleTrigger(*scheduleCronSpec, manual)
+			if err != nil {
+				return err
+			}
+
 			rs.Spec.Trigger = &volsyncv1alpha1.ReplicationSourceTriggerSpec{
-				Schedule: scheduleCronSpec,
+				Schedule: schedule,
+				Manual:   manual,
+			}
+			if blackout, _ := v.BlackoutWindowActive(); blackout {
+				rs.Spec.Paused = true
 			}
 		}
 
+		if v.IsMoverRestic() {
+			v.rsResticSpecSet(rs, resticSecretName, volumeOptions)
+
+			return nil
+		}
+
+		rs.Spec.Restic = nil
+
 		rs.Spec.RsyncTLS = &volsyncv1alpha1.ReplicationSourceRsyncTLSSpec{
-			KeySecret: &pskSecretName,
-			Address:   &remoteAddress,
+			KeySecret:            &pskSecretName,
+			Address:              &remoteAddress,
+			Port:                 v.getRsyncPort(rsSpec.ProtectedPVC.Name, rsSpec.ProtectedPVC.Namespace, peer),
+			MoverSecurityContext: v.getMoverSecurityContext(),
+			MoverServiceAccount:  v.getMoverServiceAccount(),
 
-			ReplicationSourceVolumeOptions: volsyncv1alpha1.ReplicationSourceVolumeOptions{
-				// Always using CopyMethod of snapshot for now - could use 'Clone' CopyMethod for specific
-				// storage classes that support it in the future
-				CopyMethod:              volsyncv1alpha1.CopyMethodSnapshot,
-				VolumeSnapshotClassName: &volumeSnapshotClassName,
-				StorageClassName:        rsSpec.ProtectedPVC.StorageClassName,
-				AccessModes:             rsSpec.ProtectedPVC.AccessModes,
-			},
+			ReplicationSourceVolumeOptions: volumeOptions,
 		}
 
 		return nil
@@ -518,6 +778,46 @@ func (v *VSHandler) createOrUpdateRS(rsSpec ramendrv1alpha1.VolSyncReplicationSo
 	return rs, nil
 }
 
+// sourceCopyMethodAndVolumeSnapshotClass returns the copy method, and the VolumeSnapshotClass if any, of the
+// ReplicationSource of a PVC. A static volume is synced directly from its PVC, without snapshots, as its PV is not
+// provisioned by a CSI driver. Its scheduled syncs run once its application is quiesced, see staticVolumeSyncTrigger.
+// A VolumeSnapshot PVC is synced directly too, as it is not written to, and for its mover to bind it if its
+// StorageClass waits for a first consumer. Otherwise the copy method of the profile is used, and defaults to Snapshot.
+func (v *VSHandler) sourceCopyMethodAndVolumeSnapshotClass(
+	rsSpec *ramendrv1alpha1.VolSyncReplicationSourceSpec,
+) (volsyncv1alpha1.CopyMethodType, *string, error) {
+	if IsStaticVolume(rsSpec.ProtectedPVC) || IsVolumeSnapshotPVC(rsSpec.ProtectedPVC) {
+		return volsyncv1alpha1.CopyMethodDirect, nil, nil
+	}
+
+	if v.profile != nil {
+		switch v.profile.CopyMethod {
+		case ramendrv1alpha1.VolSyncCopyMethodDirect:
+			return volsyncv1alpha1.CopyMethodDirect, nil, nil
+		case ramendrv1alpha1.VolSyncCopyMethodClone:
+			return volsyncv1alpha1.CopyMethodClone, nil, nil
+		}
+	}
+
+	storageClass, err := v.getStorageClass(rsSpec.ProtectedPVC.StorageClassName)
+	if err != nil {
+		return "", nil, err
+	}
+
+	volumeSnapshotClassName, err := v.getVolumeSnapshotClassFromPVCStorageClass(storageClass)
+	if err != nil {
+		return "", nil, err
+	}
+
+	// Fix for CephFS (replication source only) - may need different storageclass and access modes
+	err = v.ModifyRSSpecForCephFS(rsSpec, storageClass)
+	if err != nil {
+		return "", nil, err
+	}
+
+	return volsyncv1alpha1.CopyMethodSnapshot, &volumeSnapshotClassName, nil
+}
+
 func (v *VSHandler) PreparePVC(pvcNamespacedName types.NamespacedName, prepFinalSync, copyMethodDirect bool) error {
 	if prepFinalSync || copyMethodDirect {
 		prepared, err := v.TakePVCOwnership(pvcNamespacedName)
@@ -659,8 +959,19 @@ func (v *VSHandler) copySecretToPVCNamespace(secretName string, pvcNamespacedNam
 	err := v.client.Get(v.ctx,
 		types.NamespacedName{
 			Name:      secretName,
-			Namespace: pvcNamespacedName.Namespace,
+			Namespace: v.owner.GetNamespace(),
 		}, secret)
+	if err != nil {
+		return fmt.Errorf("error getting secret from the admin namespace (%w)", err)
+	}
+
+	existingCopy := &corev1.Secret{}
+
+	err = v.client.Get(v.ctx,
+		types.NamespacedName{
+			Name:      secretName,
+			Namespace: pvcNamespacedName.Namespace,
+		}, existingCopy)
 	if err != nil && !kerrors.IsNotFound(err) {
 		v.log.Error(err, "Failed to get secret", "secretName", secretName)
 
@@ -668,8 +979,19 @@ func (v *VSHandler) copySecretToPVCNamespace(secretName string, pvcNamespacedNam
 	}
 
 	if err == nil {
-		v.log.Info("Secret already exists in the PVC namespace", "secretName", secretName, "pvcNamespace",
-			pvcNamespacedName.Namespace)
+		// Update the copy when the pre-shared key is rotated
+		if reflect.DeepEqual(existingCopy.Data, secret.Data) {
+			v.log.Info("Secret already exists in the PVC namespace", "secretName", secretName, "pvcNamespace",
+				pvcNamespacedName.Namespace)
+
+			return nil
+		}
+
+		existingCopy.Data = secret.Data
+
+		if err := v.client.Update(v.ctx, existingCopy); err != nil {
+			return fmt.Errorf("error updating secret (%w)", err)
+		}
 
 		return nil
 	}
@@ -677,15 +999,6 @@ func (v *VSHandler) copySecretToPVCNamespace(secretName string, pvcNamespacedNam
 	v.log.Info("volsync secret not found in the pvc namespace, will create it", "secretName", secretName,
 		"pvcNamespace", pvcNamespacedName.Namespace)
 
-	err = v.client.Get(v.ctx,
-		types.NamespacedName{
-			Name:      secretName,
-			Namespace: v.owner.GetNamespace(),
-		}, secret)
-	if err != nil {
-		return fmt.Errorf("error getting secret from the admin namespace (%w)", err)
-	}
-
 	secretCopy := secret.DeepCopy()
 
 	secretCopy.ObjectMeta = metav1.ObjectMeta{
@@ -728,7 +1041,7 @@ func (v *VSHandler) DeleteRS(pvcName string, pvcNamespace string) error {
 	for i := range currentRSListByOwner.Items {
 		rs := currentRSListByOwner.Items[i]
 
-		if rs.GetName() == getReplicationSourceName(pvcName) {
+		if slices.Contains(v.rsNames(pvcName), rs.GetName()) || rs.GetName() == getReplicationSourceName(pvcName) {
 			// Delete the ReplicationSource, log errors with cleanup but continue on
 			if err := v.client.Delete(v.ctx, &rs); err != nil {
 				v.log.Error(err, "Error cleaning up ReplicationSource", "name", rs.GetName())
@@ -799,7 +1112,7 @@ func (v *VSHandler) DeleteSnapshots(pvcNamespace string) error {
 
 //nolint:gocognit
 func (v *VSHandler) deleteLocalRDAndRS(rd *volsyncv1alpha1.ReplicationDestination) error {
-	latestRDImage, err := v.getRDLatestImage(rd.GetName(), rd.GetNamespace())
+	latestRDImage, err := v.getRDRecoveryImage(rd.GetName(), rd.GetNamespace())
 	if err != nil {
 		return err
 	}
@@ -973,7 +1286,15 @@ func (v *VSHandler) listByOwner(list client.ObjectList, objNamespace string) err
 
 func (v *VSHandler) EnsurePVCfromRD(rdSpec ramendrv1alpha1.VolSyncReplicationDestinationSpec, failoverAction bool,
 ) error {
-	latestImage, err := v.getRDLatestImage(rdSpec.ProtectedPVC.Name, rdSpec.ProtectedPVC.Namespace)
+	if err := v.resticRDPullLatest(rdSpec); err != nil {
+		return err
+	}
+
+	if IsStaticVolume(rdSpec.ProtectedPVC) {
+		return v.ensureStaticPVC(rdSpec, failoverAction)
+	}
+
+	latestImage, err := v.getRDRecoveryImage(rdSpec.ProtectedPVC.Name, rdSpec.ProtectedPVC.Namespace)
 	if err != nil {
 		return err
 	}
@@ -997,6 +1318,46 @@ func (v *VSHandler) EnsurePVCfromRD(rdSpec ramendrv1alpha1.VolSyncReplicationDes
 	return v.validateSnapshotAndEnsurePVC(rdSpec, *vsImageRef, failoverAction)
 }
 
+// ensureStaticPVC ensures the PVC, that a static volume was synced directly into, exists and re-adds the
+// annotations from the old Primary, once a sync completed. There is no snapshot to roll back to on failover: the
+// PVC has the data of the latest completed sync, which ran while the application was quiesced, unless a sync was
+// interrupted by the failover, whose changes are only partially applied.
+func (v *VSHandler) ensureStaticPVC(rdSpec ramendrv1alpha1.VolSyncReplicationDestinationSpec,
+	failoverAction bool,
+) error {
+	rd, err := v.getRD(rdSpec.ProtectedPVC.Name, rdSpec.ProtectedPVC.Namespace)
+	if err != nil {
+		return err
+	}
+
+	if rd == nil || rd.Status == nil || !isLatestImageReady(rd.Status.LatestImage) {
+		return fmt.Errorf("static volume pvc %s/%s has not completed a sync", rdSpec.ProtectedPVC.Namespace,
+			rdSpec.ProtectedPVC.Name)
+	}
+
+	if rd.Status.LastSyncStartTime != nil {
+		if !failoverAction {
+			return fmt.Errorf("static volume pvc %s/%s is syncing", rdSpec.ProtectedPVC.Namespace,
+				rdSpec.ProtectedPVC.Name)
+		}
+
+		v.log.Info("Static volume sync interrupted by failover, its changes are partially applied",
+			"pvcName", rdSpec.ProtectedPVC.Name, "lastSyncTime", rd.Status.LastSyncTime)
+	}
+
+	pvc, err := v.getPVC(util.ProtectedPVCNamespacedName(rdSpec.ProtectedPVC))
+	if err != nil {
+		return err
+	}
+
+	if pvc.Spec.VolumeName != rdSpec.ProtectedPVC.StaticPVName {
+		return fmt.Errorf("pvc %s/%s is not bound to static PV %s", pvc.GetNamespace(), pvc.GetName(),
+			rdSpec.ProtectedPVC.StaticPVName)
+	}
+
+	return v.addBackOCMAnnotationsAndUpdate(pvc, rdSpec.ProtectedPVC.Annotations)
+}
+
 //nolint:cyclop,funlen,gocognit
 func (v *VSHandler) EnsurePVCforDirectCopy(ctx context.Context,
 	rdSpec ramendrv1alpha1.VolSyncReplicationDestinationSpec,
@@ -1017,6 +1378,11 @@ func (v *VSHandler) EnsurePVCforDirectCopy(ctx context.Context,
 	}
 
 	if pvc != nil {
+		// The source PVC may have been expanded since this PVC was created
+		if util.PVCExpandStorageRequest(pvc, rdSpec.ProtectedPVC.Resources.Requests.Storage()) {
+			logger.Info("Expanding PVC", "capacity", pvc.Spec.Resources.Requests.Storage())
+		}
+
 		return v.removeOCMAnnotationsAndUpdate(pvc)
 	}
 
@@ -1039,6 +1405,10 @@ func (v *VSHandler) EnsurePVCforDirectCopy(ctx context.Context,
 			pvc.Spec.StorageClassName = rdSpec.ProtectedPVC.StorageClassName
 			volumeMode := corev1.PersistentVolumeFilesystem
 			pvc.Spec.VolumeMode = &volumeMode
+
+			if IsStaticVolume(rdSpec.ProtectedPVC) {
+				staticPVCSpecSet(&pvc.Spec, rdSpec.ProtectedPVC.StaticPVName)
+			}
 		}
 
 		pvc.Spec.Resources.Requests = rdSpec.ProtectedPVC.Resources.Requests
@@ -1212,9 +1582,11 @@ func (v *VSHandler) ensurePVCFromSnapshot(rdSpec ramendrv1alpha1.VolSyncReplicat
 			return nil
 		}
 		if pvc.Status.Phase == corev1.ClaimBound {
-			// PVC already bound at this point
+			// PVC already bound at this point, expand it if the source PVC was expanded
 			l.V(1).Info("PVC already bound")
 
+			util.PVCExpandStorageRequest(pvc, pvcRequestedCapacity)
+
 			return nil
 		}
 
@@ -1358,7 +1730,10 @@ func (v *VSHandler) addOwnerReferenceAndUpdate(obj client.Object, owner metav1.O
 }
 
 func (v *VSHandler) getRsyncServiceType() *corev1.ServiceType {
-	// Use default right now - in future we may use a volsyncProfile
+	if v.profile != nil && v.profile.ServiceType != nil {
+		return v.profile.ServiceType
+	}
+
 	return &DefaultRsyncServiceType
 }
 
@@ -1517,7 +1892,19 @@ func (v *VSHandler) GetVolumeSnapshotClasses() ([]snapv1.VolumeSnapshotClass, er
 	return v.volumeSnapshotClassList.Items, nil
 }
 
-func (v *VSHandler) getScheduleCronSpec() (*string, error) {
+// getScheduleCronSpec returns the cronspec for the pvc's scheduling interval, if it overrides the VRG's, or else
+// the VRG's schedule, if specified, or else the cronspec for the VRG's scheduling interval
+func (v *VSHandler) getScheduleCronSpec(pvcSchedulingInterval string) (*string, error) {
+	if pvcSchedulingInterval != "" && (v.schedule == "" || pvcSchedulingInterval != v.schedulingInterval) {
+		return ConvertSchedulingIntervalToCronSpec(pvcSchedulingInterval)
+	}
+
+	if v.schedule != "" {
+		schedule := v.schedule
+
+		return &schedule, nil
+	}
+
 	if v.schedulingInterval != "" {
 		return ConvertSchedulingIntervalToCronSpec(v.schedulingInterval)
 	}
@@ -1531,6 +1918,9 @@ func (v *VSHandler) getScheduleCronSpec() (*string, error) {
 
 // Convert from schedulingInterval which is in the format of <num><m,h,d>
 // to the format VolSync expects, which is cronspec: https://en.wikipedia.org/wiki/Cron#Overview
+// Minutes that are a multiple of an hour are converted to hours, and hours that are a multiple of a day to days.
+// An interval that a cron step cannot express, a step of more than 59 minutes, 23 hours or 28 days, is an error
+// rather than a schedule that would not run on the interval.
 func ConvertSchedulingIntervalToCronSpec(schedulingInterval string) (*string, error) {
 	// format needs to have at least 1 number and end with m or h or d
 	if len(schedulingInterval) < SchedulingIntervalMinLength {
@@ -1547,23 +1937,43 @@ func ConvertSchedulingIntervalToCronSpec(schedulingInterval string) (*string, er
 		return nil, fmt.Errorf("scheduling interval prefix %s cannot be convered to an int value", num)
 	}
 
+	if numInt < 1 {
+		return nil, fmt.Errorf("scheduling interval %s is invalid. It must be at least 1", schedulingInterval)
+	}
+
+	if mhd == "m" && numInt > CronSpecMaxMinute && numInt%MinutesPerHour == 0 {
+		mhd, numInt = "h", numInt/MinutesPerHour
+	}
+
+	if mhd == "h" && numInt > CronSpecMaxHour && numInt%HoursPerDay == 0 {
+		mhd, numInt = "d", numInt/HoursPerDay
+	}
+
 	var cronSpec string
 
 	switch mhd {
 	case "m":
-		cronSpec = fmt.Sprintf("*/%s * * * *", num)
+		if numInt > CronSpecMaxMinute {
+			return nil, fmt.Errorf("scheduling interval %s is invalid. Minutes over %d must be whole hours",
+				schedulingInterval, CronSpecMaxMinute)
+		}
+
+		cronSpec = fmt.Sprintf("*/%d * * * *", numInt)
 	case "h":
-		// TODO: cronspec has a max here of 23 hours - do we try to convert into days?
-		cronSpec = fmt.Sprintf("0 */%s * * *", num)
+		if numInt > CronSpecMaxHour {
+			return nil, fmt.Errorf("scheduling interval %s is invalid. Hours over %d must be whole days",
+				schedulingInterval, CronSpecMaxHour)
+		}
+
+		cronSpec = fmt.Sprintf("0 */%d * * *", numInt)
 	case "d":
 		if numInt > CronSpecMaxDayOfMonth {
-			// Max # of days in interval we'll allow is 28 - otherwise there are issues converting to a cronspec
-			// which is expected to be a day of the month (1-31).  I.e. if we tried to set to */31 we'd get
-			// every 31st day of the month
-			num = "28"
+			// A cron step of days is a step of days of the month (1-31), i.e. */31 would sync on the 31st only
+			return nil, fmt.Errorf("scheduling interval %s is invalid. Days must be at most %d",
+				schedulingInterval, CronSpecMaxDayOfMonth)
 		}
 
-		cronSpec = fmt.Sprintf("0 0 */%s * *", num)
+		cronSpec = fmt.Sprintf("0 0 */%d * *", numInt)
 	}
 
 	if cronSpec == "" {
@@ -1573,30 +1983,54 @@ func ConvertSchedulingIntervalToCronSpec(schedulingInterval string) (*string, er
 	return &cronSpec, nil
 }
 
+// IsRSDataProtected returns true if the ReplicationSources of a PVC have synced to all peers
 func (v *VSHandler) IsRSDataProtected(pvcName, pvcNamespace string) (bool, error) {
 	l := v.log.WithValues("pvcName", pvcName)
 
-	// Get RD instance
-	rs := &volsyncv1alpha1.ReplicationSource{}
-
-	err := v.client.Get(v.ctx,
-		types.NamespacedName{
-			Name:      getReplicationSourceName(pvcName),
-			Namespace: pvcNamespace,
-		}, rs)
+	rss, err := v.getRSs(pvcName, pvcNamespace)
 	if err != nil {
-		if !kerrors.IsNotFound(err) {
-			l.Error(err, "Failed to get ReplicationSource")
+		l.Error(err, "Failed to get ReplicationSource")
 
-			return false, fmt.Errorf("%w", err)
-		}
+		return false, err
+	}
 
+	if rss == nil {
 		l.Info("No ReplicationSource found", "pvcName", pvcName)
 
 		return false, nil
 	}
 
-	return isRSLastSyncTimeReady(rs.Status), nil
+	return isRSLastSyncTimeReady(leastRecentlySyncedRS(rss).Status), nil
+}
+
+// BlackoutWindowActive returns true if the current time is within a blackout window, when ReplicationSources,
+// other than those running a final or manual sync, and restic ReplicationDestinations are paused. It also returns
+// the time this next changes, which is zero without blackout windows.
+func (v *VSHandler) BlackoutWindowActive() (bool, time.Time) {
+	return util.ReplicationWindowActive(v.blackoutWindows, time.Now())
+}
+
+// SetManualSyncTrigger sets the manual trigger of the ReplicationSources that are not running a final sync,
+// replacing their schedule, to request an immediate sync of each. An empty trigger restores their schedule.
+func (v *VSHandler) SetManualSyncTrigger(trigger string) {
+	v.manualSyncTrigger = trigger
+}
+
+// IsRSManualSyncComplete returns true if the ReplicationSources of a PVC completed a sync for the manual trigger
+// to all peers
+func (v *VSHandler) IsRSManualSyncComplete(pvcName, pvcNamespace, trigger string) (bool, error) {
+	rss, err := v.getRSs(pvcName, pvcNamespace)
+	if err != nil || rss == nil {
+		return false, err
+	}
+
+	for _, rs := range rss {
+		if rs.Status == nil || rs.Status.LastManualSync != trigger {
+			return false, nil
+		}
+	}
+
+	return true, nil
 }
 
 func isRSLastSyncTimeReady(rsStatus *volsyncv1alpha1.ReplicationSourceStatus) bool {
@@ -1633,7 +2067,7 @@ func (v *VSHandler) IsRDDataProtected(pvcName, pvcNamespace string) (bool, error
 
 func (v *VSHandler) PrecreateDestPVCIfEnabled(rdSpec ramendrv1alpha1.VolSyncReplicationDestinationSpec,
 ) (*string, error) {
-	if !v.IsCopyMethodDirect() {
+	if !v.IsCopyMethodDirect() && !IsStaticVolume(rdSpec.ProtectedPVC) {
 		v.log.Info("Using default copyMethod of Snapshot")
 
 		return nil, nil // use default copyMethod
@@ -1666,6 +2100,17 @@ func (v *VSHandler) PrecreateDestPVCIfEnabled(rdSpec ramendrv1alpha1.VolSyncRepl
 	return &rdSpec.ProtectedPVC.Name, nil
 }
 
+// staticPVCSpecSet binds a PVC to a static PV. A PVC without a StorageClass name is bound only to PVs without one,
+// rather than provisioned by the default StorageClass.
+func staticPVCSpecSet(pvcSpec *corev1.PersistentVolumeClaimSpec, staticPVName string) {
+	pvcSpec.VolumeName = staticPVName
+
+	if pvcSpec.StorageClassName == nil {
+		noStorageClassName := ""
+		pvcSpec.StorageClassName = &noStorageClassName
+	}
+}
+
 func (v *VSHandler) IsCopyMethodDirect() bool {
 	return v.destinationCopyMethod == volsyncv1alpha1.CopyMethodDirect
 }
@@ -1702,7 +2147,14 @@ func getLocalServiceNameForRD(rdName string) string {
 
 // This is the remote service name that can be accessed from another cluster.  This assumes submariner and that
 // a ServiceExport is created for the service on the cluster that has the ReplicationDestination
-func getRemoteServiceNameForRDFromPVCName(pvcName, rdNamespace string) string {
+// getRemoteServiceNameForRDFromPVCName returns the clusterset name of the service of the ReplicationDestination of
+// a PVC exported by a peer cluster, or by any peer cluster if the peer name is empty
+func getRemoteServiceNameForRDFromPVCName(pvcName, rdNamespace, peer string) string {
+	if peer != "" {
+		return fmt.Sprintf("%s.%s.%s.svc.clusterset.local", peer, getLocalServiceNameForRDFromPVCName(pvcName),
+			rdNamespace)
+	}
+
 	return fmt.Sprintf("%s.%s.svc.clusterset.local", getLocalServiceNameForRDFromPVCName(pvcName), rdNamespace)
 }
 
@@ -1802,9 +2254,12 @@ func (v *VSHandler) reconcileLocalRD(rdSpec ramendrv1alpha1.VolSyncReplicationDe
 			pvcAccessModes = rdSpec.ProtectedPVC.AccessModes
 		}
 
+		// The local ReplicationSource syncs to the service on the same cluster
 		lrd.Spec.RsyncTLS = &volsyncv1alpha1.ReplicationDestinationRsyncTLSSpec{
-			ServiceType: v.getRsyncServiceType(),
-			KeySecret:   &pskSecretName,
+			ServiceType:          &DefaultRsyncServiceType,
+			KeySecret:            &pskSecretName,
+			MoverSecurityContext: v.getMoverSecurityContext(),
+			MoverServiceAccount:  v.getMoverServiceAccount(),
 
 			ReplicationDestinationVolumeOptions: volsyncv1alpha1.ReplicationDestinationVolumeOptions{
 				CopyMethod:       volsyncv1alpha1.CopyMethodDirect,
@@ -1882,8 +2337,10 @@ func (v *VSHandler) reconcileLocalRS(rd *volsyncv1alpha1.ReplicationDestination,
 
 		lrs.Spec.SourcePVC = pvc.GetName()
 		lrs.Spec.RsyncTLS = &volsyncv1alpha1.ReplicationSourceRsyncTLSSpec{
-			KeySecret: &pskSecretName,
-			Address:   &address,
+			KeySecret:            &pskSecretName,
+			Address:              &address,
+			MoverSecurityContext: v.getMoverSecurityContext(),
+			MoverServiceAccount:  v.getMoverServiceAccount(),
 
 			ReplicationSourceVolumeOptions: volsyncv1alpha1.ReplicationSourceVolumeOptions{
 				CopyMethod: volsyncv1alpha1.CopyMethodDirect,
@@ -1949,7 +2406,7 @@ func (v *VSHandler) deleteLocalRD(lrdName, lrdNamespace string) error {
 
 func (v *VSHandler) setupLocalRS(rd *volsyncv1alpha1.ReplicationDestination,
 ) (*corev1.PersistentVolumeClaim, error) {
-	latestImage, err := v.getRDLatestImage(rd.GetName(), rd.GetNamespace())
+	latestImage, err := v.getRDRecoveryImage(rd.GetName(), rd.GetNamespace())
 	if err != nil {
 		return nil, err
 	}
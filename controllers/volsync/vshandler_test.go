@@ -73,6 +73,22 @@ var _ = Describe("VolSync Handler - utils", func() {
 			_, err := volsync.ConvertSchedulingIntervalToCronSpec("123")
 			Expect(err).To((HaveOccurred()))
 		})
+		It("Should convert minutes that are a multiple of an hour to hours", func() {
+			cronSpecSchedule, err := volsync.ConvertSchedulingIntervalToCronSpec("120m")
+			Expect(err).NotTo((HaveOccurred()))
+			Expect(*cronSpecSchedule).To(Equal("0 */2 * * *"))
+		})
+		It("Should convert hours that are a multiple of a day to days", func() {
+			cronSpecSchedule, err := volsync.ConvertSchedulingIntervalToCronSpec("48h")
+			Expect(err).NotTo((HaveOccurred()))
+			Expect(*cronSpecSchedule).To(Equal("0 0 */2 * *"))
+		})
+		It("Should fail if interval cannot be expressed as a cronspec step", func() {
+			for _, interval := range []string{"90m", "25h", "31d", "0m"} {
+				_, err := volsync.ConvertSchedulingIntervalToCronSpec(interval)
+				Expect(err).To(HaveOccurred(), interval)
+			}
+		})
 	})
 })
 
@@ -440,6 +456,198 @@ var _ = Describe("VolSync_Handler", func() {
 			"none")
 	})
 
+	Describe("Stagger ReplicationSources", func() {
+		rsCreate := func(name string, paused bool, reason string, lastSyncTime time.Time) {
+			rs := &volsyncv1alpha1.ReplicationSource{
+				ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: testNamespace.GetName()},
+				Spec: volsyncv1alpha1.ReplicationSourceSpec{
+					SourcePVC: name,
+					Paused:    paused,
+					RsyncTLS:  &volsyncv1alpha1.ReplicationSourceRsyncTLSSpec{},
+				},
+			}
+			Expect(k8sClient.Create(ctx, rs)).To(Succeed())
+
+			rs.Status = &volsyncv1alpha1.ReplicationSourceStatus{
+				LastSyncTime: &metav1.Time{Time: lastSyncTime},
+				Conditions: []metav1.Condition{{
+					Type:               volsyncv1alpha1.ConditionSynchronizing,
+					Status:             metav1.ConditionTrue,
+					Reason:             reason,
+					Message:            reason,
+					LastTransitionTime: metav1.Now(),
+				}},
+			}
+			Expect(k8sClient.Status().Update(ctx, rs)).To(Succeed())
+		}
+
+		var pvcs []types.NamespacedName
+
+		JustBeforeEach(func() {
+			now := time.Now()
+			rsCreate("syncing", false, volsyncv1alpha1.SynchronizingReasonSync, now.Add(-time.Hour))
+			rsCreate("due-older", true, volsyncv1alpha1.SynchronizingReasonSync, now.Add(-3*time.Hour))
+			rsCreate("due-newer", true, volsyncv1alpha1.SynchronizingReasonSync, now.Add(-2*time.Hour))
+			rsCreate("waiting", true, volsyncv1alpha1.SynchronizingReasonSched, now.Add(-4*time.Hour))
+
+			pvcs = nil
+			for _, name := range []string{"syncing", "due-older", "due-newer", "waiting", "new"} {
+				pvcs = append(pvcs, types.NamespacedName{Namespace: testNamespace.GetName(), Name: name})
+			}
+		})
+
+		It("Should not stagger ReplicationSources without a cap", func() {
+			Expect(vsHandler.StaggerReplicationSources(pvcs)).To(Equal(0))
+		})
+
+		It("Should stagger ReplicationSources due to sync beyond the cap", func() {
+			vsHandler.SetProfile(&ramendrv1alpha1.VolSyncProfile{MaxConcurrentSyncs: 3})
+
+			// The syncing ReplicationSource keeps syncing, and the ReplicationSource yet to be created and the least
+			// recently synced one due to sync are selected, leaving one due to sync waiting
+			Expect(vsHandler.StaggerReplicationSources(pvcs)).To(Equal(1))
+		})
+	})
+
+	Describe("Recovery points", func() {
+		pvcName := "rp-pvc"
+		now := time.Now().Truncate(time.Second)
+
+		var rdSpec ramendrv1alpha1.VolSyncReplicationDestinationSpec
+
+		snapshotCreate := func(name string, takenAt time.Time, recoveryPoint bool) {
+			snapshot := createSnapshot(name, testNamespace.GetName())
+
+			if recoveryPoint {
+				snapshot.SetLabels(map[string]string{
+					volsync.VRGOwnerNameLabel:      owner.GetName(),
+					volsync.VRGOwnerNamespaceLabel: owner.GetNamespace(),
+					volsync.RecoveryPointPVCLabel:  pvcName,
+				})
+				Expect(k8sClient.Update(ctx, snapshot)).To(Succeed())
+			}
+
+			snapshot.Status = &snapv1.VolumeSnapshotStatus{CreationTime: &metav1.Time{Time: takenAt}}
+			Expect(k8sClient.Status().Update(ctx, snapshot)).To(Succeed())
+		}
+
+		JustBeforeEach(func() {
+			rdSpec = ramendrv1alpha1.VolSyncReplicationDestinationSpec{
+				ProtectedPVC: ramendrv1alpha1.ProtectedPVC{
+					Name:             pvcName,
+					Namespace:        testNamespace.GetName(),
+					StorageClassName: &testStorageClassName,
+					Resources: corev1.VolumeResourceRequirements{
+						Requests: corev1.ResourceList{corev1.ResourceStorage: resource.MustParse("1Gi")},
+					},
+				},
+			}
+
+			snapshotCreate("rp-1", now.Add(-3*time.Hour), true)
+			snapshotCreate("rp-2", now.Add(-2*time.Hour), true)
+			snapshotCreate("rp-3", now.Add(-time.Hour), false)
+
+			rd := &volsyncv1alpha1.ReplicationDestination{
+				ObjectMeta: metav1.ObjectMeta{Name: pvcName, Namespace: testNamespace.GetName()},
+				Spec: volsyncv1alpha1.ReplicationDestinationSpec{
+					RsyncTLS: &volsyncv1alpha1.ReplicationDestinationRsyncTLSSpec{},
+				},
+			}
+			Expect(k8sClient.Create(ctx, rd)).To(Succeed())
+
+			apiGrp := APIGrp
+			rd.Status = &volsyncv1alpha1.ReplicationDestinationStatus{
+				LatestImage: &corev1.TypedLocalObjectReference{
+					Kind:     volsync.VolumeSnapshotKind,
+					APIGroup: &apiGrp,
+					Name:     "rp-3",
+				},
+			}
+			Expect(k8sClient.Status().Update(ctx, rd)).To(Succeed())
+
+			Eventually(func() bool {
+				err := k8sClient.Get(ctx, client.ObjectKeyFromObject(rd), rd)
+
+				return err == nil && rd.Status != nil && rd.Status.LatestImage != nil
+			}, maxWait, interval).Should(BeTrue())
+		})
+
+		It("Should retain the latest image and the latest recovery points up to the profile's number", func() {
+			vsHandler.SetProfile(&ramendrv1alpha1.VolSyncProfile{RetainedSnapshots: 2})
+
+			recoveryPoints, err := vsHandler.ReconcileRecoveryPoints(rdSpec)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(recoveryPoints).To(HaveLen(2))
+			Expect(recoveryPoints[0].SnapshotName).To(Equal("rp-3"))
+			Expect(recoveryPoints[1].SnapshotName).To(Equal("rp-2"))
+
+			snapshot := &snapv1.VolumeSnapshot{}
+			Expect(k8sClient.Get(ctx, types.NamespacedName{Name: "rp-3", Namespace: testNamespace.GetName()},
+				snapshot)).To(Succeed())
+			Expect(snapshot.GetLabels()).To(HaveKeyWithValue(volsync.VolSyncDoNotDeleteLabel,
+				volsync.VolSyncDoNotDeleteLabelVal))
+
+			Eventually(func() bool {
+				err := k8sClient.Get(ctx, types.NamespacedName{Name: "rp-1", Namespace: testNamespace.GetName()},
+					snapshot)
+
+				return kerrors.IsNotFound(err)
+			}, maxWait, interval).Should(BeTrue())
+		})
+
+		It("Should keep a recovery point a test failover restored from until the test failover ends", func() {
+			vsHandler.SetProfile(&ramendrv1alpha1.VolSyncProfile{RetainedSnapshots: 2})
+
+			snapshot := &snapv1.VolumeSnapshot{}
+			Expect(k8sClient.Get(ctx, types.NamespacedName{Name: "rp-1", Namespace: testNamespace.GetName()},
+				snapshot)).To(Succeed())
+
+			for key, value := range volsync.TestFailoverLabels(owner) {
+				snapshot.Labels[key] = value
+			}
+
+			snapshot.Labels[volsync.VolSyncDoNotDeleteLabel] = volsync.VolSyncDoNotDeleteLabelVal
+			snapshot.SetAnnotations(map[string]string{"ramendr.openshift.io/test-failover-pvc": pvcName})
+			Expect(k8sClient.Update(ctx, snapshot)).To(Succeed())
+
+			// Not pruned while a test failover uses it
+			recoveryPoints, err := vsHandler.ReconcileRecoveryPoints(rdSpec)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(recoveryPoints).To(HaveLen(2))
+			Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(snapshot), snapshot)).To(Succeed())
+
+			// Neither deleted nor released to VolSync when the test failover ends, although not the latest image
+			Expect(vsHandler.CleanupTestFailover(nil)).To(Succeed())
+			Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(snapshot), snapshot)).To(Succeed())
+			Expect(snapshot.GetLabels()).NotTo(HaveKey(volsync.TestFailoverLabel))
+			Expect(snapshot.GetLabels()).To(HaveKeyWithValue(volsync.VolSyncDoNotDeleteLabel,
+				volsync.VolSyncDoNotDeleteLabelVal))
+			Expect(snapshot.GetLabels()).To(HaveKeyWithValue(volsync.RecoveryPointPVCLabel, pvcName))
+
+			// Pruned once released, as beyond the profile's number
+			_, err = vsHandler.ReconcileRecoveryPoints(rdSpec)
+			Expect(err).NotTo(HaveOccurred())
+			Eventually(func() bool {
+				return kerrors.IsNotFound(k8sClient.Get(ctx, client.ObjectKeyFromObject(snapshot), snapshot))
+			}, maxWait, interval).Should(BeTrue())
+		})
+
+		It("Should restore from the latest recovery point at or before the recovery point time", func() {
+			vsHandler.SetRecoveryPointTime(&metav1.Time{Time: now.Add(-90 * time.Minute)})
+			Expect(vsHandler.EnsurePVCfromRD(rdSpec, false)).To(Succeed())
+
+			pvc := &corev1.PersistentVolumeClaim{}
+			Expect(k8sClient.Get(ctx, types.NamespacedName{Name: pvcName, Namespace: testNamespace.GetName()},
+				pvc)).To(Succeed())
+			Expect(pvc.Spec.DataSource.Name).To(Equal("rp-2"))
+		})
+
+		It("Should fail to restore if no recovery point is at or before the recovery point time", func() {
+			vsHandler.SetRecoveryPointTime(&metav1.Time{Time: now.Add(-4 * time.Hour)})
+			Expect(vsHandler.EnsurePVCfromRD(rdSpec, false)).NotTo(Succeed())
+		})
+	})
+
 	Describe("Reconcile ReplicationDestination", func() {
 		Context("When reconciling RDSpec", func() {
 			capacity := resource.MustParse("2Gi")
@@ -651,6 +859,196 @@ var _ = Describe("VolSync_Handler", func() {
 						})
 					})
 				})
+
+				Context("When reconciling RD with the Restic mover", func() {
+					JustBeforeEach(func() {
+						vsHandler.SetProfile(&ramendrv1alpha1.VolSyncProfile{Mover: ramendrv1alpha1.VolSyncMoverRestic})
+						vsHandler.SetResticRepository(&volsync.ResticRepository{
+							URL:             "s3:http://s3.example.com/bucket/ns/vrg/volsync",
+							Region:          "us-east-1",
+							AccessKeyID:     []byte("id"),
+							SecretAccessKey: []byte("key"),
+						})
+
+						_, err := vsHandler.ReconcileRD(rdSpec)
+						Expect(err).ToNot(HaveOccurred())
+
+						Eventually(func() error {
+							return k8sClient.Get(ctx, types.NamespacedName{
+								Name:      rdSpec.ProtectedPVC.Name,
+								Namespace: testNamespace.GetName(),
+							}, createdRD)
+						}, maxWait, interval).Should(Succeed())
+					})
+
+					It("Should create the RD to pull from the PVC's restic repository", func() {
+						Expect(createdRD.Spec.RsyncTLS).To(BeNil())
+						Expect(createdRD.Spec.Restic).NotTo(BeNil())
+						Expect(createdRD.Spec.Restic.CopyMethod).To(Equal(volsyncv1alpha1.CopyMethodSnapshot))
+						Expect(createdRD.Spec.Trigger).NotTo(BeNil())
+						Expect(createdRD.Spec.Trigger.Schedule).NotTo(BeNil())
+
+						resticSecret := &corev1.Secret{}
+						Expect(k8sClient.Get(ctx, types.NamespacedName{
+							Name:      createdRD.Spec.Restic.Repository,
+							Namespace: testNamespace.GetName(),
+						}, resticSecret)).To(Succeed())
+						Expect(string(resticSecret.Data["RESTIC_REPOSITORY"])).To(Equal(
+							"s3:http://s3.example.com/bucket/ns/vrg/volsync/" + testNamespace.GetName() + "/" +
+								rdSpec.ProtectedPVC.Name))
+						Expect(string(resticSecret.Data["AWS_ACCESS_KEY_ID"])).To(Equal("id"))
+					})
+				})
+
+				Context("When reconciling RD with a LoadBalancer VolSync profile", func() {
+					serviceType := corev1.ServiceTypeLoadBalancer
+					moverServiceAccount := "mover"
+
+					JustBeforeEach(func() {
+						vsHandler.SetProfile(&ramendrv1alpha1.VolSyncProfile{
+							ServiceType:         &serviceType,
+							LoadBalancerDomain:  "dr.example.com",
+							ServiceAnnotations:  map[string]string{"a": "b"},
+							MoverServiceAccount: &moverServiceAccount,
+						})
+
+						_, err := vsHandler.ReconcileRD(rdSpec)
+						Expect(err).ToNot(HaveOccurred())
+
+						Eventually(func() error {
+							return k8sClient.Get(ctx, types.NamespacedName{
+								Name:      rdSpec.ProtectedPVC.Name,
+								Namespace: testNamespace.GetName(),
+							}, createdRD)
+						}, maxWait, interval).Should(Succeed())
+					})
+
+					It("Should create the RD with the profile's service and mover settings", func() {
+						Expect(*createdRD.Spec.RsyncTLS.ServiceType).To(Equal(serviceType))
+						Expect(*createdRD.Spec.RsyncTLS.ServiceAnnotations).To(Equal(map[string]string{
+							"a": "b",
+							volsync.ExternalDNSHostnameAnnotation: fmt.Sprintf("volsync-rsync-tls-dst-%s.%s.dr.example.com",
+								createdRD.GetName(), createdRD.GetNamespace()),
+						}))
+						Expect(*createdRD.Spec.RsyncTLS.MoverServiceAccount).To(Equal("mover"))
+
+						// A LoadBalancer service is not exported
+						svcExport := &unstructured.Unstructured{}
+						svcExport.SetGroupVersionKind(schema.GroupVersionKind{
+							Group:   volsync.ServiceExportGroup,
+							Kind:    volsync.ServiceExportKind,
+							Version: volsync.ServiceExportVersion,
+						})
+						Consistently(func() bool {
+							return kerrors.IsNotFound(k8sClient.Get(ctx, client.ObjectKey{
+								Name:      fmt.Sprintf("volsync-rsync-tls-dst-%s", createdRD.GetName()),
+								Namespace: createdRD.GetNamespace(),
+							}, svcExport))
+						}, 1*time.Second, interval).Should(BeTrue())
+					})
+				})
+				Context("When reconciling RD with a NodePort VolSync profile", func() {
+					serviceType := corev1.ServiceTypeNodePort
+					var service *corev1.Service
+
+					JustBeforeEach(func() {
+						vsHandler.SetProfile(&ramendrv1alpha1.VolSyncProfile{
+							ServiceType:        &serviceType,
+							LoadBalancerDomain: "dr.example.com",
+						})
+
+						// VolSync creates the service with a node port allocated by the cluster
+						service = &corev1.Service{
+							ObjectMeta: metav1.ObjectMeta{
+								Name:      fmt.Sprintf("volsync-rsync-tls-dst-%s", rdSpec.ProtectedPVC.Name),
+								Namespace: testNamespace.GetName(),
+							},
+							Spec: corev1.ServiceSpec{
+								Type:  corev1.ServiceTypeNodePort,
+								Ports: []corev1.ServicePort{{Name: "rsync-tls", Port: 8000}},
+							},
+						}
+						Expect(k8sClient.Create(ctx, service)).To(Succeed())
+
+						_, err := vsHandler.ReconcileRD(rdSpec)
+						Expect(err).ToNot(HaveOccurred())
+					})
+
+					It("Should set the node port of the RD service from the PVC", func() {
+						var nodePort int32
+
+						Eventually(func() int32 {
+							Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(service), service)).To(Succeed())
+							nodePort = service.Spec.Ports[0].NodePort
+
+							return nodePort
+						}, maxWait, interval).Should(And(BeNumerically(">=", 30000), BeNumerically("<=", 32767)))
+
+						_, err := vsHandler.ReconcileRD(rdSpec)
+						Expect(err).ToNot(HaveOccurred())
+						Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(service), service)).To(Succeed())
+						Expect(service.Spec.Ports[0].NodePort).To(Equal(nodePort))
+					})
+
+					It("Should set the next free node port, and report it, if another service has the one from the PVC",
+						func() {
+							Eventually(func() int32 {
+								Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(service), service)).To(Succeed())
+
+								return service.Spec.Ports[0].NodePort
+							}, maxWait, interval).ShouldNot(BeZero())
+
+							nodePort := service.Spec.Ports[0].NodePort
+							Expect(vsHandler.RDNodePorts()).To(ConsistOf(ramendrv1alpha1.VolSyncNodePort{
+								Namespace: rdSpec.ProtectedPVC.Namespace,
+								Name:      rdSpec.ProtectedPVC.Name,
+								NodePort:  nodePort,
+							}))
+
+							// Another service is allocated the node port while VolSync recreates the RD service
+							Expect(k8sClient.Delete(ctx, service)).To(Succeed())
+
+							occupant := &corev1.Service{
+								ObjectMeta: metav1.ObjectMeta{Name: "occupant", Namespace: testNamespace.GetName()},
+								Spec: corev1.ServiceSpec{
+									Type:  corev1.ServiceTypeNodePort,
+									Ports: []corev1.ServicePort{{Name: "other", Port: 8000, NodePort: nodePort}},
+								},
+							}
+							Eventually(func() error {
+								return k8sClient.Create(ctx, occupant)
+							}, maxWait, interval).Should(Succeed())
+
+							service.ObjectMeta = metav1.ObjectMeta{Name: service.GetName(), Namespace: service.GetNamespace()}
+							service.Spec.Ports[0].NodePort = 0
+							service.Spec.ClusterIP = ""
+							service.Spec.ClusterIPs = nil
+							Expect(k8sClient.Create(ctx, service)).To(Succeed())
+
+							vsHandler = volsync.NewVSHandler(ctx, k8sClient, logger, owner, asyncSpec, "none", "Snapshot",
+								false)
+							vsHandler.SetProfile(&ramendrv1alpha1.VolSyncProfile{
+								ServiceType:        &serviceType,
+								LoadBalancerDomain: "dr.example.com",
+							})
+
+							_, err := vsHandler.ReconcileRD(rdSpec)
+							Expect(err).ToNot(HaveOccurred())
+							Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(service), service)).To(Succeed())
+
+							nextNodePort := nodePort + 1
+							if nodePort == 32767 {
+								nextNodePort = 30000
+							}
+
+							Expect(service.Spec.Ports[0].NodePort).To(Equal(nextNodePort))
+							Expect(vsHandler.RDNodePorts()).To(ConsistOf(ramendrv1alpha1.VolSyncNodePort{
+								Namespace: rdSpec.ProtectedPVC.Namespace,
+								Name:      rdSpec.ProtectedPVC.Name,
+								NodePort:  nextNodePort,
+							}))
+						})
+				})
 			})
 
 			Context("With CopyMethod 'Direct'", func() {
@@ -871,6 +1269,144 @@ var _ = Describe("VolSync_Handler", func() {
 						})
 					})
 
+					Context("When reconciling RS with more than one peer cluster", func() {
+						peers := []string{"cluster-b", "cluster-c"}
+
+						JustBeforeEach(func() {
+							vsHandler.SetPeerClusters("cluster-a", peers)
+						})
+
+						It("Should create a ReplicationSource per peer syncing to the peer's address", func() {
+							finalSyncDone, returnedRS, err := vsHandler.ReconcileRS(rsSpec, false)
+							Expect(err).ToNot(HaveOccurred())
+							Expect(finalSyncDone).To(BeFalse())
+							Expect(returnedRS).NotTo(BeNil())
+
+							for _, peer := range peers {
+								peerRS := &volsyncv1alpha1.ReplicationSource{}
+								Eventually(func() error {
+									return k8sClient.Get(ctx, types.NamespacedName{
+										Name:      rsSpec.ProtectedPVC.Name + "-" + peer,
+										Namespace: testNamespace.GetName(),
+									}, peerRS)
+								}, maxWait, interval).Should(Succeed())
+
+								Expect(peerRS.Spec.SourcePVC).To(Equal(rsSpec.ProtectedPVC.Name))
+								Expect(*peerRS.Spec.RsyncTLS.Address).To(Equal(peer + ".volsync-rsync-tls-dst-" +
+									rsSpec.ProtectedPVC.Name + "." + testNamespace.GetName() + ".svc.clusterset.local"))
+							}
+
+							Expect(vsHandler.DeleteRS(rsSpec.ProtectedPVC.Name, testNamespace.GetName())).To(Succeed())
+							Eventually(func() int {
+								rsList := &volsyncv1alpha1.ReplicationSourceList{}
+								Expect(k8sClient.List(ctx, rsList, client.InNamespace(testNamespace.GetName()))).To(Succeed())
+
+								return len(rsList.Items)
+							}, maxWait, interval).Should(BeZero())
+						})
+
+						It("Should delete the ReplicationSource it had before it had more than one peer", func() {
+							vsHandler.SetPeerClusters("cluster-a", peers[:1])
+							_, returnedRS, err := vsHandler.ReconcileRS(rsSpec, false)
+							Expect(err).ToNot(HaveOccurred())
+							Expect(returnedRS).NotTo(BeNil())
+							Expect(returnedRS.GetName()).To(Equal(rsSpec.ProtectedPVC.Name))
+
+							vsHandler.SetPeerClusters("cluster-a", peers)
+							_, returnedRS, err = vsHandler.ReconcileRS(rsSpec, false)
+							Expect(err).ToNot(HaveOccurred())
+							Expect(returnedRS).NotTo(BeNil())
+
+							Eventually(func() bool {
+								err := k8sClient.Get(ctx, types.NamespacedName{
+									Name:      rsSpec.ProtectedPVC.Name,
+									Namespace: testNamespace.GetName(),
+								}, &volsyncv1alpha1.ReplicationSource{})
+
+								return kerrors.IsNotFound(err)
+							}, maxWait, interval).Should(BeTrue())
+						})
+					})
+
+					Context("When reconciling RS for a static volume", func() {
+						It("Should quiesce its application when a sync is due, and resume it once the sync completes", func() {
+							staticRSSpec := rsSpec
+							staticRSSpec.ProtectedPVC.StaticPVName = "static-pv"
+							pvcNamespacedName := types.NamespacedName{
+								Namespace: staticRSSpec.ProtectedPVC.Namespace, Name: staticRSSpec.ProtectedPVC.Name,
+							}
+
+							// The sync waits for the application pod mounting the PVC to be quiesced
+							_, returnedRS, err := vsHandler.ReconcileRS(staticRSSpec, false)
+							Expect(err).ToNot(HaveOccurred())
+							Expect(returnedRS).NotTo(BeNil())
+							Expect(returnedRS.Spec.RsyncTLS.CopyMethod).To(Equal(volsyncv1alpha1.CopyMethodDirect))
+							Expect(returnedRS.Spec.Paused).To(BeTrue())
+							Expect(vsHandler.StaticVolumesQuiesce()).To(ConsistOf(pvcNamespacedName))
+
+							Expect(k8sClient.Delete(ctx, podMountingPVC)).To(Succeed())
+							Eventually(func() bool {
+								err := k8sClient.Get(ctx, client.ObjectKeyFromObject(podMountingPVC), podMountingPVC)
+
+								return kerrors.IsNotFound(err)
+							}, maxWait, interval).Should(BeTrue())
+
+							// The sync is triggered once the application is quiesced, which stays quiesced until it completes
+							Eventually(func() bool {
+								vsHandler = volsync.NewVSHandler(ctx, k8sClient, logger, owner, asyncSpec, "none", "Snapshot",
+									false)
+								_, returnedRS, err = vsHandler.ReconcileRS(staticRSSpec, false)
+								Expect(err).ToNot(HaveOccurred())
+								Expect(returnedRS).NotTo(BeNil())
+
+								return returnedRS.Spec.Paused
+							}, maxWait, interval).Should(BeFalse())
+							Expect(returnedRS.Spec.Trigger.Schedule).To(BeNil())
+							Expect(returnedRS.Spec.Trigger.Manual).To(HavePrefix(volsync.ScheduleTriggerPrefix))
+							Expect(vsHandler.StaticVolumesQuiesce()).To(ConsistOf(pvcNamespacedName))
+
+							returnedRS.Status = &volsyncv1alpha1.ReplicationSourceStatus{
+								LastManualSync: returnedRS.Spec.Trigger.Manual,
+							}
+							Expect(k8sClient.Status().Update(ctx, returnedRS)).To(Succeed())
+
+							// The application resumes once the sync completes, until the next sync is due
+							Eventually(func() []types.NamespacedName {
+								vsHandler = volsync.NewVSHandler(ctx, k8sClient, logger, owner, asyncSpec, "none", "Snapshot",
+									false)
+								_, returnedRS, err = vsHandler.ReconcileRS(staticRSSpec, false)
+								Expect(err).ToNot(HaveOccurred())
+
+								return vsHandler.StaticVolumesQuiesce()
+							}, maxWait, interval).Should(BeEmpty())
+							Expect(returnedRS.Spec.Paused).To(BeFalse())
+						})
+					})
+
+					Context("When reconciling RS with a schedule the VolSync API does not accept", func() {
+						JustBeforeEach(func() {
+							vsHandler = volsync.NewVSHandler(ctx, k8sClient, logger, owner,
+								&ramendrv1alpha1.VRGAsyncSpec{SchedulingInterval: "5m", Schedule: "0,30 * * * *"},
+								"none", "Snapshot", false)
+						})
+
+						It("Should trigger the RS manually when the schedule is due", func() {
+							_, returnedRS, err := vsHandler.ReconcileRS(rsSpec, false)
+							Expect(err).ToNot(HaveOccurred())
+							Expect(returnedRS).NotTo(BeNil())
+							Expect(returnedRS.Spec.Trigger.Schedule).To(BeNil())
+							Expect(returnedRS.Spec.Trigger.Manual).To(HavePrefix(volsync.ScheduleTriggerPrefix))
+							Expect(vsHandler.ScheduledSyncNext().Minute()).To(BeElementOf(0, 30))
+							Expect(vsHandler.ScheduledSyncNext()).To(BeTemporally("<=", time.Now().Add(30*time.Minute)))
+
+							// The trigger is kept until the schedule is next due
+							trigger := returnedRS.Spec.Trigger.Manual
+							_, returnedRS, err = vsHandler.ReconcileRS(rsSpec, false)
+							Expect(err).ToNot(HaveOccurred())
+							Expect(returnedRS.Spec.Trigger.Manual).To(Equal(trigger))
+						})
+					})
+
 					Context("When reconciling RS with no previous RD", func() {
 						var returnedRS *volsyncv1alpha1.ReplicationSource
 
@@ -1066,6 +1602,14 @@ var _ = Describe("VolSync_Handler", func() {
 											Expect(finalSyncDone).To(BeTrue())
 											Expect(returnedRS).NotTo(BeNil())
 
+											// The pvc is kept until the final sync of all pvcs is complete
+											Consistently(func() error {
+												return k8sClient.Get(ctx, client.ObjectKeyFromObject(testPVC), testPVC)
+											}, 1*time.Second, interval).Should(Succeed())
+											Expect(util.ResourceIsDeleted(testPVC)).To(BeFalse())
+
+											Expect(vsHandler.CleanupAfterFinalSync(rsSpec)).To(Succeed())
+
 											// Now check to see if the pvc was removed
 											Eventually(func() bool {
 												err := k8sClient.Get(ctx, client.ObjectKeyFromObject(testPVC), testPVC)
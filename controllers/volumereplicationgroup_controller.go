@@ -55,6 +55,7 @@ type VolumeReplicationGroupReconciler struct {
 	kubeObjects         kubeobjects.RequestsManager
 	RateLimiter         *workqueue.RateLimiter
 	veleroCRsAreWatched bool
+	vmReader            client.Reader
 }
 
 // SetupWithManager sets up the controller with the Manager.
@@ -107,6 +108,13 @@ func (r *VolumeReplicationGroupReconciler) SetupWithManager(
 		r.Log.Info("VolSync disabled; don't own volsync resources")
 	}
 
+	if ramenConfig.VolumeGroupReplication.FeatureEnabled {
+		r.Log.Info("VolumeGroupReplication enabled; adding watches")
+		ctrlBuilder = r.addVolGroupRepWatches(ctrlBuilder)
+	}
+
+	ctrlBuilder = r.addVMWatches(ctrlBuilder, mgr)
+
 	r.kubeObjects = velero.RequestsManager{}
 
 	if !ramenConfig.KubeObjectProtection.Disabled {
@@ -133,7 +141,7 @@ func (r *VolumeReplicationGroupReconciler) pvcMapFunc(ctx context.Context, obj c
 		return []reconcile.Request{}
 	}
 
-	return filterPVC(r.Client, pvc,
+	return filterPVC(r.Client, r.vmReader, pvc,
 		log.WithValues("pvc", types.NamespacedName{Name: pvc.Name, Namespace: pvc.Namespace}))
 }
 
@@ -244,6 +252,13 @@ func updateEventDecision(oldPVC, newPVC *corev1.PersistentVolumeClaim, log logr.
 		return requeue
 	}
 
+	if oldCapacity, newCapacity := oldPVC.Status.Capacity.Storage(), newPVC.Status.Capacity.Storage(); !oldCapacity.Equal(
+		*newCapacity) {
+		predicateLog.Info("Reconciling due to capacity change", "before", oldCapacity, "after", newCapacity)
+
+		return requeue
+	}
+
 	if oldPVC.Status.Phase != corev1.ClaimBound && newPVC.Status.Phase == corev1.ClaimBound {
 		predicateLog.Info("Reconciling due to phase change", "before", oldPVC.Status.Phase, "after", newPVC.Status.Phase)
 
@@ -296,7 +311,9 @@ func pvcAnnotationAdded(oldPVC, newPVC *corev1.PersistentVolumeClaim) (bool, boo
 	return protectedAdded || archivedAdded, protectedAdded, archivedAdded
 }
 
-func filterPVC(reader client.Reader, pvc *corev1.PersistentVolumeClaim, log logr.Logger) []reconcile.Request {
+func filterPVC(reader, vmReader client.Reader, pvc *corev1.PersistentVolumeClaim,
+	log logr.Logger,
+) []reconcile.Request {
 	req := []reconcile.Request{}
 
 	var vrgs ramendrv1alpha1.VolumeReplicationGroupList
@@ -342,8 +359,27 @@ func filterPVC(reader client.Reader, pvc *corev1.PersistentVolumeClaim, log logr
 		vrgNamespacedName := types.NamespacedName{Name: vrg.Name, Namespace: vrg.Namespace}
 		namespaceSelected := slices.Contains(pvcSelector.NamespaceNames, pvc.Namespace)
 		labelMatch := selector.Matches(labels.Set(pvc.GetLabels()))
+
+		if labelMatch && FindProtectedPVC(&vrg, pvc.Namespace, pvc.Name) == nil {
+			labelMatch, err = rmnutil.PVCMatchesFilter(pvc, pvcSelector.Filter)
+			if err != nil {
+				log1.Error(err, "Failed to filter the PVC for VolumeReplicationGroup")
+
+				continue
+			}
+		}
+
 		ownerMatch := rmnutil.OwnerNamespacedName(pvc) == vrgNamespacedName
 
+		if !labelMatch && namespaceSelected && !ownerMatch {
+			labelMatch, err = vmsSelectPVC(context.TODO(), vmReader, &vrg, pvcSelector, pvc)
+			if err != nil {
+				log1.Error(err, "Failed to find the VMs of the PVC for VolumeReplicationGroup")
+
+				continue
+			}
+		}
+
 		if labelMatch && namespaceSelected || ownerMatch {
 			log1.Info("Found VolumeReplicationGroup with matching labels or owner",
 				"vrg", vrgNamespacedName.String(), "selector", selector,
@@ -363,14 +399,21 @@ func filterPVC(reader client.Reader, pvc *corev1.PersistentVolumeClaim, log logr
 // +kubebuilder:rbac:groups=ramendr.openshift.io,resources=volumereplicationgroups/finalizers,verbs=update
 // +kubebuilder:rbac:groups=replication.storage.openshift.io,resources=volumereplications,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=replication.storage.openshift.io,resources=volumereplicationclasses,verbs=get;list;watch
+// +kubebuilder:rbac:groups=replication.storage.openshift.io,resources=volumegroupreplications,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=replication.storage.openshift.io,resources=volumegroupreplicationclasses,verbs=get;list;watch
 // +kubebuilder:rbac:groups=storage.k8s.io,resources=storageclasses,verbs=get;list;watch;create;update
 // +kubebuilder:rbac:groups=storage.k8s.io,resources=volumeattachments,verbs=get;list;watch
 // +kubebuilder:rbac:groups=core,resources=pods,verbs=get;list;watch
-// +kubebuilder:rbac:groups=core,resources=persistentvolumeclaims,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=apps,resources=deployments;statefulsets,verbs=get;list;watch;update;patch
+// +kubebuilder:rbac:groups=apps,resources=replicasets,verbs=get;list;watch
+// +kubebuilder:rbac:groups=core,resources=services,verbs=get;list;watch;update
+// +kubebuilder:rbac:groups=core,resources=persistentvolumeclaims,verbs=get;list;watch;create;update;patch;delete;deletecollection
+// +kubebuilder:rbac:groups=core,resources=namespaces,verbs=get;list;watch;create;update;delete
 // +kubebuilder:rbac:groups=core,resources=persistentvolumes,verbs=get;list;watch;update;patch;create
 // +kubebuilder:rbac:groups=volsync.backube,resources=replicationdestinations,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=volsync.backube,resources=replicationsources,verbs=get;list;watch;create;update;patch;delete
-// +kubebuilder:rbac:groups=snapshot.storage.k8s.io,resources=volumesnapshots,verbs=get;list;watch;update;delete
+// +kubebuilder:rbac:groups=snapshot.storage.k8s.io,resources=volumesnapshots,verbs=get;list;watch;create;update;delete;deletecollection
+// +kubebuilder:rbac:groups=snapshot.storage.k8s.io,resources=volumesnapshotcontents,verbs=get;list;watch;create;delete;deletecollection
 // +kubebuilder:rbac:groups=snapshot.storage.k8s.io,resources=volumesnapshotclasses,verbs=get;list;watch
 // +kubebuilder:rbac:groups=multicluster.x-k8s.io,resources=serviceexports,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=core,resources=events,verbs=get;create;patch;update
@@ -378,6 +421,8 @@ func filterPVC(reader client.Reader, pvc *corev1.PersistentVolumeClaim, log logr
 // +kubebuilder:rbac:groups=ramendr.openshift.io,resources=recipes,verbs=get;list;watch
 // +kubebuilder:rbac:groups="",resources=configmaps,verbs=list;watch
 // +kubebuilder:rbac:groups="apiextensions.k8s.io",resources=customresourcedefinitions,verbs=get;list;watch
+// +kubebuilder:rbac:groups=kubevirt.io,resources=virtualmachines,verbs=get;list;watch;update;patch
+// +kubebuilder:rbac:groups=kubevirt.io,resources=virtualmachineinstances,verbs=get;list;watch
 
 // Reconcile is part of the main kubernetes reconciliation loop which aims to
 // move the current state of the cluster closer to the desired state.
@@ -440,6 +485,7 @@ func (r *VolumeReplicationGroupReconciler) Reconcile(ctx context.Context, req ct
 	v.volSyncHandler = volsync.NewVSHandler(ctx, r.Client, log, v.instance,
 		v.instance.Spec.Async, cephFSCSIDriverNameOrDefault(v.ramenConfig),
 		volSyncDestinationCopyMethodOrDefault(v.ramenConfig), adminNamespaceVRG)
+	v.volSyncProfileSet()
 
 	if v.instance.Status.ProtectedPVCs == nil {
 		v.instance.Status.ProtectedPVCs = []ramendrv1alpha1.ProtectedPVC{}
@@ -478,6 +524,7 @@ type VRGInstance struct {
 	vrgObjectProtected   *metav1.Condition
 	kubeObjectsProtected *metav1.Condition
 	vrcUpdated           bool
+	notDemotedPVCs       []string
 	namespacedName       string
 	volSyncHandler       *volsync.VSHandler
 	objectStorers        map[string]cachedObjectStorer
@@ -496,11 +543,16 @@ const (
 	pvcVRAnnotationProtectedValue    = "protected"
 	pvcVRAnnotationArchivedKey       = "volumereplicationgroups.ramendr.openshift.io/vr-archived"
 	pvcVRAnnotationArchivedVersionV1 = "archiveV1"
+	pvcVRAnnotationArchivedCapacity  = "volumereplicationgroups.ramendr.openshift.io/vr-archived-capacity"
+	pvcAnnotationRestoreCapacity     = "volumereplicationgroups.ramendr.openshift.io/restore-capacity"
 	pvVRAnnotationRetentionKey       = "volumereplicationgroups.ramendr.openshift.io/vr-retained"
 	pvVRAnnotationRetentionValue     = "retained"
 	RestoreAnnotation                = "volumereplicationgroups.ramendr.openshift.io/ramen-restore"
 	RestoredByRamen                  = "True"
 
+	// PVC annotation to override the VRG's scheduling interval for the PVC
+	PVCSchedulingIntervalAnnotation = "ramendr.openshift.io/scheduling-interval"
+
 	// StorageClass label
 	StorageIDLabel = "ramendr.openshift.io/storageid"
 
@@ -622,19 +674,56 @@ func (v *VRGInstance) clusterDataRestore(result *ctrl.Result) (int, error) {
 		return numRestoredForVS + numRestoredForVR, fmt.Errorf("failed to restore PV/PVC for VolRep (%w)", err)
 	}
 
-	// Only after both succeed, we mark ClusterDataReady as true
+	numRestoredForSnapshots, err := v.volumeSnapshotsRestore()
+	if err != nil {
+		v.log.Info("VolumeSnapshot restore failed")
+
+		return numRestoredForVS + numRestoredForVR + numRestoredForSnapshots,
+			fmt.Errorf("failed to restore VolumeSnapshots (%w)", err)
+	}
+
+	// Only after all succeed, we mark ClusterDataReady as true
 	msg := "Restored PVs and PVCs"
-	if numRestoredForVS+numRestoredForVR == 0 {
+	if numRestoredForVS+numRestoredForVR+numRestoredForSnapshots == 0 {
 		msg = "Nothing to restore"
 	}
 
 	setVRGClusterDataReadyCondition(&v.instance.Status.Conditions, v.instance.Generation, msg)
 
-	return numRestoredForVS + numRestoredForVR, nil
+	return numRestoredForVS + numRestoredForVR + numRestoredForSnapshots, nil
 }
 
 func (v *VRGInstance) listPVCsByVrgPVCSelector() (*corev1.PersistentVolumeClaimList, error) {
-	return v.listPVCsByPVCSelector(v.recipeElements.PvcSelector.LabelSelector)
+	pvcList, err := v.listPVCsByPVCSelector(v.recipeElements.PvcSelector.LabelSelector)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := rmnutil.FilterPVCs(pvcList, v.recipeElements.PvcSelector.Filter, v.pvcProtectedDespiteFilter); err != nil {
+		return nil, err
+	}
+
+	if err := v.vmPVCsAppend(pvcList); err != nil {
+		return nil, err
+	}
+
+	if err := v.volumeSnapshotPVCsAppend(pvcList); err != nil {
+		return nil, err
+	}
+
+	return pvcList, nil
+}
+
+// pvcProtectedDespiteFilter returns true if a PVC that no longer satisfies the PVC filter, such as after its size
+// or annotations changed, is protected already. It remains protected until it is deselected by the label selector.
+func (v *VRGInstance) pvcProtectedDespiteFilter(pvc *corev1.PersistentVolumeClaim) bool {
+	if FindProtectedPVC(v.instance, pvc.Namespace, pvc.Name) == nil {
+		return false
+	}
+
+	v.log.Info("PVC no longer satisfies the PVC filter, but remains protected", "pvc", pvc.Namespace+"/"+pvc.Name)
+
+	return true
 }
 
 func (v *VRGInstance) listPVCsOwnedByVrg() (*corev1.PersistentVolumeClaimList, error) {
@@ -699,7 +788,14 @@ func (v *VRGInstance) updatePVCList() error {
 }
 
 func (v *VRGInstance) updateReplicationClassList() error {
-	labelSelector := v.instance.Spec.Async.ReplicationClassSelector
+	labelSelector := metav1.LabelSelector{}
+
+	switch {
+	case v.instance.Spec.Async != nil:
+		labelSelector = v.instance.Spec.Async.ReplicationClassSelector
+	case v.instance.Spec.Sync != nil:
+		labelSelector = v.instance.Spec.Sync.ReplicationClassSelector
+	}
 
 	v.log.Info("Fetching VolumeReplicationClass", "labeled", labels.Set(labelSelector.MatchLabels))
 	listOptions := []client.ListOption{
@@ -739,6 +835,23 @@ func (v *VRGInstance) separatePVCsUsingStorageClassProvisioner(pvcList *corev1.P
 		pvc := &pvcList.Items[idx]
 		scName := pvc.Spec.StorageClassName
 
+		staticPV, err := v.pvcStaticPV(pvc)
+		if err != nil {
+			return fmt.Errorf("failed to check PV of PVC %s/%s (%w)", pvc.GetNamespace(), pvc.GetName(), err)
+		}
+
+		// Static PVs are not provisioned by a storage backend that could replicate them
+		if staticPV != nil {
+			if v.ramenConfig.VolSync.Disabled {
+				return fmt.Errorf("PVC %s/%s is bound to static PV %s, which requires VolSync, but VolSync is disabled",
+					pvc.GetNamespace(), pvc.GetName(), staticPV.GetName())
+			}
+
+			v.volSyncPVCs = append(v.volSyncPVCs, *pvc)
+
+			continue
+		}
+
 		if scName == nil || *scName == "" {
 			return fmt.Errorf("missing storage class name for PVC %s/%s", pvc.GetNamespace(), pvc.GetName())
 		}
@@ -778,18 +891,41 @@ func (v *VRGInstance) processForDeletion() ctrl.Result {
 
 	defer v.log.Info("Exiting processing VolumeReplicationGroup")
 
+	unfreezeResult := ctrl.Result{}
+	if v.reconcileConsistentSyncAbort(&unfreezeResult, "aborted as VRG is deleted") {
+		v.log.Info("Requeuing as the application is unfreezing")
+
+		return v.updateVRGConditionsAndStatus(unfreezeResult)
+	}
+
 	if err := v.disownPVCs(); err != nil {
 		v.log.Info("Disowning PVCs failed", "error", err)
 
 		return ctrl.Result{Requeue: true}
 	}
 
+	if v.instance.Spec.ReplicationState == ramendrv1alpha1.Primary {
+		if err := v.volumeSnapshotPVCsDelete(); err != nil {
+			v.log.Info("VolumeSnapshot PVCs deletion failed", "error", err)
+
+			return ctrl.Result{Requeue: true}
+		}
+	}
+
 	if err := v.cleanupResources(); err != nil {
 		v.log.Info("Cleanup owned resources failed", "error", err)
 
 		return ctrl.Result{Requeue: true}
 	}
 
+	if err := v.testFailoverCleanup(); err != nil {
+		v.log.Info("Test failover cleanup failed", "error", err)
+
+		return ctrl.Result{Requeue: true}
+	}
+
+	v.resyncMetricsDelete()
+
 	if !containsString(v.instance.ObjectMeta.Finalizers, vrgFinalizerName) {
 		v.log.Info("Finalizer missing from resource", "finalizer", vrgFinalizerName)
 
@@ -901,7 +1037,10 @@ func (v *VRGInstance) processAsPrimary() ctrl.Result {
 		}
 	}
 
+	v.consistentSyncTriggerSet()
 	v.reconcileAsPrimary()
+	v.reconcileConsistentSync(&v.result)
+	v.reconcileTestFailover(&v.result)
 
 	// If requeue is false, then VRG was successfully processed as primary.
 	// Hence the event to be generated is Success of type normal.
@@ -947,16 +1086,26 @@ func (v *VRGInstance) shouldRestoreClusterData() bool {
 func (v *VRGInstance) reconcileAsPrimary() {
 	var finalSyncPrepared struct {
 		volSync bool
+		vms     bool
 	}
 
 	vrg := v.instance
 	v.result.Requeue = v.reconcileVolSyncAsPrimary(&finalSyncPrepared.volSync)
 	v.reconcileVolRepsAsPrimary()
+
+	if v.reconcileVolumeSnapshotsAsPrimary() {
+		v.result.Requeue = true
+	}
+
 	v.kubeObjectsProtectPrimary(&v.result)
 	v.vrgObjectProtect(&v.result)
+	finalSyncPrepared.vms = v.reconcileVMsAsPrimary()
 
 	if vrg.Spec.PrepareForFinalSync {
-		vrg.Status.PrepareForFinalSyncComplete = finalSyncPrepared.volSync
+		vrg.Status.PrepareForFinalSyncComplete = finalSyncPrepared.volSync && finalSyncPrepared.vms
+	} else if !vrg.Spec.RunFinalSync {
+		// A final sync cancelled by an aborted relocation is prepared again when the relocation is retried
+		vrg.Status.PrepareForFinalSyncComplete = false
 	}
 }
 
@@ -1037,6 +1186,9 @@ func (v *VRGInstance) processAsSecondary() ctrl.Result {
 	v.instance.Status.LastGroupSyncTime = nil
 
 	result := v.reconcileAsSecondary()
+	if v.reconcileConsistentSyncAbort(&result, "aborted as VRG is no longer primary") {
+		result.Requeue = true
+	}
 
 	// If requeue is false, then VRG was successfully processed as Secondary.
 	// Hence the event to be generated is Success of type normal.
@@ -1053,6 +1205,7 @@ func (v *VRGInstance) processAsSecondary() ctrl.Result {
 func (v *VRGInstance) reconcileAsSecondary() ctrl.Result {
 	vrg := v.instance
 	result := ctrl.Result{}
+	result.Requeue = v.reconcileVMsAsSecondary()
 	result.Requeue = v.reconcileVolSyncAsSecondary() || result.Requeue
 	result.Requeue = v.reconcileVolRepsAsSecondary() || result.Requeue
 
@@ -1064,10 +1217,18 @@ func (v *VRGInstance) reconcileAsSecondary() ctrl.Result {
 	// Clear the conditions only if there are no more work as secondary and the RDSpec is not empty.
 	// Note: When using VolSync, we preserve the secondary and we need the status of the VRG to be
 	// clean. In all other cases, the VRG will be deleted and we don't care about the its conditions.
+	// The test failover condition is preserved, as it tracks work that is independent of replication.
 	if !result.Requeue && len(v.instance.Spec.VolSync.RDSpec) > 0 {
-		v.instance.Status.Conditions = []metav1.Condition{}
+		conditions := []metav1.Condition{}
+		if testFailover := findCondition(v.instance.Status.Conditions, VRGConditionTypeTestFailover); testFailover != nil {
+			conditions = append(conditions, *testFailover)
+		}
+
+		v.instance.Status.Conditions = conditions
 	}
 
+	v.reconcileTestFailover(&result)
+
 	return result
 }
 
@@ -1282,6 +1443,9 @@ func (v *VRGInstance) updateVRGConditions() {
 	v.updateVRGLastGroupSyncTime()
 	v.updateVRGLastGroupSyncDuration()
 	v.updateLastGroupSyncBytes()
+	v.updateVRGGroupSyncProgress()
+	v.updateVRGGroupSchedulingInterval()
+	v.updateVRGVolumesNotDemotedCondition()
 }
 
 func (v *VRGInstance) vrgReadyStatus(reason string) *metav1.Condition {
@@ -1376,6 +1540,28 @@ func (v *VRGInstance) updateLastGroupSyncBytes() {
 	v.instance.Status.LastGroupSyncBytes = totalLastSyncBytes
 }
 
+func (v *VRGInstance) updateVRGGroupSyncProgress() {
+	var latestCompletionTime *metav1.Time
+
+	syncsInProgress := 0
+
+	for _, protectedPVC := range v.instance.Status.ProtectedPVCs {
+		if !protectedPVC.SyncInProgress {
+			continue
+		}
+
+		syncsInProgress++
+
+		if protectedPVC.EstimatedSyncCompletionTime != nil &&
+			(latestCompletionTime == nil || latestCompletionTime.Before(protectedPVC.EstimatedSyncCompletionTime)) {
+			latestCompletionTime = protectedPVC.EstimatedSyncCompletionTime
+		}
+	}
+
+	v.instance.Status.GroupSyncsInProgress = syncsInProgress
+	v.instance.Status.EstimatedGroupSyncCompletionTime = latestCompletionTime
+}
+
 // isVRGReasonError returns true if the passed in VRG condition reason matches any errors reported as the Reason
 func isVRGReasonError(condition *metav1.Condition) bool {
 	return condition.Reason == VRGConditionReasonError ||
@@ -0,0 +1,187 @@
+// SPDX-FileCopyrightText: The RamenDR authors
+// SPDX-License-Identifier: Apache-2.0
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	ramendrv1alpha1 "github.com/ramendr/ramen/api/v1alpha1"
+	rmnutil "github.com/ramendr/ramen/controllers/util"
+)
+
+// VolumeReplicationGroupWebhook defaults and validates VolumeReplicationGroups on admission, rejecting the
+// misconfigurations that a reconcile would otherwise only report in the VRG's conditions
+type VolumeReplicationGroupWebhook struct{}
+
+// +kubebuilder:webhook:path=/mutate-ramendr-openshift-io-v1alpha1-volumereplicationgroup,mutating=true,failurePolicy=fail,sideEffects=None,groups=ramendr.openshift.io,resources=volumereplicationgroups,verbs=create;update,versions=v1alpha1,name=mvolumereplicationgroup.ramendr.openshift.io,admissionReviewVersions=v1
+// +kubebuilder:webhook:path=/validate-ramendr-openshift-io-v1alpha1-volumereplicationgroup,mutating=false,failurePolicy=fail,sideEffects=None,groups=ramendr.openshift.io,resources=volumereplicationgroups,verbs=create;update,versions=v1alpha1,name=vvolumereplicationgroup.ramendr.openshift.io,admissionReviewVersions=v1
+
+var (
+	_ webhook.CustomDefaulter = &VolumeReplicationGroupWebhook{}
+	_ webhook.CustomValidator = &VolumeReplicationGroupWebhook{}
+)
+
+// SetupWebhookWithManager registers the webhook with the manager's webhook server
+func (w *VolumeReplicationGroupWebhook) SetupWebhookWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewWebhookManagedBy(mgr).
+		For(&ramendrv1alpha1.VolumeReplicationGroup{}).
+		WithDefaulter(w).
+		WithValidator(w).
+		Complete()
+}
+
+// Default removes duplicate S3 profiles, which would otherwise each be uploaded to and downloaded from
+func (w *VolumeReplicationGroupWebhook) Default(_ context.Context, obj runtime.Object) error {
+	vrg, ok := obj.(*ramendrv1alpha1.VolumeReplicationGroup)
+	if !ok {
+		return fmt.Errorf("expected a VolumeReplicationGroup but got a %T", obj)
+	}
+
+	if len(vrg.Spec.S3Profiles) == 0 {
+		return nil
+	}
+
+	seen := make(map[string]struct{}, len(vrg.Spec.S3Profiles))
+	s3Profiles := make([]string, 0, len(vrg.Spec.S3Profiles))
+
+	for _, s3ProfileName := range vrg.Spec.S3Profiles {
+		if _, ok := seen[s3ProfileName]; ok {
+			continue
+		}
+
+		seen[s3ProfileName] = struct{}{}
+		s3Profiles = append(s3Profiles, s3ProfileName)
+	}
+
+	vrg.Spec.S3Profiles = s3Profiles
+
+	return nil
+}
+
+func (w *VolumeReplicationGroupWebhook) ValidateCreate(_ context.Context, obj runtime.Object,
+) (admission.Warnings, error) {
+	vrg, ok := obj.(*ramendrv1alpha1.VolumeReplicationGroup)
+	if !ok {
+		return nil, fmt.Errorf("expected a VolumeReplicationGroup but got a %T", obj)
+	}
+
+	return nil, vrgInvalid(vrg, vrgSpecValidate(vrg))
+}
+
+func (w *VolumeReplicationGroupWebhook) ValidateUpdate(_ context.Context, oldObj, newObj runtime.Object,
+) (admission.Warnings, error) {
+	oldVRG, ok := oldObj.(*ramendrv1alpha1.VolumeReplicationGroup)
+	if !ok {
+		return nil, fmt.Errorf("expected a VolumeReplicationGroup but got a %T", oldObj)
+	}
+
+	vrg, ok := newObj.(*ramendrv1alpha1.VolumeReplicationGroup)
+	if !ok {
+		return nil, fmt.Errorf("expected a VolumeReplicationGroup but got a %T", newObj)
+	}
+
+	// A VRG being deleted must remain updatable, for its finalizers to be removed
+	if rmnutil.ResourceIsDeleted(vrg) {
+		return nil, nil
+	}
+
+	allErrs := vrgSpecValidate(vrg)
+	allErrs = append(allErrs, vrgSpecUpdateValidate(oldVRG, vrg)...)
+
+	return nil, vrgInvalid(vrg, allErrs)
+}
+
+func (w *VolumeReplicationGroupWebhook) ValidateDelete(_ context.Context, _ runtime.Object,
+) (admission.Warnings, error) {
+	return nil, nil
+}
+
+func vrgInvalid(vrg *ramendrv1alpha1.VolumeReplicationGroup, allErrs field.ErrorList) error {
+	if len(allErrs) == 0 {
+		return nil
+	}
+
+	return k8serrors.NewInvalid(ramendrv1alpha1.GroupVersion.WithKind("VolumeReplicationGroup").GroupKind(),
+		vrg.GetName(), allErrs)
+}
+
+// vrgSpecValidate checks the rules of validateVRGState and validateVRGMode, the action, and the combinations of
+// replication state and action related fields that a VRG does not support
+func vrgSpecValidate(vrg *ramendrv1alpha1.VolumeReplicationGroup) field.ErrorList {
+	allErrs := field.ErrorList{}
+	specPath := field.NewPath("spec")
+
+	switch vrg.Spec.ReplicationState {
+	case ramendrv1alpha1.Primary, ramendrv1alpha1.Secondary:
+	default:
+		allErrs = append(allErrs, field.NotSupported(specPath.Child("replicationState"), vrg.Spec.ReplicationState,
+			[]string{string(ramendrv1alpha1.Primary), string(ramendrv1alpha1.Secondary)}))
+	}
+
+	// An action is not restricted to a replication state, as a DRPC sets the action it runs on each of its VRGs,
+	// whether primary or secondary, and the final sync flags on the primary VRG of a relocation whatever its
+	// previous action
+	switch vrg.Spec.Action {
+	case "", ramendrv1alpha1.VRGActionFailover, ramendrv1alpha1.VRGActionRelocate:
+	default:
+		allErrs = append(allErrs, field.NotSupported(specPath.Child("action"), vrg.Spec.Action,
+			[]string{string(ramendrv1alpha1.VRGActionFailover), string(ramendrv1alpha1.VRGActionRelocate)}))
+	}
+
+	switch {
+	case vrg.Spec.Async == nil && vrg.Spec.Sync == nil:
+		allErrs = append(allErrs, field.Required(specPath.Child("async"), "one of async or sync mode is required"))
+	case vrg.Spec.Async != nil && vrg.Spec.Sync != nil:
+		allErrs = append(allErrs, field.Forbidden(specPath.Child("sync"), "async and sync modes are exclusive"))
+	}
+
+	if len(vrg.Spec.S3Profiles) == 0 {
+		allErrs = append(allErrs, field.Required(specPath.Child("s3Profiles"), "at least one S3 profile is required"))
+	}
+
+	if vrg.Spec.ReplicationState != ramendrv1alpha1.Primary {
+		if vrg.Spec.PrepareForFinalSync {
+			allErrs = append(allErrs, field.Forbidden(specPath.Child("prepareForFinalSync"),
+				"is only supported for a primary VRG"))
+		}
+
+		if vrg.Spec.RunFinalSync {
+			allErrs = append(allErrs, field.Forbidden(specPath.Child("runFinalSync"),
+				"is only supported for a primary VRG"))
+		}
+	}
+
+	if vrg.Spec.PrepareForFinalSync && vrg.Spec.RunFinalSync {
+		allErrs = append(allErrs, field.Forbidden(specPath.Child("runFinalSync"),
+			"may not be set with prepareForFinalSync"))
+	}
+
+	if vrg.Spec.TestFailover != nil && vrg.Spec.ReplicationState != ramendrv1alpha1.Secondary {
+		allErrs = append(allErrs, field.Forbidden(specPath.Child("testFailover"),
+			"is only supported for a secondary VRG"))
+	}
+
+	return allErrs
+}
+
+// vrgSpecUpdateValidate checks that the replication mode of a VRG is not changed. Its PVC selector may change, as
+// the hub updates it from the DRPC's, and the PVCs it no longer selects are unprotected.
+func vrgSpecUpdateValidate(oldVRG, vrg *ramendrv1alpha1.VolumeReplicationGroup) field.ErrorList {
+	allErrs := field.ErrorList{}
+	specPath := field.NewPath("spec")
+
+	if (oldVRG.Spec.Async == nil) != (vrg.Spec.Async == nil) || (oldVRG.Spec.Sync == nil) != (vrg.Spec.Sync == nil) {
+		allErrs = append(allErrs, field.Forbidden(specPath.Child("async"), "replication mode is immutable"))
+	}
+
+	return allErrs
+}
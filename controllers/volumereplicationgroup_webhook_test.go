@@ -0,0 +1,140 @@
+// SPDX-FileCopyrightText: The RamenDR authors
+// SPDX-License-Identifier: Apache-2.0
+
+package controllers_test
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	ramen "github.com/ramendr/ramen/api/v1alpha1"
+	"github.com/ramendr/ramen/controllers"
+)
+
+var _ = Describe("VolumeReplicationGroupWebhook", func() {
+	var vrgWebhook *controllers.VolumeReplicationGroupWebhook
+
+	vrgValid := func() *ramen.VolumeReplicationGroup {
+		return &ramen.VolumeReplicationGroup{
+			ObjectMeta: metav1.ObjectMeta{Name: "vrg", Namespace: "vrg-ns"},
+			Spec: ramen.VolumeReplicationGroupSpec{
+				PVCSelector:      metav1.LabelSelector{MatchLabels: map[string]string{"app": "a"}},
+				ReplicationState: ramen.Primary,
+				S3Profiles:       []string{"s3profile1"},
+				Async:            &ramen.VRGAsyncSpec{SchedulingInterval: "1m"},
+			},
+		}
+	}
+
+	BeforeEach(func() {
+		vrgWebhook = &controllers.VolumeReplicationGroupWebhook{}
+	})
+
+	Context("Default", func() {
+		It("removes duplicate S3 profiles and preserves their order", func() {
+			vrg := vrgValid()
+			vrg.Spec.S3Profiles = []string{"s3profile2", "s3profile1", "s3profile2"}
+			Expect(vrgWebhook.Default(context.TODO(), vrg)).To(Succeed())
+			Expect(vrg.Spec.S3Profiles).To(Equal([]string{"s3profile2", "s3profile1"}))
+		})
+	})
+
+	DescribeTable("ValidateCreate",
+		func(mutate func(*ramen.VolumeReplicationGroup), field string) {
+			vrg := vrgValid()
+			mutate(vrg)
+
+			_, err := vrgWebhook.ValidateCreate(context.TODO(), vrg)
+			if field == "" {
+				Expect(err).NotTo(HaveOccurred())
+
+				return
+			}
+
+			Expect(k8serrors.IsInvalid(err)).To(BeTrue(), "%v", err)
+			Expect(err.Error()).To(ContainSubstring(field))
+		},
+		Entry("accepts a valid VRG", func(vrg *ramen.VolumeReplicationGroup) {}, ""),
+		Entry("rejects an unknown replication state", func(vrg *ramen.VolumeReplicationGroup) {
+			vrg.Spec.ReplicationState = "tertiary"
+		}, "spec.replicationState"),
+		Entry("rejects neither async nor sync mode", func(vrg *ramen.VolumeReplicationGroup) {
+			vrg.Spec.Async = nil
+		}, "spec.async"),
+		Entry("rejects both async and sync modes", func(vrg *ramen.VolumeReplicationGroup) {
+			vrg.Spec.Sync = &ramen.VRGSyncSpec{}
+		}, "spec.sync"),
+		Entry("rejects empty S3 profiles", func(vrg *ramen.VolumeReplicationGroup) {
+			vrg.Spec.S3Profiles = nil
+		}, "spec.s3Profiles"),
+		Entry("rejects an unknown action", func(vrg *ramen.VolumeReplicationGroup) {
+			vrg.Spec.Action = "Migrate"
+		}, "spec.action"),
+		Entry("accepts any action on a secondary", func(vrg *ramen.VolumeReplicationGroup) {
+			vrg.Spec.ReplicationState = ramen.Secondary
+			vrg.Spec.Action = ramen.VRGActionFailover
+		}, ""),
+		Entry("accepts a final sync of a primary that failed over", func(vrg *ramen.VolumeReplicationGroup) {
+			vrg.Spec.Action = ramen.VRGActionFailover
+			vrg.Spec.PrepareForFinalSync = true
+		}, ""),
+		Entry("rejects prepareForFinalSync on a secondary", func(vrg *ramen.VolumeReplicationGroup) {
+			vrg.Spec.ReplicationState = ramen.Secondary
+			vrg.Spec.PrepareForFinalSync = true
+		}, "spec.prepareForFinalSync"),
+		Entry("rejects runFinalSync on a secondary", func(vrg *ramen.VolumeReplicationGroup) {
+			vrg.Spec.ReplicationState = ramen.Secondary
+			vrg.Spec.RunFinalSync = true
+		}, "spec.runFinalSync"),
+		Entry("rejects both prepareForFinalSync and runFinalSync", func(vrg *ramen.VolumeReplicationGroup) {
+			vrg.Spec.PrepareForFinalSync = true
+			vrg.Spec.RunFinalSync = true
+		}, "spec.runFinalSync"),
+		Entry("rejects testFailover on a primary", func(vrg *ramen.VolumeReplicationGroup) {
+			vrg.Spec.TestFailover = &ramen.TestFailoverSpec{}
+		}, "spec.testFailover"),
+	)
+
+	DescribeTable("ValidateUpdate",
+		func(mutate func(*ramen.VolumeReplicationGroup), field string) {
+			oldVRG := vrgValid()
+			vrg := vrgValid()
+			mutate(vrg)
+
+			_, err := vrgWebhook.ValidateUpdate(context.TODO(), oldVRG, vrg)
+			if field == "" {
+				Expect(err).NotTo(HaveOccurred())
+
+				return
+			}
+
+			Expect(k8serrors.IsInvalid(err)).To(BeTrue(), "%v", err)
+			Expect(err.Error()).To(ContainSubstring(field))
+		},
+		Entry("accepts a change of replication state", func(vrg *ramen.VolumeReplicationGroup) {
+			vrg.Spec.ReplicationState = ramen.Secondary
+			vrg.Spec.Action = ramen.VRGActionRelocate
+		}, ""),
+		Entry("accepts a change of PVC selector", func(vrg *ramen.VolumeReplicationGroup) {
+			vrg.Spec.PVCSelector.MatchLabels["app"] = "b"
+		}, ""),
+		Entry("rejects a change of replication mode", func(vrg *ramen.VolumeReplicationGroup) {
+			vrg.Spec.Async = nil
+			vrg.Spec.Sync = &ramen.VRGSyncSpec{}
+		}, "spec.async"),
+		Entry("rejects a rule of ValidateCreate", func(vrg *ramen.VolumeReplicationGroup) {
+			vrg.Spec.S3Profiles = []string{}
+		}, "spec.s3Profiles"),
+		Entry("accepts any change to a VRG being deleted", func(vrg *ramen.VolumeReplicationGroup) {
+			now := metav1.Now()
+			vrg.DeletionTimestamp = &now
+			vrg.Spec.Async = nil
+			vrg.Spec.Sync = &ramen.VRGSyncSpec{}
+		}, ""),
+	)
+})
@@ -0,0 +1,421 @@
+// SPDX-FileCopyrightText: The RamenDR authors
+// SPDX-License-Identifier: Apache-2.0
+
+package controllers
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	ramendrv1alpha1 "github.com/ramendr/ramen/api/v1alpha1"
+	"github.com/ramendr/ramen/controllers/kubeobjects"
+	rmnutil "github.com/ramendr/ramen/controllers/util"
+)
+
+const (
+	consistentSyncTimeoutDefault = 10 * time.Minute
+	consistentSyncPollInterval   = 10 * time.Second
+
+	// Hook requests are labeled apart from kube objects capture requests, which are selected by owner labels
+	consistentSyncLabelOwnerNamespaceName = "ramendr.openshift.io/consistent-sync-owner-namespace-name"
+	consistentSyncLabelOwnerName          = "ramendr.openshift.io/consistent-sync-owner-name"
+)
+
+func consistentSyncLabels(vrg *ramendrv1alpha1.VolumeReplicationGroup) map[string]string {
+	return map[string]string{
+		consistentSyncLabelOwnerNamespaceName: vrg.Namespace,
+		consistentSyncLabelOwnerName:          vrg.Name,
+	}
+}
+
+func consistentSyncRequestName(vrg *ramendrv1alpha1.VolumeReplicationGroup, phase ramendrv1alpha1.ConsistentSyncPhase,
+	hookNumber int,
+) string {
+	return vrg.Namespace + "--" + vrg.Name + "--consistent-sync--" + strings.ToLower(string(phase)) + "--" +
+		strconv.Itoa(hookNumber)
+}
+
+func (v *VRGInstance) consistentSyncS3KeyPrefix() string {
+	return s3PathNamePrefix(v.instance.Namespace, v.instance.Name) + "consistent-sync/"
+}
+
+// consistentSyncTrigger is the manual trigger of the ReplicationSources syncing for a consistent sync request
+func consistentSyncTrigger(status *ramendrv1alpha1.ConsistentSyncStatus) string {
+	return "vrg-consistent-sync-" + status.SyncStartTime.UTC().Format("20060102150405")
+}
+
+// consistentSyncTriggerSet requests the ReplicationSources to sync immediately while a consistent sync request is
+// waiting for the volumes to sync
+func (v *VRGInstance) consistentSyncTriggerSet() {
+	status := v.instance.Status.ConsistentSync
+	if status == nil || status.Phase != ramendrv1alpha1.ConsistentSyncPhaseSyncing {
+		return
+	}
+
+	v.volSyncHandler.SetManualSyncTrigger(consistentSyncTrigger(status))
+}
+
+// reconcileConsistentSync progresses the consistent sync request of a primary VRG: it freezes the application,
+// waits for each protected volume to sync, and unfreezes the application. The application is unfrozen even if
+// freezing it or syncing its volumes fails.
+func (v *VRGInstance) reconcileConsistentSync(result *ctrl.Result) {
+	vrg := v.instance
+	spec := vrg.Spec.ConsistentSync
+
+	if spec == nil {
+		return
+	}
+
+	status := vrg.Status.ConsistentSync
+	if status == nil || status.RequestID != spec.RequestID {
+		if vrg.Spec.PrepareForFinalSync || vrg.Spec.RunFinalSync {
+			v.log.Info("Consistent sync deferred, as VRG is orchestrating final sync", "request", spec.RequestID)
+
+			return
+		}
+
+		if err := v.consistentSyncRequestsDelete(); err != nil {
+			v.log.Info("Consistent sync previous hook requests delete failed", "error", err)
+
+			result.Requeue = true
+
+			return
+		}
+
+		status = &ramendrv1alpha1.ConsistentSyncStatus{
+			RequestID: spec.RequestID,
+			Phase:     ramendrv1alpha1.ConsistentSyncPhaseFreezing,
+		}
+		vrg.Status.ConsistentSync = status
+
+		if err := consistentSyncVolRepIntervalsVerify(vrg, consistentSyncTimeout(spec)); err != nil {
+			v.consistentSyncFail(status, err, v.log.WithValues("request", spec.RequestID))
+
+			return
+		}
+
+		v.log.Info("Consistent sync started", "request", spec.RequestID)
+	}
+
+	log := v.log.WithValues("request", status.RequestID, "phase", status.Phase)
+
+	switch status.Phase {
+	case ramendrv1alpha1.ConsistentSyncPhaseFreezing:
+		v.consistentSyncFreeze(result, status, log)
+	case ramendrv1alpha1.ConsistentSyncPhaseSyncing:
+		v.consistentSyncWait(result, status, spec, log)
+	case ramendrv1alpha1.ConsistentSyncPhaseUnfreezing:
+		v.consistentSyncUnfreeze(result, status, log)
+	case ramendrv1alpha1.ConsistentSyncPhaseCompleted, ramendrv1alpha1.ConsistentSyncPhaseFailed:
+	}
+}
+
+func (v *VRGInstance) consistentSyncFreeze(result *ctrl.Result, status *ramendrv1alpha1.ConsistentSyncStatus,
+	log logr.Logger,
+) {
+	if len(v.instance.Spec.ConsistentSync.FreezeHooks) != len(v.recipeElements.ConsistentSyncFreezeHooks) {
+		v.consistentSyncAbort(result, status,
+			errors.New("freeze hooks require kube object protection with a recipe that defines them"), log)
+
+		return
+	}
+
+	completed, err := v.consistentSyncHooksRun(v.recipeElements.ConsistentSyncFreezeHooks, status, log)
+	if err != nil {
+		v.consistentSyncAbort(result, status, fmt.Errorf("freeze failed: %w", err), log)
+
+		return
+	}
+
+	if !completed {
+		delaySetIfLess(result, consistentSyncPollInterval, log)
+
+		return
+	}
+
+	status.Phase = ramendrv1alpha1.ConsistentSyncPhaseSyncing
+	status.HooksCompleted = 0
+	status.SyncStartTime = &metav1.Time{Time: time.Now()}
+
+	log.Info("Consistent sync application frozen, syncing volumes", "syncStartTime", status.SyncStartTime)
+	delaySetMinimum(result)
+}
+
+func consistentSyncTimeout(spec *ramendrv1alpha1.ConsistentSyncSpec) time.Duration {
+	if spec.Timeout != nil {
+		return spec.Timeout.Duration
+	}
+
+	return consistentSyncTimeoutDefault
+}
+
+// consistentSyncVolRepIntervalsVerify returns an error if an async VolRep volume is not scheduled to sync within
+// the timeout, as its VolumeReplication cannot be requested to sync and the request would otherwise time out with
+// the application frozen
+func consistentSyncVolRepIntervalsVerify(vrg *ramendrv1alpha1.VolumeReplicationGroup, timeout time.Duration) error {
+	if vrg.Spec.Async == nil {
+		return nil
+	}
+
+	for _, protectedPVC := range vrg.Status.ProtectedPVCs {
+		if protectedPVC.ProtectedByVolSync {
+			continue
+		}
+
+		schedulingInterval := protectedPVC.SchedulingInterval
+		if schedulingInterval == "" {
+			schedulingInterval = vrg.Spec.Async.SchedulingInterval
+		}
+
+		seconds, err := rmnutil.SchedulingIntervalSeconds(schedulingInterval)
+		if err != nil {
+			return fmt.Errorf("PVC %s/%s scheduling interval %q is invalid: %w",
+				protectedPVC.Namespace, protectedPVC.Name, schedulingInterval, err)
+		}
+
+		if interval := time.Duration(seconds) * time.Second; interval > timeout {
+			return fmt.Errorf("PVC %s/%s scheduling interval %v exceeds the timeout %v",
+				protectedPVC.Namespace, protectedPVC.Name, interval, timeout)
+		}
+	}
+
+	return nil
+}
+
+// consistentSyncWait waits for each protected volume to sync: a VolSync volume for the manual trigger of its
+// ReplicationSource, and an async VolRep volume for the next scheduled sync, as a VolumeReplication cannot be
+// requested to sync. Sync mode volumes are mirrored synchronously.
+func (v *VRGInstance) consistentSyncWait(result *ctrl.Result, status *ramendrv1alpha1.ConsistentSyncStatus,
+	spec *ramendrv1alpha1.ConsistentSyncSpec, log logr.Logger,
+) {
+	pending := []string{}
+	trigger := consistentSyncTrigger(status)
+
+	for _, protectedPVC := range v.instance.Status.ProtectedPVCs {
+		synced, err := v.consistentSyncPVCSynced(protectedPVC, status, trigger)
+		if err != nil {
+			log.Info("Consistent sync PVC sync status check failed", "pvc", protectedPVC.Name, "error", err)
+		}
+
+		if !synced {
+			pending = append(pending, protectedPVC.Namespace+"/"+protectedPVC.Name)
+		}
+	}
+
+	if len(pending) == 0 {
+		log.Info("Consistent sync volumes synced")
+
+		status.Phase = ramendrv1alpha1.ConsistentSyncPhaseUnfreezing
+		status.HooksCompleted = 0
+
+		delaySetMinimum(result)
+
+		return
+	}
+
+	timeout := consistentSyncTimeout(spec)
+	if time.Since(status.SyncStartTime.Time) > timeout {
+		v.consistentSyncAbort(result, status,
+			fmt.Errorf("timed out after %v waiting for PVCs %v to sync", timeout, pending), log)
+
+		return
+	}
+
+	log.Info("Consistent sync volumes syncing", "pending", pending)
+	delaySetIfLess(result, consistentSyncPollInterval, log)
+}
+
+func (v *VRGInstance) consistentSyncPVCSynced(protectedPVC ramendrv1alpha1.ProtectedPVC,
+	status *ramendrv1alpha1.ConsistentSyncStatus, trigger string,
+) (bool, error) {
+	switch {
+	case protectedPVC.ProtectedByVolSync:
+		return v.volSyncHandler.IsRSManualSyncComplete(protectedPVC.Name, protectedPVC.Namespace, trigger)
+	case v.instance.Spec.Sync != nil:
+		return true, nil
+	default:
+		return protectedPVC.LastSyncTime != nil && !protectedPVC.LastSyncTime.Before(status.SyncStartTime), nil
+	}
+}
+
+func (v *VRGInstance) consistentSyncUnfreeze(result *ctrl.Result, status *ramendrv1alpha1.ConsistentSyncStatus,
+	log logr.Logger,
+) {
+	completed, err := v.consistentSyncHooksRun(v.recipeElements.ConsistentSyncUnfreezeHooks, status, log)
+	if err != nil {
+		// The remaining unfreeze hooks are skipped, as the application state is unknown
+		status.Message = strings.TrimPrefix(status.Message+"; ", "; ") + fmt.Sprintf("unfreeze failed: %v", err)
+		completed = true
+	}
+
+	if !completed {
+		delaySetIfLess(result, consistentSyncPollInterval, log)
+
+		return
+	}
+
+	if err := v.consistentSyncRequestsDelete(); err != nil {
+		log.Info("Consistent sync hook requests delete failed", "error", err)
+	}
+
+	if status.Message != "" {
+		v.consistentSyncFail(status, errors.New(status.Message), log)
+
+		return
+	}
+
+	status.Phase = ramendrv1alpha1.ConsistentSyncPhaseCompleted
+	v.instance.Status.LastConsistentSyncTime = status.SyncStartTime
+
+	log.Info("Consistent sync completed", "lastConsistentSyncTime", status.SyncStartTime)
+	rmnutil.ReportIfNotPresent(v.reconciler.eventRecorder, v.instance, corev1.EventTypeNormal,
+		rmnutil.EventReasonConsistentSyncCompleted,
+		fmt.Sprintf("Consistent sync %s completed at %s", status.RequestID,
+			status.SyncStartTime.Format(time.RFC3339)))
+}
+
+// consistentSyncFail fails a consistent sync request whose application is not frozen
+func (v *VRGInstance) consistentSyncFail(status *ramendrv1alpha1.ConsistentSyncStatus, err error, log logr.Logger) {
+	status.Phase = ramendrv1alpha1.ConsistentSyncPhaseFailed
+	status.Message = err.Error()
+
+	log.Info("Consistent sync failed", "message", status.Message)
+	rmnutil.ReportIfNotPresent(v.reconciler.eventRecorder, v.instance, corev1.EventTypeWarning,
+		rmnutil.EventReasonConsistentSyncFailed,
+		fmt.Sprintf("Consistent sync %s failed: %s", status.RequestID, status.Message))
+}
+
+// reconcileConsistentSyncAbort aborts the consistent sync request of a VRG that is no longer primary, or is
+// deleted, and unfreezes its application. It returns true until the application is unfrozen.
+func (v *VRGInstance) reconcileConsistentSyncAbort(result *ctrl.Result, reason string) bool {
+	status := v.instance.Status.ConsistentSync
+	if status == nil {
+		return false
+	}
+
+	log := v.log.WithValues("request", status.RequestID, "phase", status.Phase)
+
+	switch status.Phase {
+	case ramendrv1alpha1.ConsistentSyncPhaseFreezing, ramendrv1alpha1.ConsistentSyncPhaseSyncing:
+		v.consistentSyncAbort(result, status, errors.New(reason), log)
+	case ramendrv1alpha1.ConsistentSyncPhaseUnfreezing:
+	case ramendrv1alpha1.ConsistentSyncPhaseCompleted, ramendrv1alpha1.ConsistentSyncPhaseFailed:
+		return false
+	}
+
+	v.consistentSyncUnfreeze(result, status, log)
+
+	return status.Phase == ramendrv1alpha1.ConsistentSyncPhaseUnfreezing
+}
+
+// consistentSyncAbort fails a consistent sync request once the application is unfrozen
+func (v *VRGInstance) consistentSyncAbort(result *ctrl.Result, status *ramendrv1alpha1.ConsistentSyncStatus,
+	err error, log logr.Logger,
+) {
+	log.Info("Consistent sync aborted, unfreezing application", "error", err)
+
+	status.Phase = ramendrv1alpha1.ConsistentSyncPhaseUnfreezing
+	status.HooksCompleted = 0
+	status.Message = err.Error()
+
+	delaySetMinimum(result)
+}
+
+// consistentSyncHooksRun runs the hooks of the current phase in order, one at a time, each as a kube objects
+// protect request whose exec hooks run in the selected pods. It returns true once all of them completed.
+func (v *VRGInstance) consistentSyncHooksRun(hooks []kubeobjects.CaptureSpec,
+	status *ramendrv1alpha1.ConsistentSyncStatus, log logr.Logger,
+) (bool, error) {
+	if status.HooksCompleted >= len(hooks) {
+		return true, nil
+	}
+
+	if len(v.s3StoreAccessors) == 0 {
+		return false, errors.New("no S3 store available for hook requests")
+	}
+
+	veleroNamespaceName := v.veleroNamespaceName()
+	labels := consistentSyncLabels(v.instance)
+
+	requests, err := v.reconciler.kubeObjects.ProtectRequestsGet(
+		v.ctx, v.reconciler.APIReader, veleroNamespaceName, labels)
+	if err != nil {
+		return false, fmt.Errorf("hook requests query error: %w", err)
+	}
+
+	requestsByName := kubeobjects.RequestsMapKeyedByName(requests)
+
+	for ; status.HooksCompleted < len(hooks); status.HooksCompleted++ {
+		hook := hooks[status.HooksCompleted]
+		requestName := consistentSyncRequestName(v.instance, status.Phase, status.HooksCompleted)
+		log1 := log.WithValues("hook", hook.Name, "request", requestName)
+
+		request, ok := requestsByName[requestName]
+		if !ok {
+			s3StoreAccessor := v.s3StoreAccessors[0]
+
+			if _, err := v.reconciler.kubeObjects.ProtectRequestCreate(
+				v.ctx, v.reconciler.Client, v.log,
+				s3StoreAccessor.S3CompatibleEndpoint, s3StoreAccessor.S3Bucket, s3StoreAccessor.S3Region,
+				v.consistentSyncS3KeyPrefix(), s3StoreAccessor.VeleroNamespaceSecretKeyRef,
+				s3StoreAccessor.CACertificates,
+				hook.Spec, veleroNamespaceName, requestName,
+				labels, nil,
+			); err != nil {
+				return false, fmt.Errorf("hook %s request submit error: %w", hook.Name, err)
+			}
+
+			log1.Info("Consistent sync hook request submitted")
+
+			return false, nil
+		}
+
+		err := request.Status(v.log)
+		if errors.Is(err, kubeobjects.RequestProcessingError{}) {
+			log1.Info("Consistent sync hook running", "state", err.Error())
+
+			return false, nil
+		}
+
+		if deallocateErr := request.Deallocate(v.ctx, v.reconciler.Client, v.log); deallocateErr != nil {
+			log1.Info("Consistent sync hook request deallocate error", "error", deallocateErr)
+		}
+
+		if err != nil {
+			return false, fmt.Errorf("hook %s error: %w", hook.Name, err)
+		}
+
+		log1.Info("Consistent sync hook completed", "start", request.StartTime(), "end", request.EndTime())
+	}
+
+	return true, nil
+}
+
+// consistentSyncRequestsDelete deletes the hook requests of consistent syncs, and the kube objects they captured
+func (v *VRGInstance) consistentSyncRequestsDelete() error {
+	if v.instance.Spec.KubeObjectProtection == nil {
+		return nil
+	}
+
+	if err := v.reconciler.kubeObjects.ProtectRequestsDelete(
+		v.ctx, v.reconciler.Client, v.veleroNamespaceName(), consistentSyncLabels(v.instance),
+	); err != nil {
+		return err
+	}
+
+	for _, s3StoreAccessor := range v.s3StoreAccessors {
+		if err := s3StoreAccessor.ObjectStorer.DeleteObjectsWithKeyPrefix(v.consistentSyncS3KeyPrefix()); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,118 @@
+// SPDX-FileCopyrightText: The RamenDR authors
+// SPDX-License-Identifier: Apache-2.0
+
+// white box testing desired for consistent sync phase transitions
+package controllers //nolint: testpackage
+
+import (
+	"time"
+
+	"github.com/go-logr/logr"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	ramen "github.com/ramendr/ramen/api/v1alpha1"
+	rmnutil "github.com/ramendr/ramen/controllers/util"
+)
+
+var _ = Describe("VRG_ConsistentSync", func() {
+	Context("consistentSyncTimeout", func() {
+		It("defaults to 10 minutes", func() {
+			Expect(consistentSyncTimeout(&ramen.ConsistentSyncSpec{})).To(Equal(consistentSyncTimeoutDefault))
+		})
+		It("returns the requested timeout", func() {
+			Expect(consistentSyncTimeout(&ramen.ConsistentSyncSpec{Timeout: &metav1.Duration{Duration: time.Hour}})).
+				To(Equal(time.Hour))
+		})
+	})
+
+	Context("consistentSyncVolRepIntervalsVerify", func() {
+		vrg := func(schedulingInterval string, protectedPVCs ...ramen.ProtectedPVC) *ramen.VolumeReplicationGroup {
+			return &ramen.VolumeReplicationGroup{
+				Spec: ramen.VolumeReplicationGroupSpec{
+					Async: &ramen.VRGAsyncSpec{SchedulingInterval: schedulingInterval},
+				},
+				Status: ramen.VolumeReplicationGroupStatus{ProtectedPVCs: protectedPVCs},
+			}
+		}
+
+		It("accepts VolRep volumes scheduled to sync within the timeout", func() {
+			Expect(consistentSyncVolRepIntervalsVerify(vrg("5m", ramen.ProtectedPVC{Name: "pvc1"}),
+				10*time.Minute)).To(Succeed())
+		})
+		It("rejects a VolRep volume scheduled to sync after the timeout", func() {
+			Expect(consistentSyncVolRepIntervalsVerify(vrg("1h", ramen.ProtectedPVC{Name: "pvc1"}),
+				10*time.Minute)).To(MatchError(ContainSubstring("pvc1")))
+		})
+		It("prefers the scheduling interval of a PVC to the VRG's", func() {
+			Expect(consistentSyncVolRepIntervalsVerify(vrg("5m",
+				ramen.ProtectedPVC{Name: "pvc1", SchedulingInterval: "1h"}), 10*time.Minute)).
+				To(MatchError(ContainSubstring("pvc1")))
+			Expect(consistentSyncVolRepIntervalsVerify(vrg("1h",
+				ramen.ProtectedPVC{Name: "pvc1", SchedulingInterval: "5m"}), 10*time.Minute)).To(Succeed())
+		})
+		It("ignores VolSync volumes, which are triggered to sync", func() {
+			Expect(consistentSyncVolRepIntervalsVerify(vrg("1h",
+				ramen.ProtectedPVC{Name: "pvc1", ProtectedByVolSync: true}), 10*time.Minute)).To(Succeed())
+		})
+		It("ignores sync mode volumes, which are mirrored synchronously", func() {
+			syncVRG := vrg("", ramen.ProtectedPVC{Name: "pvc1"})
+			syncVRG.Spec.Async = nil
+			syncVRG.Spec.Sync = &ramen.VRGSyncSpec{}
+			Expect(consistentSyncVolRepIntervalsVerify(syncVRG, 10*time.Minute)).To(Succeed())
+		})
+	})
+
+	Context("reconcileConsistentSyncAbort", func() {
+		vrgInstance := func(phase ramen.ConsistentSyncPhase) *VRGInstance {
+			return &VRGInstance{
+				reconciler: &VolumeReplicationGroupReconciler{
+					eventRecorder: rmnutil.NewEventReporter(record.NewFakeRecorder(10)),
+				},
+				instance: &ramen.VolumeReplicationGroup{
+					ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: "vrg"},
+					Status: ramen.VolumeReplicationGroupStatus{
+						ConsistentSync: &ramen.ConsistentSyncStatus{
+							RequestID:      "1",
+							Phase:          phase,
+							HooksCompleted: 1,
+							SyncStartTime:  &metav1.Time{Time: time.Now()},
+						},
+					},
+				},
+				log: logr.Discard(),
+			}
+		}
+
+		It("does nothing without a consistent sync request", func() {
+			v := vrgInstance(ramen.ConsistentSyncPhaseFreezing)
+			v.instance.Status.ConsistentSync = nil
+			Expect(v.reconcileConsistentSyncAbort(&ctrl.Result{}, "aborted")).To(BeFalse())
+		})
+		It("unfreezes the application and fails a request that is freezing or syncing", func() {
+			for _, phase := range []ramen.ConsistentSyncPhase{
+				ramen.ConsistentSyncPhaseFreezing, ramen.ConsistentSyncPhaseSyncing,
+			} {
+				v := vrgInstance(phase)
+				Expect(v.reconcileConsistentSyncAbort(&ctrl.Result{}, "aborted")).To(BeFalse())
+				Expect(v.instance.Status.ConsistentSync.Phase).To(Equal(ramen.ConsistentSyncPhaseFailed))
+				Expect(v.instance.Status.ConsistentSync.Message).To(Equal("aborted"))
+				Expect(v.instance.Status.LastConsistentSyncTime).To(BeNil())
+			}
+		})
+		It("leaves a completed or failed request as is", func() {
+			for _, phase := range []ramen.ConsistentSyncPhase{
+				ramen.ConsistentSyncPhaseCompleted, ramen.ConsistentSyncPhaseFailed,
+			} {
+				v := vrgInstance(phase)
+				Expect(v.reconcileConsistentSyncAbort(&ctrl.Result{}, "aborted")).To(BeFalse())
+				Expect(v.instance.Status.ConsistentSync.Phase).To(Equal(phase))
+				Expect(v.instance.Status.ConsistentSync.Message).To(BeEmpty())
+			}
+		})
+	})
+})
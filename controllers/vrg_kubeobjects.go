@@ -448,7 +448,7 @@ func (v *VRGInstance) kubeObjectsCaptureStatus(status metav1.ConditionStatus, re
 }
 
 func (v *VRGInstance) kubeObjectsRecover(result *ctrl.Result,
-	s3StoreProfile ramen.S3StoreProfile, objectStorer ObjectStorer,
+	s3StoreProfile ramen.S3StoreProfile, objectStorer ObjectStorer, namespaceMapping map[string]string,
 ) error {
 	if v.kubeObjectProtectionDisabled("recovery") {
 		return nil
@@ -504,7 +504,7 @@ func (v *VRGInstance) kubeObjectsRecover(result *ctrl.Result,
 		sourceVrgNamespaceName, sourceVrgName, captureToRecoverFromIdentifier,
 		kubeobjects.RequestsMapKeyedByName(captureRequestsStruct),
 		kubeobjects.RequestsMapKeyedByName(recoverRequestsStruct),
-		veleroNamespaceName, labels, log,
+		veleroNamespaceName, labels, namespaceMapping, log,
 	)
 }
 
@@ -584,9 +584,16 @@ func (v *VRGInstance) kubeObjectsRecoveryStartOrResume(
 	sourceVrgNamespaceName, sourceVrgName string,
 	captureToRecoverFromIdentifier *ramen.KubeObjectsCaptureIdentifier,
 	captureRequests, recoverRequests map[string]kubeobjects.Request,
-	veleroNamespaceName string, labels map[string]string, log logr.Logger,
+	veleroNamespaceName string, labels map[string]string, namespaceMapping map[string]string, log logr.Logger,
 ) error {
 	groups := v.recipeElements.RecoverWorkflow
+	if namespaceMapping != nil {
+		groups = recoverGroupsWithNamespaceMapping(groups, namespaceMapping)
+		if len(groups) == 0 {
+			return nil
+		}
+	}
+
 	requests := make([]kubeobjects.Request, len(groups))
 
 	for groupNumber, recoverGroup := range groups {
@@ -641,6 +648,27 @@ func (v *VRGInstance) kubeObjectsRecoveryStartOrResume(
 	return v.kubeObjectsRecoverRequestsDelete(result, veleroNamespaceName, labels)
 }
 
+// recoverGroupsWithNamespaceMapping returns the recover groups that restore kube objects, redirected to the
+// mapped namespaces. Groups that capture instead of restore are dropped, and cluster scoped resources are
+// excluded, so that the restored objects stay isolated in the mapped namespaces.
+func recoverGroupsWithNamespaceMapping(groups []kubeobjects.RecoverSpec, namespaceMapping map[string]string,
+) []kubeobjects.RecoverSpec {
+	mappedGroups := make([]kubeobjects.RecoverSpec, 0, len(groups))
+	includeClusterResources := false
+
+	for _, group := range groups {
+		if group.BackupName == ramen.ReservedBackupName {
+			continue
+		}
+
+		group.NamespaceMapping = namespaceMapping
+		group.IncludeClusterResources = &includeClusterResources
+		mappedGroups = append(mappedGroups, group)
+	}
+
+	return mappedGroups
+}
+
 func (v *VRGInstance) kubeObjectsRecoverRequestsDelete(
 	result *ctrl.Result, veleroNamespaceName string, labels map[string]string,
 ) error {
@@ -683,6 +711,10 @@ func (v *VRGInstance) kubeObjectsProtectionDelete(result *ctrl.Result) error {
 
 	vrg := v.instance
 
+	if err := v.consistentSyncRequestsDelete(); err != nil {
+		return err
+	}
+
 	return v.kubeObjectsRecoverRequestsDelete(
 		result,
 		v.veleroNamespaceName(),
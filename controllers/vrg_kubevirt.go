@@ -0,0 +1,555 @@
+// SPDX-FileCopyrightText: The RamenDR authors
+// SPDX-License-Identifier: Apache-2.0
+
+package controllers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	ramen "github.com/ramendr/ramen/api/v1alpha1"
+	"github.com/ramendr/ramen/controllers/kubeobjects"
+	rmnutil "github.com/ramendr/ramen/controllers/util"
+	"golang.org/x/exp/slices"
+	corev1 "k8s.io/api/core/v1"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+// KubeVirt resources are handled as unstructured objects, as Ramen does not build with the KubeVirt API.
+const (
+	VirtualMachineKind         = "VirtualMachine"
+	VirtualMachineInstanceKind = "VirtualMachineInstance"
+	DataVolumeKind             = "DataVolume"
+
+	vmCRDName = "virtualmachines.kubevirt.io"
+
+	// VM annotation whose value is the run strategy of a VM that Ramen stopped, restored once the VM may run again
+	VMRunStrategyAnnotation = "ramendr.openshift.io/vm-run-strategy"
+
+	vmRunStrategyHalted = "Halted"
+	vmRunStrategyAlways = "Always"
+
+	// PVC annotation with which CDI adopts a populated PVC as the DataVolume of the same name
+	dataVolumePrePopulatedAnnotation = "cdi.kubevirt.io/storage.prePopulated"
+
+	// Name of the kube objects capture group of the virtual machines and their DataVolumes
+	vmCaptureGroupName = "virtualmachines"
+)
+
+var (
+	kubeVirtGroupVersion = schema.GroupVersion{Group: "kubevirt.io", Version: "v1"}
+
+	// Resources of the virtual machines capture group, recovered after the other kube objects. Virtual machine
+	// instances are not captured, as KubeVirt creates them for running virtual machines.
+	vmCaptureGroupResources    = []string{"datavolumes.cdi.kubevirt.io", "virtualmachines.kubevirt.io"}
+	vmCaptureResourcesExcluded = append([]string{"virtualmachineinstances.kubevirt.io"}, vmCaptureGroupResources...)
+)
+
+func vmNew() *unstructured.Unstructured {
+	vm := &unstructured.Unstructured{}
+	vm.SetGroupVersionKind(kubeVirtGroupVersion.WithKind(VirtualMachineKind))
+
+	return vm
+}
+
+func vmListNew() *unstructured.UnstructuredList {
+	vmList := &unstructured.UnstructuredList{}
+	vmList.SetGroupVersionKind(kubeVirtGroupVersion.WithKind(VirtualMachineKind + "List"))
+
+	return vmList
+}
+
+func vmiNew() *unstructured.Unstructured {
+	vmi := &unstructured.Unstructured{}
+	vmi.SetGroupVersionKind(kubeVirtGroupVersion.WithKind(VirtualMachineInstanceKind))
+
+	return vmi
+}
+
+// addVMWatches watches virtual machines, if KubeVirt is installed, and reads them from the cache of the watch
+func (r *VolumeReplicationGroupReconciler) addVMWatches(ctrlBuilder *builder.Builder, mgr ctrl.Manager,
+) *builder.Builder {
+	installedCRD := &apiextensionsv1.CustomResourceDefinition{}
+	if err := r.APIReader.Get(context.TODO(), types.NamespacedName{Name: vmCRDName}, installedCRD); err != nil {
+		r.Log.Info("Cannot fetch KubeVirt CRD; VM protection won't work unless KubeVirt is installed",
+			"CRD", vmCRDName, "error", err)
+
+		return ctrlBuilder
+	}
+
+	r.Log.Info("KubeVirt installed; watch virtual machines")
+
+	r.vmReader = mgr.GetCache()
+
+	return ctrlBuilder.Watches(vmNew(),
+		handler.EnqueueRequestsFromMapFunc(r.vmMapFunc),
+		builder.WithPredicates(predicate.Or(
+			predicate.GenerationChangedPredicate{},
+			predicate.AnnotationChangedPredicate{},
+			predicate.LabelChangedPredicate{},
+		)),
+	)
+}
+
+// vmMapFunc returns requests for the VRGs that protect a virtual machine
+func (r *VolumeReplicationGroupReconciler) vmMapFunc(ctx context.Context, obj client.Object) []reconcile.Request {
+	log := ctrl.Log.WithName("vmmap").WithName("VolumeReplicationGroup").WithValues(
+		"vm", types.NamespacedName{Name: obj.GetName(), Namespace: obj.GetNamespace()})
+
+	vrgs := ramen.VolumeReplicationGroupList{}
+	if err := r.Client.List(ctx, &vrgs); err != nil {
+		log.Error(err, "Failed to get list of VolumeReplicationGroup resources")
+
+		return []reconcile.Request{}
+	}
+
+	_, ramenConfig, err := ConfigMapGet(ctx, r.Client)
+	if err != nil {
+		log.Error(err, "Failed to get Ramen config")
+
+		return []reconcile.Request{}
+	}
+
+	req := []reconcile.Request{}
+
+	for i := range vrgs.Items {
+		vrg := &vrgs.Items[i]
+		if vrg.Spec.VMProtection == nil {
+			continue
+		}
+
+		pvcSelector, err := GetPVCSelector(ctx, r.Client, *vrg, *ramenConfig, log)
+		if err != nil || !slices.Contains(pvcSelector.NamespaceNames, obj.GetNamespace()) {
+			continue
+		}
+
+		selector, err := vmSelector(vrg.Spec.VMProtection)
+		if err != nil || !selector.Matches(labels.Set(obj.GetLabels())) {
+			continue
+		}
+
+		log.Info("Found VolumeReplicationGroup protecting VM", "vrg", vrg.Namespace+"/"+vrg.Name)
+
+		req = append(req, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(vrg)})
+	}
+
+	return req
+}
+
+// vmSelector returns the label selector of the virtual machines that a VRG protects
+func vmSelector(vmProtection *ramen.VMProtectionSpec) (labels.Selector, error) {
+	if vmProtection.VMSelector == nil {
+		return labels.Everything(), nil
+	}
+
+	selector, err := metav1.LabelSelectorAsSelector(vmProtection.VMSelector)
+	if err != nil {
+		return nil, fmt.Errorf("error with VM label selector (%w)", err)
+	}
+
+	return selector, nil
+}
+
+// vmsListFromReader returns the virtual machines in the namespaces that are selected by a VRG VM protection spec
+func vmsListFromReader(ctx context.Context, reader client.Reader, vmProtection *ramen.VMProtectionSpec,
+	namespaceNames []string,
+) ([]unstructured.Unstructured, error) {
+	selector, err := vmSelector(vmProtection)
+	if err != nil {
+		return nil, err
+	}
+
+	vms := []unstructured.Unstructured{}
+
+	for _, namespaceName := range namespaceNames {
+		vmList := vmListNew()
+		if err := reader.List(ctx, vmList, client.InNamespace(namespaceName),
+			client.MatchingLabelsSelector{Selector: selector}); err != nil {
+			return nil, fmt.Errorf("failed to list VMs in namespace %s (%w)", namespaceName, err)
+		}
+
+		vms = append(vms, vmList.Items...)
+	}
+
+	return vms, nil
+}
+
+// vmsList returns the virtual machines, selected by the VRG VM protection spec, in the protected namespaces.
+// It returns none if VM protection is disabled, or if KubeVirt is not installed.
+func (v *VRGInstance) vmsList() ([]unstructured.Unstructured, error) {
+	vmProtection := v.instance.Spec.VMProtection
+	if vmProtection == nil {
+		return nil, nil
+	}
+
+	if v.reconciler.vmReader == nil {
+		v.log.Info("VM protection enabled, but KubeVirt is not installed")
+
+		return nil, nil
+	}
+
+	vms, err := vmsListFromReader(v.ctx, v.reconciler.vmReader, vmProtection,
+		v.recipeElements.PvcSelector.NamespaceNames)
+	if err != nil && meta.IsNoMatchError(errors.Unwrap(err)) {
+		v.log.Info("VM protection enabled, but KubeVirt is not installed")
+
+		return nil, nil
+	}
+
+	return vms, err
+}
+
+// vmsSelectPVC returns true if a PVC, that satisfies the PVC filter of a VRG, is a volume of a virtual machine
+// that the VRG protects
+func vmsSelectPVC(ctx context.Context, vmReader client.Reader, vrg *ramen.VolumeReplicationGroup,
+	pvcSelector PvcSelector, pvc *corev1.PersistentVolumeClaim,
+) (bool, error) {
+	if vrg.Spec.VMProtection == nil || vmReader == nil {
+		return false, nil
+	}
+
+	if FindProtectedPVC(vrg, pvc.Namespace, pvc.Name) == nil {
+		if match, err := rmnutil.PVCMatchesFilter(pvc, pvcSelector.Filter); err != nil || !match {
+			return false, err
+		}
+	}
+
+	vms, err := vmsListFromReader(ctx, vmReader, vrg.Spec.VMProtection, []string{pvc.Namespace})
+	if err != nil {
+		return false, err
+	}
+
+	for _, vm := range vms {
+		if slices.Contains(vmPVCNames(vm), pvc.Name) {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+// vmPVCNames returns the names of the PVCs of a virtual machine's volumes, including those of its DataVolumes,
+// which are named after them
+func vmPVCNames(vm unstructured.Unstructured) []string {
+	names := sets.New[string]()
+
+	volumes, _, _ := unstructured.NestedSlice(vm.Object, "spec", "template", "spec", "volumes")
+	for _, volume := range volumes {
+		volumeMap, ok := volume.(map[string]interface{})
+		if !ok {
+			continue
+		}
+
+		if name, found, _ := unstructured.NestedString(volumeMap, "persistentVolumeClaim", "claimName"); found {
+			names.Insert(name)
+		}
+
+		if name, found, _ := unstructured.NestedString(volumeMap, "dataVolume", "name"); found {
+			names.Insert(name)
+		}
+	}
+
+	dataVolumeTemplates, _, _ := unstructured.NestedSlice(vm.Object, "spec", "dataVolumeTemplates")
+	for _, dataVolumeTemplate := range dataVolumeTemplates {
+		dataVolumeTemplateMap, ok := dataVolumeTemplate.(map[string]interface{})
+		if !ok {
+			continue
+		}
+
+		if name, found, _ := unstructured.NestedString(dataVolumeTemplateMap, "metadata", "name"); found {
+			names.Insert(name)
+		}
+	}
+
+	return sets.List(names)
+}
+
+// vmPVCsAppend appends the PVCs of the protected virtual machines that are not already in the list
+func (v *VRGInstance) vmPVCsAppend(pvcList *corev1.PersistentVolumeClaimList) error {
+	vms, err := v.vmsList()
+	if err != nil {
+		return err
+	}
+
+	pvcs := sets.New[types.NamespacedName]()
+	for i := range pvcList.Items {
+		pvcs.Insert(client.ObjectKeyFromObject(&pvcList.Items[i]))
+	}
+
+	for _, vm := range vms {
+		for _, pvcName := range vmPVCNames(vm) {
+			pvcNamespacedName := types.NamespacedName{Namespace: vm.GetNamespace(), Name: pvcName}
+			if pvcs.Has(pvcNamespacedName) {
+				continue
+			}
+
+			pvc := corev1.PersistentVolumeClaim{}
+			if err := v.reconciler.Get(v.ctx, pvcNamespacedName, &pvc); err != nil {
+				if k8serrors.IsNotFound(err) {
+					v.log.Info("VM PVC not found", "VM", vm.GetName(), "PVC", pvcNamespacedName.String())
+
+					continue
+				}
+
+				return fmt.Errorf("failed to get PVC %s of VM %s (%w)", pvcNamespacedName, vm.GetName(), err)
+			}
+
+			match, err := rmnutil.PVCMatchesFilter(&pvc, v.recipeElements.PvcSelector.Filter)
+			if err != nil {
+				return err
+			}
+
+			if !match && !v.pvcProtectedDespiteFilter(&pvc) {
+				v.log.Info("VM PVC does not satisfy the PVC filter", "VM", vm.GetName(),
+					"PVC", pvcNamespacedName.String())
+
+				continue
+			}
+
+			v.log.Info("Protecting VM PVC", "VM", vm.GetName(), "PVC", pvcNamespacedName.String())
+			pvcList.Items = append(pvcList.Items, pvc)
+			pvcs.Insert(pvcNamespacedName)
+		}
+	}
+
+	return nil
+}
+
+// vmRunStrategy returns the run strategy of a virtual machine, converting the deprecated running field to its
+// equivalent run strategy
+func vmRunStrategy(vm unstructured.Unstructured) string {
+	if runStrategy, found, _ := unstructured.NestedString(vm.Object, "spec", "runStrategy"); found {
+		return runStrategy
+	}
+
+	if running, _, _ := unstructured.NestedBool(vm.Object, "spec", "running"); running {
+		return vmRunStrategyAlways
+	}
+
+	return vmRunStrategyHalted
+}
+
+// vmRunStrategySet sets the run strategy of a virtual machine, removing the running field as KubeVirt does not
+// allow both
+func vmRunStrategySet(vm *unstructured.Unstructured, runStrategy string) error {
+	unstructured.RemoveNestedField(vm.Object, "spec", "running")
+
+	return unstructured.SetNestedField(vm.Object, runStrategy, "spec", "runStrategy")
+}
+
+// vmsStop halts the protected virtual machines, so that their volumes are not in use while they change
+// replication state, recording their run strategies to restore when they are started. It returns true once
+// none of their instances remain.
+func (v *VRGInstance) vmsStop() (bool, error) {
+	vms, err := v.vmsList()
+	if err != nil {
+		return false, err
+	}
+
+	stopped := true
+
+	for i := range vms {
+		vm := &vms[i]
+		log := v.log.WithValues("VM", client.ObjectKeyFromObject(vm).String())
+
+		if runStrategy := vmRunStrategy(*vm); runStrategy != vmRunStrategyHalted {
+			if err := v.vmStop(vm, runStrategy); err != nil {
+				return false, err
+			}
+
+			log.Info("VM stopped", "runStrategy", runStrategy)
+		}
+
+		if err := v.reconciler.APIReader.Get(v.ctx, client.ObjectKeyFromObject(vm), vmiNew()); err != nil {
+			if k8serrors.IsNotFound(err) {
+				continue
+			}
+
+			return false, fmt.Errorf("failed to get instance of VM %s/%s (%w)", vm.GetNamespace(), vm.GetName(), err)
+		}
+
+		log.Info("VM instance still running")
+
+		stopped = false
+	}
+
+	return stopped, nil
+}
+
+func (v *VRGInstance) vmStop(vm *unstructured.Unstructured, runStrategy string) error {
+	annotations := vm.GetAnnotations()
+	if annotations == nil {
+		annotations = map[string]string{}
+	}
+
+	if _, ok := annotations[VMRunStrategyAnnotation]; !ok {
+		annotations[VMRunStrategyAnnotation] = runStrategy
+		vm.SetAnnotations(annotations)
+	}
+
+	if err := vmRunStrategySet(vm, vmRunStrategyHalted); err != nil {
+		return err
+	}
+
+	if err := v.reconciler.Update(v.ctx, vm); err != nil {
+		return fmt.Errorf("failed to stop VM %s/%s (%w)", vm.GetNamespace(), vm.GetName(), err)
+	}
+
+	return nil
+}
+
+// vmsStart restores the run strategies of the protected virtual machines that Ramen stopped, including those
+// recovered from a capture taken while they were stopped
+func (v *VRGInstance) vmsStart() error {
+	vms, err := v.vmsList()
+	if err != nil {
+		return err
+	}
+
+	for i := range vms {
+		vm := &vms[i]
+
+		runStrategy, ok := vm.GetAnnotations()[VMRunStrategyAnnotation]
+		if !ok {
+			continue
+		}
+
+		if err := vmRunStrategySet(vm, runStrategy); err != nil {
+			return err
+		}
+
+		annotations := vm.GetAnnotations()
+		delete(annotations, VMRunStrategyAnnotation)
+		vm.SetAnnotations(annotations)
+
+		if err := v.reconciler.Update(v.ctx, vm); err != nil {
+			return fmt.Errorf("failed to start VM %s/%s (%w)", vm.GetNamespace(), vm.GetName(), err)
+		}
+
+		v.log.Info("VM started", "VM", client.ObjectKeyFromObject(vm).String(), "runStrategy", runStrategy)
+	}
+
+	return nil
+}
+
+// reconcileVMsAsPrimary stops the protected virtual machines while the VRG prepares for, or runs, the final sync
+// of a relocation, and starts those that Ramen stopped otherwise, once their volumes are reconciled
+func (v *VRGInstance) reconcileVMsAsPrimary() bool {
+	if v.instance.Spec.VMProtection == nil {
+		return true
+	}
+
+	if v.instance.Spec.PrepareForFinalSync || v.instance.Spec.RunFinalSync {
+		stopped, err := v.vmsStop()
+		if err != nil {
+			v.log.Info("Failed to stop VMs for final sync", "error", err)
+		}
+
+		if !stopped {
+			v.result.Requeue = true
+		}
+
+		return stopped
+	}
+
+	if v.result.Requeue {
+		return true
+	}
+
+	if err := v.vmsStart(); err != nil {
+		v.log.Info("Failed to start VMs", "error", err)
+
+		v.result.Requeue = true
+	}
+
+	return true
+}
+
+// reconcileVMsAsSecondary stops the protected virtual machines, that remain on a cluster that the workload failed
+// over or relocated from, so that their volumes can be demoted. It returns true if any instances remain.
+func (v *VRGInstance) reconcileVMsAsSecondary() bool {
+	if v.instance.Spec.VMProtection == nil {
+		return false
+	}
+
+	stopped, err := v.vmsStop()
+	if err != nil {
+		v.log.Info("Failed to stop VMs", "error", err)
+	}
+
+	return !stopped
+}
+
+// pvcsDataVolumeAdopt annotates PVCs, restored from the object store, that were populated by a DataVolume, so that
+// CDI adopts them when the DataVolume is recovered with its virtual machine, rather than populating them again
+func pvcsDataVolumeAdopt(pvcList []corev1.PersistentVolumeClaim) {
+	for i := range pvcList {
+		pvc := &pvcList[i]
+
+		for _, ownerReference := range pvc.GetOwnerReferences() {
+			if ownerReference.Kind != DataVolumeKind {
+				continue
+			}
+
+			annotations := pvc.GetAnnotations()
+			if annotations == nil {
+				annotations = map[string]string{}
+			}
+
+			annotations[dataVolumePrePopulatedAnnotation] = ownerReference.Name
+			pvc.SetAnnotations(annotations)
+		}
+	}
+}
+
+// vmCaptureWorkflowDefault returns the default kube objects capture workflow of a VRG that protects virtual
+// machines. The virtual machines and their DataVolumes are captured in a group of their own, so that they are
+// recovered after the objects they depend on, and the instances of the virtual machines are not captured.
+func vmCaptureWorkflowDefault(captureSpec kubeobjects.CaptureSpec) []kubeobjects.CaptureSpec {
+	vmCaptureSpec := captureSpec
+	vmCaptureSpec.Name = vmCaptureGroupName
+	vmCaptureSpec.Spec.IncludedResources = vmCaptureGroupResources
+
+	captureSpec.Spec.ExcludedResources = append(captureSpec.Spec.ExcludedResources, vmCaptureResourcesExcluded...)
+	captureSpec.Spec.LabelSelector = vmLauncherPodsExcluded(captureSpec.Spec.LabelSelector)
+
+	return []kubeobjects.CaptureSpec{captureSpec, vmCaptureSpec}
+}
+
+// vmLauncherPodsExcluded returns a copy of a label selector that additionally excludes the pods that run virtual
+// machine instances
+func vmLauncherPodsExcluded(labelSelector *metav1.LabelSelector) *metav1.LabelSelector {
+	selector := &metav1.LabelSelector{}
+	if labelSelector != nil {
+		selector = labelSelector.DeepCopy()
+	}
+
+	selector.MatchExpressions = append(selector.MatchExpressions, metav1.LabelSelectorRequirement{
+		Key:      "kubevirt.io",
+		Operator: metav1.LabelSelectorOpNotIn,
+		Values:   []string{"virt-launcher"},
+	})
+
+	return selector
+}
+
+// vmRecoverWorkflowDefault returns the default kube objects recover workflow of a VRG that protects virtual
+// machines, which recovers the virtual machines and their DataVolumes last
+func vmRecoverWorkflowDefault() []kubeobjects.RecoverSpec {
+	return []kubeobjects.RecoverSpec{{}, {BackupName: vmCaptureGroupName}}
+}
@@ -0,0 +1,130 @@
+// SPDX-FileCopyrightText: The RamenDR authors
+// SPDX-License-Identifier: Apache-2.0
+
+// white box testing desired for virtual machine helpers
+package controllers //nolint: testpackage
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
+
+	ramen "github.com/ramendr/ramen/api/v1alpha1"
+	"github.com/ramendr/ramen/controllers/kubeobjects"
+)
+
+var _ = Describe("VRG_KubeVirt", func() {
+	vm := func(spec map[string]interface{}) unstructured.Unstructured {
+		return unstructured.Unstructured{Object: map[string]interface{}{"spec": spec}}
+	}
+
+	Context("vmPVCNames", func() {
+		It("returns none for a VM without volumes", func() {
+			Expect(vmPVCNames(vm(map[string]interface{}{}))).To(BeEmpty())
+		})
+		It("returns the PVCs, DataVolumes and DataVolume templates of a VM once each", func() {
+			names := vmPVCNames(vm(map[string]interface{}{
+				"dataVolumeTemplates": []interface{}{
+					map[string]interface{}{"metadata": map[string]interface{}{"name": "dv1"}},
+				},
+				"template": map[string]interface{}{
+					"spec": map[string]interface{}{
+						"volumes": []interface{}{
+							map[string]interface{}{
+								"name":                  "disk0",
+								"persistentVolumeClaim": map[string]interface{}{"claimName": "pvc1"},
+							},
+							map[string]interface{}{
+								"name":       "disk1",
+								"dataVolume": map[string]interface{}{"name": "dv1"},
+							},
+							map[string]interface{}{
+								"name":       "disk2",
+								"dataVolume": map[string]interface{}{"name": "dv2"},
+							},
+							map[string]interface{}{
+								"name":             "cloudinit",
+								"cloudInitNoCloud": map[string]interface{}{"userData": "#cloud-config"},
+							},
+						},
+					},
+				},
+			}))
+			Expect(names).To(ConsistOf("pvc1", "dv1", "dv2"))
+		})
+	})
+
+	Context("vmRunStrategy", func() {
+		It("returns the run strategy of a VM", func() {
+			Expect(vmRunStrategy(vm(map[string]interface{}{"runStrategy": "RerunOnFailure"}))).
+				To(Equal("RerunOnFailure"))
+		})
+		It("converts a running VM to the Always run strategy", func() {
+			Expect(vmRunStrategy(vm(map[string]interface{}{"running": true}))).To(Equal(vmRunStrategyAlways))
+		})
+		It("converts a VM that is not running to the Halted run strategy", func() {
+			Expect(vmRunStrategy(vm(map[string]interface{}{"running": false}))).To(Equal(vmRunStrategyHalted))
+			Expect(vmRunStrategy(vm(map[string]interface{}{}))).To(Equal(vmRunStrategyHalted))
+		})
+		It("sets a run strategy in place of the running field", func() {
+			runningVM := vm(map[string]interface{}{"running": true})
+			Expect(vmRunStrategySet(&runningVM, vmRunStrategyHalted)).To(Succeed())
+			Expect(runningVM.Object["spec"]).ToNot(HaveKey("running"))
+			Expect(vmRunStrategy(runningVM)).To(Equal(vmRunStrategyHalted))
+		})
+	})
+
+	Context("vmCaptureWorkflowDefault", func() {
+		It("captures the VMs and their DataVolumes last, in a group of their own", func() {
+			captureSpec := kubeobjects.CaptureSpec{}
+			captureSpec.Spec.ExcludedResources = []string{"secrets"}
+			captureSpec.Spec.LabelSelector = &metav1.LabelSelector{MatchLabels: map[string]string{"app": "a"}}
+
+			workflow := vmCaptureWorkflowDefault(captureSpec)
+			Expect(workflow).To(HaveLen(2))
+
+			Expect(workflow[0].Spec.ExcludedResources).To(ConsistOf(
+				"secrets",
+				"virtualmachineinstances.kubevirt.io",
+				"datavolumes.cdi.kubevirt.io",
+				"virtualmachines.kubevirt.io",
+			))
+			selector, err := metav1.LabelSelectorAsSelector(workflow[0].Spec.LabelSelector)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(selector.Matches(labels.Set{"app": "a"})).To(BeTrue())
+			Expect(selector.Matches(labels.Set{"app": "a", "kubevirt.io": "virt-launcher"})).To(BeFalse())
+
+			Expect(workflow[1].Name).To(Equal(vmCaptureGroupName))
+			Expect(workflow[1].Spec.IncludedResources).To(ConsistOf(
+				"datavolumes.cdi.kubevirt.io",
+				"virtualmachines.kubevirt.io",
+			))
+			Expect(workflow[1].Spec.LabelSelector.MatchExpressions).To(BeEmpty())
+
+			Expect(captureSpec.Spec.ExcludedResources).To(ConsistOf("secrets"))
+			Expect(captureSpec.Spec.LabelSelector.MatchExpressions).To(BeEmpty())
+		})
+		It("recovers the VMs and their DataVolumes last", func() {
+			Expect(vmRecoverWorkflowDefault()).To(Equal([]kubeobjects.RecoverSpec{{}, {BackupName: vmCaptureGroupName}}))
+		})
+	})
+
+	Context("vmSelector", func() {
+		It("selects every VM without a VM selector", func() {
+			selector, err := vmSelector(&ramen.VMProtectionSpec{})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(selector.Empty()).To(BeTrue())
+		})
+		It("selects the VMs matching the VM selector", func() {
+			selector, err := vmSelector(&ramen.VMProtectionSpec{
+				VMSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"vm": "protected"}},
+			})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(selector.Matches(labels.Set{"vm": "protected"})).To(BeTrue())
+			Expect(selector.Matches(labels.Set{"vm": "other"})).To(BeFalse())
+		})
+	})
+})
@@ -11,6 +11,7 @@ import (
 type PvcSelector struct {
 	LabelSelector  metav1.LabelSelector
 	NamespaceNames []string
+	Filter         *ramen.PVCFilter
 }
 
 // pvcNamespaceNamesDefault returns the default pvc namespaces for the VRG.
@@ -26,7 +27,7 @@ func pvcNamespaceNamesDefault(vrg ramen.VolumeReplicationGroup, ramenConfig rame
 
 // getPVCSelector returns the PVC selector for the VRG. Recipe configuration overrides the VRG configuration.
 func getPVCSelector(vrg ramen.VolumeReplicationGroup, ramenConfig ramen.RamenConfig,
-	recipeVolNamespaces []string, recipeVolLabelSelector *metav1.LabelSelector,
+	recipeVolNamespaces []string, recipeVolLabelSelector *metav1.LabelSelector, recipeVolFilter *ramen.PVCFilter,
 ) PvcSelector {
 	var selector PvcSelector
 
@@ -42,5 +43,11 @@ func getPVCSelector(vrg ramen.VolumeReplicationGroup, ramenConfig ramen.RamenCon
 		selector.NamespaceNames = pvcNamespaceNamesDefault(vrg, ramenConfig)
 	}
 
+	if recipeVolFilter != nil {
+		selector.Filter = recipeVolFilter
+	} else {
+		selector.Filter = vrg.Spec.PVCFilter
+	}
+
 	return selector
 }
@@ -28,6 +28,9 @@ type RecipeElements struct {
 	PvcSelector     PvcSelector
 	CaptureWorkflow []kubeobjects.CaptureSpec
 	RecoverWorkflow []kubeobjects.RecoverSpec
+	// Hooks to freeze, and to unfreeze, the application for a consistent sync
+	ConsistentSyncFreezeHooks   []kubeobjects.CaptureSpec
+	ConsistentSyncUnfreezeHooks []kubeobjects.CaptureSpec
 }
 
 func captureWorkflowDefault(vrg ramen.VolumeReplicationGroup, ramenConfig ramen.RamenConfig) []kubeobjects.CaptureSpec {
@@ -51,10 +54,20 @@ func captureWorkflowDefault(vrg ramen.VolumeReplicationGroup, ramenConfig ramen.
 		captureSpecs[0].Spec.LabelSelector = vrg.Spec.KubeObjectProtection.KubeObjectSelector
 	}
 
+	if vrg.Spec.VMProtection != nil {
+		return vmCaptureWorkflowDefault(captureSpecs[0])
+	}
+
 	return captureSpecs
 }
 
-func recoverWorkflowDefault() []kubeobjects.RecoverSpec { return []kubeobjects.RecoverSpec{{}} }
+func recoverWorkflowDefault(vrg ramen.VolumeReplicationGroup) []kubeobjects.RecoverSpec {
+	if vrg.Spec.VMProtection != nil {
+		return vmRecoverWorkflowDefault()
+	}
+
+	return []kubeobjects.RecoverSpec{{}}
+}
 
 func GetPVCSelector(ctx context.Context, reader client.Reader, vrg ramen.VolumeReplicationGroup,
 	ramenConfig ramen.RamenConfig,
@@ -84,42 +97,39 @@ func recipeVolumesAndOptionallyWorkflowsGet(ctx context.Context, reader client.R
 ) error {
 	if vrg.Spec.KubeObjectProtection == nil {
 		*recipeElements = RecipeElements{
-			PvcSelector: getPVCSelector(vrg, ramenConfig, nil, nil),
+			PvcSelector: getPVCSelector(vrg, ramenConfig, nil, nil, nil),
 		}
 
 		return nil
 	}
 
-	if vrg.Spec.KubeObjectProtection.RecipeRef == nil {
+	recipeRefs := recipeRefsGet(*vrg.Spec.KubeObjectProtection)
+	if len(recipeRefs) == 0 {
 		*recipeElements = RecipeElements{
-			PvcSelector:     getPVCSelector(vrg, ramenConfig, nil, nil),
+			PvcSelector:     getPVCSelector(vrg, ramenConfig, nil, nil, nil),
 			CaptureWorkflow: captureWorkflowDefault(vrg, ramenConfig),
-			RecoverWorkflow: recoverWorkflowDefault(),
+			RecoverWorkflow: recoverWorkflowDefault(vrg),
 		}
 
 		return nil
 	}
 
-	recipeNamespacedName := types.NamespacedName{
-		Namespace: vrg.Spec.KubeObjectProtection.RecipeRef.Namespace,
-		Name:      vrg.Spec.KubeObjectProtection.RecipeRef.Name,
-	}
-
-	recipe := recipe.Recipe{}
-	if err := reader.Get(ctx, recipeNamespacedName, &recipe); err != nil {
-		return fmt.Errorf("recipe %v get error: %w", recipeNamespacedName.String(), err)
+	recipe, err := recipesGetAndMerge(ctx, reader, recipeRefs, vrg.Spec.KubeObjectProtection.RecipeParameters, log)
+	if err != nil {
+		return err
 	}
 
-	if err := RecipeParametersExpand(&recipe, vrg.Spec.KubeObjectProtection.RecipeParameters, log); err != nil {
+	recipeVolumesFilter, err := recipeVolumesFilterGet(recipe)
+	if err != nil {
 		return err
 	}
 
 	var selector PvcSelector
 	if recipe.Spec.Volumes == nil {
-		selector = getPVCSelector(vrg, ramenConfig, nil, nil)
+		selector = getPVCSelector(vrg, ramenConfig, nil, nil, recipeVolumesFilter)
 	} else {
 		selector = getPVCSelector(vrg, ramenConfig, recipe.Spec.Volumes.IncludedNamespaces,
-			recipe.Spec.Volumes.LabelSelector)
+			recipe.Spec.Volumes.LabelSelector, recipeVolumesFilter)
 	}
 
 	*recipeElements = RecipeElements{
@@ -133,6 +143,144 @@ func recipeVolumesAndOptionallyWorkflowsGet(ctx context.Context, reader client.R
 	return recipeNamespacesValidate(*recipeElements, vrg, ramenConfig)
 }
 
+// RecipeVolumesFilterAnnotation is a recipe annotation whose value is a json encoded PVCFilter that
+// narrows down the PVCs selected by the recipe's volume group
+const RecipeVolumesFilterAnnotation = "ramendr.openshift.io/volumes-pvc-filter"
+
+func recipeVolumesFilterGet(recipe recipe.Recipe) (*ramen.PVCFilter, error) {
+	value, ok := recipe.GetAnnotations()[RecipeVolumesFilterAnnotation]
+	if !ok {
+		return nil, nil
+	}
+
+	filter := &ramen.PVCFilter{}
+	if err := json.Unmarshal([]byte(value), filter); err != nil {
+		return nil, fmt.Errorf("recipe %s annotation %s json unmarshal error: %w",
+			recipe.GetName(), RecipeVolumesFilterAnnotation, err)
+	}
+
+	return filter, nil
+}
+
+// recipeRefsGet returns the references of the recipes to compose, in merge order.
+func recipeRefsGet(kubeObjectProtection ramen.KubeObjectProtectionSpec) []ramen.RecipeRef {
+	recipeRefs := make([]ramen.RecipeRef, 0, len(kubeObjectProtection.RecipeRefs)+1)
+
+	if kubeObjectProtection.RecipeRef != nil {
+		recipeRefs = append(recipeRefs, *kubeObjectProtection.RecipeRef)
+	}
+
+	return append(recipeRefs, kubeObjectProtection.RecipeRefs...)
+}
+
+func recipesGetAndMerge(ctx context.Context, reader client.Reader, recipeRefs []ramen.RecipeRef,
+	parameters map[string][]string, log logr.Logger,
+) (recipe.Recipe, error) {
+	recipes := make([]recipe.Recipe, len(recipeRefs))
+
+	for i, recipeRef := range recipeRefs {
+		recipeNamespacedName := types.NamespacedName{Namespace: recipeRef.Namespace, Name: recipeRef.Name}
+
+		if err := reader.Get(ctx, recipeNamespacedName, &recipes[i]); err != nil {
+			return recipe.Recipe{}, fmt.Errorf("recipe %v get error: %w", recipeNamespacedName.String(), err)
+		}
+
+		if err := RecipeParametersExpand(&recipes[i], parameters, log); err != nil {
+			return recipe.Recipe{}, err
+		}
+	}
+
+	return RecipesMerge(recipes...), nil
+}
+
+// RecipesMerge composes recipes in order. A later recipe's groups and hooks replace an earlier
+// recipe's groups and hooks of the same name, in place, and are otherwise appended. A later
+// recipe's volumes, workflows, application type and annotations, if specified, replace an earlier
+// recipe's. A later recipe's volumes also replace an earlier recipe's volumes filter annotation.
+func RecipesMerge(recipes ...recipe.Recipe) recipe.Recipe {
+	if len(recipes) == 1 {
+		return recipes[0]
+	}
+
+	merged := recipe.Recipe{}
+	names := make([]string, 0, len(recipes))
+
+	for _, r := range recipes {
+		names = append(names, r.Name)
+
+		if merged.Namespace == "" {
+			merged.Namespace = r.Namespace
+		}
+
+		for key, value := range r.GetAnnotations() {
+			if merged.Annotations == nil {
+				merged.Annotations = map[string]string{}
+			}
+
+			merged.Annotations[key] = value
+		}
+
+		// A volumes filter narrows down the volumes of the recipe it annotates, so a later recipe's volumes
+		// replace an earlier recipe's filter too
+		if _, ok := r.GetAnnotations()[RecipeVolumesFilterAnnotation]; !ok && r.Spec.Volumes != nil {
+			delete(merged.Annotations, RecipeVolumesFilterAnnotation)
+		}
+
+		if r.Spec.AppType != "" {
+			merged.Spec.AppType = r.Spec.AppType
+		}
+
+		merged.Spec.Groups = recipeGroupsMerge(merged.Spec.Groups, r.Spec.Groups)
+		merged.Spec.Hooks = recipeHooksMerge(merged.Spec.Hooks, r.Spec.Hooks)
+
+		if r.Spec.Volumes != nil {
+			merged.Spec.Volumes = r.Spec.Volumes
+		}
+
+		if r.Spec.CaptureWorkflow != nil {
+			merged.Spec.CaptureWorkflow = r.Spec.CaptureWorkflow
+		}
+
+		if r.Spec.RecoverWorkflow != nil {
+			merged.Spec.RecoverWorkflow = r.Spec.RecoverWorkflow
+		}
+	}
+
+	merged.Name = strings.Join(names, "+")
+
+	return merged
+}
+
+func recipeGroupsMerge(groups, overrides []*recipe.Group) []*recipe.Group {
+	for _, override := range overrides {
+		i := slices.IndexFunc(groups, func(group *recipe.Group) bool { return group.Name == override.Name })
+		if i < 0 {
+			groups = append(groups, override)
+
+			continue
+		}
+
+		groups[i] = override
+	}
+
+	return groups
+}
+
+func recipeHooksMerge(hooks, overrides []*recipe.Hook) []*recipe.Hook {
+	for _, override := range overrides {
+		i := slices.IndexFunc(hooks, func(hook *recipe.Hook) bool { return hook.Name == override.Name })
+		if i < 0 {
+			hooks = append(hooks, override)
+
+			continue
+		}
+
+		hooks[i] = override
+	}
+
+	return hooks
+}
+
 func RecipeParametersExpand(recipe *recipe.Recipe, parameters map[string][]string,
 	log logr.Logger,
 ) error {
@@ -179,7 +327,7 @@ func recipeWorkflowsGet(recipe recipe.Recipe, recipeElements *RecipeElements, vr
 	}
 
 	if recipe.Spec.RecoverWorkflow == nil {
-		recipeElements.RecoverWorkflow = recoverWorkflowDefault()
+		recipeElements.RecoverWorkflow = recoverWorkflowDefault(vrg)
 	} else {
 		recipeElements.RecoverWorkflow, err = getRecoverGroups(recipe)
 		if err != nil {
@@ -187,9 +335,57 @@ func recipeWorkflowsGet(recipe recipe.Recipe, recipeElements *RecipeElements, vr
 		}
 	}
 
+	if vrg.Spec.ConsistentSync != nil {
+		recipeElements.ConsistentSyncFreezeHooks, recipeElements.ConsistentSyncUnfreezeHooks, err =
+			ConsistentSyncHooksGet(recipe, vrg.Spec.ConsistentSync.FreezeHooks)
+		if err != nil {
+			return fmt.Errorf("failed to get consistent sync hooks: %w", err)
+		}
+	}
+
 	return err
 }
 
+// ConsistentSyncHooksGet returns the freeze hooks of a consistent sync, in order, and the inverse operations of
+// those that have one, in reverse order, to unfreeze the application
+func ConsistentSyncHooksGet(recipe recipe.Recipe, freezeHookNames []string,
+) ([]kubeobjects.CaptureSpec, []kubeobjects.CaptureSpec, error) {
+	freezeHooks := make([]kubeobjects.CaptureSpec, 0, len(freezeHookNames))
+	unfreezeHooks := []kubeobjects.CaptureSpec{}
+
+	for _, name := range freezeHookNames {
+		hook, op, err := getHookAndOpFromRecipe(&recipe, name)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		freezeHook, err := convertRecipeHookToCaptureSpec(*hook, *op)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		freezeHooks = append(freezeHooks, *freezeHook)
+
+		if op.InverseOp == "" {
+			continue
+		}
+
+		hook, inverseOp, err := getHookAndOpFromRecipe(&recipe, hook.Name+"/"+op.InverseOp)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		unfreezeHook, err := convertRecipeHookToCaptureSpec(*hook, *inverseOp)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		unfreezeHooks = append([]kubeobjects.CaptureSpec{*unfreezeHook}, unfreezeHooks...)
+	}
+
+	return freezeHooks, unfreezeHooks, nil
+}
+
 func recipeNamespacesValidate(recipeElements RecipeElements, vrg ramen.VolumeReplicationGroup,
 	ramenConfig ramen.RamenConfig,
 ) error {
@@ -282,9 +478,10 @@ func (m objectToReconcileRequestsMapper) recipeToVrgReconcileRequestsMapper(
 
 	for _, vrg := range vrgList.Items {
 		if vrg.Spec.KubeObjectProtection == nil ||
-			vrg.Spec.KubeObjectProtection.RecipeRef == nil ||
-			vrg.Spec.KubeObjectProtection.RecipeRef.Namespace != recipe.GetNamespace() ||
-			vrg.Spec.KubeObjectProtection.RecipeRef.Name != recipe.GetName() {
+			!slices.Contains(recipeRefsGet(*vrg.Spec.KubeObjectProtection), ramen.RecipeRef{
+				Namespace: recipe.GetNamespace(),
+				Name:      recipe.GetName(),
+			}) {
 			continue
 		}
 
@@ -13,6 +13,7 @@ import (
 	gomegatypes "github.com/onsi/gomega/types"
 	ramen "github.com/ramendr/ramen/api/v1alpha1"
 	"github.com/ramendr/ramen/controllers"
+	"github.com/ramendr/ramen/controllers/kubeobjects"
 	recipe "github.com/ramendr/recipe/api/v1alpha1"
 	corev1 "k8s.io/api/core/v1"
 	storagev1 "k8s.io/api/storage/v1"
@@ -566,3 +567,108 @@ var _ = Describe("VolumeReplicationGroupRecipe", func() {
 		})
 	})
 })
+
+var _ = Describe("RecipesMerge", func() {
+	group := func(name, namespaceName string) *recipe.Group {
+		return &recipe.Group{Name: name, Type: "resource", IncludedNamespaces: []string{namespaceName}}
+	}
+	hook := func(name, namespaceName string) *recipe.Hook {
+		return &recipe.Hook{Name: name, Namespace: namespaceName, Type: "exec"}
+	}
+	workflow := func(groupName string) *recipe.Workflow {
+		return &recipe.Workflow{Sequence: []map[string]string{{"group": groupName}}}
+	}
+	var base, app recipe.Recipe
+	BeforeEach(func() {
+		base = recipe.Recipe{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "a", Name: "base"},
+			Spec: recipe.RecipeSpec{
+				AppType:         "postgres",
+				Groups:          []*recipe.Group{group("config", "a"), group("data", "a")},
+				Hooks:           []*recipe.Hook{hook("quiesce", "a")},
+				Volumes:         group("volumes", "a"),
+				CaptureWorkflow: workflow("config"),
+				RecoverWorkflow: workflow("config"),
+			},
+		}
+		app = recipe.Recipe{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "a", Name: "app"},
+			Spec: recipe.RecipeSpec{
+				Groups:          []*recipe.Group{group("data", "b"), group("extra", "a")},
+				Hooks:           []*recipe.Hook{hook("app", "a")},
+				CaptureWorkflow: workflow("extra"),
+			},
+		}
+	})
+	It("returns a single recipe unchanged", func() {
+		Expect(controllers.RecipesMerge(base)).To(Equal(base))
+	})
+	It("replaces groups of the same name in place and appends others", func() {
+		merged := controllers.RecipesMerge(base, app)
+		Expect(merged.Spec.Groups).To(Equal([]*recipe.Group{group("config", "a"), group("data", "b"), group("extra", "a")}))
+	})
+	It("appends hooks of different names", func() {
+		merged := controllers.RecipesMerge(base, app)
+		Expect(merged.Spec.Hooks).To(Equal([]*recipe.Hook{hook("quiesce", "a"), hook("app", "a")}))
+	})
+	It("replaces workflows and volumes only if specified", func() {
+		merged := controllers.RecipesMerge(base, app)
+		Expect(merged.Spec.CaptureWorkflow).To(Equal(workflow("extra")))
+		Expect(merged.Spec.RecoverWorkflow).To(Equal(workflow("config")))
+		Expect(merged.Spec.Volumes).To(Equal(group("volumes", "a")))
+		Expect(merged.Spec.AppType).To(Equal("postgres"))
+	})
+	It("replaces the volumes filter only with the volumes", func() {
+		base.Annotations = map[string]string{controllers.RecipeVolumesFilterAnnotation: `{"minSize": "1Gi"}`}
+		merged := controllers.RecipesMerge(base, app)
+		Expect(merged.Annotations).To(HaveKeyWithValue(controllers.RecipeVolumesFilterAnnotation, `{"minSize": "1Gi"}`))
+
+		app.Spec.Volumes = group("volumes", "b")
+		merged = controllers.RecipesMerge(base, app)
+		Expect(merged.Annotations).NotTo(HaveKey(controllers.RecipeVolumesFilterAnnotation))
+		Expect(base.Annotations).To(HaveKey(controllers.RecipeVolumesFilterAnnotation))
+	})
+})
+
+var _ = Describe("ConsistentSyncHooksGet", func() {
+	r := recipe.Recipe{
+		Spec: recipe.RecipeSpec{
+			Hooks: []*recipe.Hook{
+				{
+					Name: "db", Namespace: "a", Type: "exec",
+					Ops: []*recipe.Operation{
+						{Name: "freeze", Command: []string{"fsfreeze", "-f"}, InverseOp: "unfreeze"},
+						{Name: "unfreeze", Command: []string{"fsfreeze", "-u"}},
+					},
+				},
+				{
+					Name: "app", Namespace: "a", Type: "exec",
+					Ops: []*recipe.Operation{
+						{Name: "pause", Command: []string{"pause"}, InverseOp: "resume"},
+						{Name: "resume", Command: []string{"resume"}},
+						{Name: "flush", Command: []string{"flush"}},
+					},
+				},
+			},
+		},
+	}
+	names := func(hooks []kubeobjects.CaptureSpec) []string {
+		hookNames := make([]string, len(hooks))
+		for i, hook := range hooks {
+			hookNames[i] = hook.Name
+		}
+
+		return hookNames
+	}
+	It("returns the freeze hooks in order and their inverse operations in reverse order", func() {
+		freezeHooks, unfreezeHooks, err := controllers.ConsistentSyncHooksGet(r,
+			[]string{"app/pause", "app/flush", "db/freeze"})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(names(freezeHooks)).To(Equal([]string{"app-pause", "app-flush", "db-freeze"}))
+		Expect(names(unfreezeHooks)).To(Equal([]string{"db-unfreeze", "app-resume"}))
+	})
+	It("returns an error for an operation the recipe does not define", func() {
+		_, _, err := controllers.ConsistentSyncHooksGet(r, []string{"db/snapshot"})
+		Expect(err).To(HaveOccurred())
+	})
+})
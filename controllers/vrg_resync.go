@@ -0,0 +1,211 @@
+// SPDX-FileCopyrightText: The RamenDR authors
+// SPDX-License-Identifier: Apache-2.0
+
+package controllers
+
+import (
+	"fmt"
+	"time"
+
+	volrep "github.com/csi-addons/kubernetes-csi-addons/apis/replication.storage/v1alpha1"
+	volrepController "github.com/csi-addons/kubernetes-csi-addons/controllers/replication.storage"
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	ramendrv1alpha1 "github.com/ramendr/ramen/api/v1alpha1"
+	rmnutil "github.com/ramendr/ramen/controllers/util"
+)
+
+const (
+	resyncPolicyDefaultMaxAttempts = 3
+	resyncPolicyDefaultBackoff     = 5 * time.Minute
+	resyncPolicyMaxBackoff         = 24 * time.Hour
+)
+
+// vrDegradedRemediate tracks the time the VolumeReplication, or VolumeGroupReplication, of a PVC is continuously
+// degraded and, as configured by the VRG resync policy, requests a resync of the VolumeReplication or reports its
+// degradation. It returns true if a resync was requested.
+func (v *VRGInstance) vrDegradedRemediate(pvcNamespacedName types.NamespacedName, volRep client.Object,
+	status *volrep.VolumeReplicationStatus, log logr.Logger,
+) bool {
+	protectedPVC := v.findProtectedPVC(pvcNamespacedName.Namespace, pvcNamespacedName.Name)
+	if protectedPVC == nil {
+		return false
+	}
+
+	metricLabels := PVCResyncMetricLabels(v.instance, pvcNamespacedName.Namespace, pvcNamespacedName.Name)
+
+	degraded, _ := isVRConditionMet(volRep, status.Conditions, volrepController.ConditionDegraded,
+		metav1.ConditionTrue)
+	if !degraded {
+		if protectedPVC.DegradedSince != nil {
+			log.Info("VolumeReplication no longer degraded", "degradedSince", protectedPVC.DegradedSince,
+				"resyncAttempts", protectedPVC.ResyncAttempts)
+		}
+
+		protectedPVC.DegradedSince = nil
+		protectedPVC.ResyncAttempts = 0
+		protectedPVC.LastResyncAttemptTime = nil
+		DeletePVCResyncMetrics(metricLabels)
+
+		return false
+	}
+
+	if protectedPVC.DegradedSince == nil {
+		protectedPVC.DegradedSince = &metav1.Time{Time: time.Now()}
+	}
+
+	resyncMetrics := NewPVCResyncMetrics(metricLabels)
+	resyncMetrics.DegradedDuration.Set(time.Since(protectedPVC.DegradedSince.Time).Seconds())
+
+	defer func() { resyncMetrics.ResyncAttempts.Set(float64(protectedPVC.ResyncAttempts)) }()
+
+	policy := v.instance.Spec.ResyncPolicy
+	if policy == nil {
+		return false
+	}
+
+	switch policy.Remediation {
+	case ramendrv1alpha1.ResyncRemediationAlertOnly:
+		rmnutil.ReportIfNotPresent(v.reconciler.eventRecorder, v.instance, corev1.EventTypeWarning,
+			rmnutil.EventReasonVRDegraded, fmt.Sprintf("%s %s/%s is degraded since %s",
+				volRep.GetObjectKind().GroupVersionKind().Kind, volRep.GetNamespace(), volRep.GetName(),
+				protectedPVC.DegradedSince.Format(time.RFC3339)))
+	case ramendrv1alpha1.ResyncRemediationAutoResync:
+		return v.vrResyncRequest(pvcNamespacedName, volRep, protectedPVC, policy, log)
+	}
+
+	return false
+}
+
+// vrResyncRequest requests a resync of a degraded, Secondary, VolumeReplication that is not already resyncing, if
+// the attempts of the resync policy are not exhausted and the backoff since the previous attempt has elapsed
+func (v *VRGInstance) vrResyncRequest(pvcNamespacedName types.NamespacedName, obj client.Object,
+	protectedPVC *ramendrv1alpha1.ProtectedPVC, policy *ramendrv1alpha1.ResyncPolicy, log logr.Logger,
+) bool {
+	if v.instance.Spec.ReplicationState != ramendrv1alpha1.Secondary {
+		return false
+	}
+
+	// A VolumeGroupReplication resyncs the volumes of all PVCs of its group, hence a resync of a grouped PVC is
+	// not requested, and is left to be requested for the group by an administrator
+	volRep, ok := obj.(*volrep.VolumeReplication)
+	if !ok {
+		rmnutil.ReportIfNotPresent(v.reconciler.eventRecorder, v.instance, corev1.EventTypeWarning,
+			rmnutil.EventReasonVRDegraded, fmt.Sprintf("%s %s/%s of PVC %s is degraded, and is not resynced "+
+				"automatically", obj.GetObjectKind().GroupVersionKind().Kind, obj.GetNamespace(), obj.GetName(),
+				pvcNamespacedName.String()))
+
+		return false
+	}
+
+	if resyncing, _ := isVRConditionMet(volRep, volRep.Status.Conditions, volrepController.ConditionResyncing,
+		metav1.ConditionTrue); resyncing {
+		return false
+	}
+
+	maxAttempts, _ := resyncPolicyLimits(policy)
+
+	nextAttemptTime, exhausted := resyncNextAttemptTime(protectedPVC, policy)
+	if exhausted {
+		rmnutil.ReportIfNotPresent(v.reconciler.eventRecorder, v.instance, corev1.EventTypeWarning,
+			rmnutil.EventReasonVRDegraded, fmt.Sprintf("VolumeReplication %s/%s is degraded after %d resync attempts",
+				volRep.Namespace, volRep.Name, protectedPVC.ResyncAttempts))
+
+		return false
+	}
+
+	if time.Now().Before(nextAttemptTime) {
+		log.Info("Resync backoff", "nextAttemptTime", nextAttemptTime)
+
+		return false
+	}
+
+	volRep.Spec.ReplicationState = volrep.Resync
+	if err := v.reconciler.Update(v.ctx, volRep); err != nil {
+		log.Info("Failed to request resync of VolumeReplication", "error", err)
+
+		return false
+	}
+
+	resyncAttemptRecord(protectedPVC, time.Now())
+
+	msg := fmt.Sprintf("Requested resync %d of %d of degraded VolumeReplication %s/%s",
+		protectedPVC.ResyncAttempts, maxAttempts, volRep.Namespace, volRep.Name)
+	log.Info(msg)
+	rmnutil.ReportIfNotPresent(v.reconciler.eventRecorder, v.instance, corev1.EventTypeNormal,
+		rmnutil.EventReasonVRResyncRequested, msg)
+	v.updatePVCDataReadyCondition(pvcNamespacedName.Namespace, pvcNamespacedName.Name, VRGConditionReasonProgressing, msg)
+
+	return true
+}
+
+// resyncPolicyLimits returns the maximum number of resync attempts and the initial backoff of a resync policy,
+// defaulting those that are unset
+func resyncPolicyLimits(policy *ramendrv1alpha1.ResyncPolicy) (int32, time.Duration) {
+	maxAttempts := policy.MaxAttempts
+	if maxAttempts <= 0 {
+		maxAttempts = resyncPolicyDefaultMaxAttempts
+	}
+
+	backoff := policy.Backoff.Duration
+	if backoff <= 0 {
+		backoff = resyncPolicyDefaultBackoff
+	}
+
+	return maxAttempts, backoff
+}
+
+// resyncBackoff returns the backoff after a number of resync attempts, doubling the initial backoff for each
+// attempt after the first, up to resyncPolicyMaxBackoff
+func resyncBackoff(backoff time.Duration, attempts int32) time.Duration {
+	for attempt := int32(1); attempt < attempts && backoff < resyncPolicyMaxBackoff; attempt++ {
+		backoff *= 2
+	}
+
+	if backoff > resyncPolicyMaxBackoff {
+		return resyncPolicyMaxBackoff
+	}
+
+	return backoff
+}
+
+// resyncNextAttemptTime returns the earliest time of the next resync attempt of a PVC, as of its previous attempts,
+// or true if the attempts of the resync policy are exhausted
+func resyncNextAttemptTime(protectedPVC *ramendrv1alpha1.ProtectedPVC,
+	policy *ramendrv1alpha1.ResyncPolicy,
+) (time.Time, bool) {
+	maxAttempts, backoff := resyncPolicyLimits(policy)
+
+	if protectedPVC.ResyncAttempts >= maxAttempts {
+		return time.Time{}, true
+	}
+
+	if protectedPVC.LastResyncAttemptTime == nil {
+		return time.Time{}, false
+	}
+
+	return protectedPVC.LastResyncAttemptTime.Add(resyncBackoff(backoff, protectedPVC.ResyncAttempts)), false
+}
+
+// resyncAttemptRecord records a resync attempt of a PVC
+func resyncAttemptRecord(protectedPVC *ramendrv1alpha1.ProtectedPVC, now time.Time) {
+	protectedPVC.ResyncAttempts++
+	protectedPVC.LastResyncAttemptTime = &metav1.Time{Time: now}
+}
+
+// vrResyncPending returns true if a resync requested for the VolumeReplication is yet to be processed, in which
+// case its desired state must not be reverted to Secondary
+func vrResyncPending(volRep *volrep.VolumeReplication) bool {
+	return volRep.Spec.ReplicationState == volrep.Resync && volRep.Status.ObservedGeneration != volRep.Generation
+}
+
+// resyncMetricsDelete deletes the resync metrics of the VRG's protected PVCs
+func (v *VRGInstance) resyncMetricsDelete() {
+	for _, protectedPVC := range v.instance.Status.ProtectedPVCs {
+		DeletePVCResyncMetrics(PVCResyncMetricLabels(v.instance, protectedPVC.Namespace, protectedPVC.Name))
+	}
+}
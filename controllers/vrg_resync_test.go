@@ -0,0 +1,86 @@
+// SPDX-FileCopyrightText: The RamenDR authors
+// SPDX-License-Identifier: Apache-2.0
+
+// white box testing desired for resync backoff and attempt accounting
+package controllers //nolint: testpackage
+
+import (
+	"math"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	ramen "github.com/ramendr/ramen/api/v1alpha1"
+)
+
+var _ = Describe("VRG_Resync", func() {
+	DescribeTable("resyncBackoff",
+		func(backoff time.Duration, attempts int32, expected time.Duration) {
+			Expect(resyncBackoff(backoff, attempts)).To(Equal(expected))
+		},
+		Entry("no attempts", time.Minute, int32(0), time.Minute),
+		Entry("first attempt", time.Minute, int32(1), time.Minute),
+		Entry("second attempt", time.Minute, int32(2), 2*time.Minute),
+		Entry("fourth attempt", time.Minute, int32(4), 8*time.Minute),
+		Entry("capped", time.Hour, int32(10), resyncPolicyMaxBackoff),
+		Entry("attempts that would overflow a shift", time.Minute, int32(100), resyncPolicyMaxBackoff),
+		Entry("maximum attempts", time.Minute, int32(math.MaxInt32), resyncPolicyMaxBackoff),
+		Entry("initial backoff beyond the cap", 48*time.Hour, int32(1), resyncPolicyMaxBackoff),
+	)
+
+	Context("resync attempts", func() {
+		var protectedPVC *ramen.ProtectedPVC
+
+		policy := &ramen.ResyncPolicy{
+			Remediation: ramen.ResyncRemediationAutoResync,
+			MaxAttempts: 3,
+			Backoff:     metav1.Duration{Duration: time.Minute},
+		}
+
+		BeforeEach(func() {
+			protectedPVC = &ramen.ProtectedPVC{Namespace: "ns", Name: "pvc"}
+		})
+
+		It("allows the first attempt immediately", func() {
+			nextAttemptTime, exhausted := resyncNextAttemptTime(protectedPVC, policy)
+			Expect(exhausted).To(BeFalse())
+			Expect(nextAttemptTime.IsZero()).To(BeTrue())
+		})
+		It("doubles the backoff after each attempt until the attempts are exhausted", func() {
+			start := time.Now()
+
+			resyncAttemptRecord(protectedPVC, start)
+			Expect(protectedPVC.ResyncAttempts).To(Equal(int32(1)))
+			nextAttemptTime, exhausted := resyncNextAttemptTime(protectedPVC, policy)
+			Expect(exhausted).To(BeFalse())
+			Expect(nextAttemptTime).To(BeTemporally("==", start.Add(time.Minute)))
+
+			resyncAttemptRecord(protectedPVC, nextAttemptTime)
+			Expect(protectedPVC.ResyncAttempts).To(Equal(int32(2)))
+			nextAttemptTime, exhausted = resyncNextAttemptTime(protectedPVC, policy)
+			Expect(exhausted).To(BeFalse())
+			Expect(nextAttemptTime).To(BeTemporally("==", start.Add(3*time.Minute)))
+
+			resyncAttemptRecord(protectedPVC, nextAttemptTime)
+			Expect(protectedPVC.ResyncAttempts).To(Equal(int32(3)))
+			_, exhausted = resyncNextAttemptTime(protectedPVC, policy)
+			Expect(exhausted).To(BeTrue())
+		})
+		It("defaults unset policy limits", func() {
+			defaultPolicy := &ramen.ResyncPolicy{Remediation: ramen.ResyncRemediationAutoResync}
+			start := time.Now()
+
+			resyncAttemptRecord(protectedPVC, start)
+			nextAttemptTime, exhausted := resyncNextAttemptTime(protectedPVC, defaultPolicy)
+			Expect(exhausted).To(BeFalse())
+			Expect(nextAttemptTime).To(BeTemporally("==", start.Add(resyncPolicyDefaultBackoff)))
+
+			protectedPVC.ResyncAttempts = resyncPolicyDefaultMaxAttempts
+			_, exhausted = resyncNextAttemptTime(protectedPVC, defaultPolicy)
+			Expect(exhausted).To(BeTrue())
+		})
+	})
+})
@@ -0,0 +1,93 @@
+// SPDX-FileCopyrightText: The RamenDR authors
+// SPDX-License-Identifier: Apache-2.0
+
+package controllers
+
+import (
+	"fmt"
+	"strings"
+
+	volrep "github.com/csi-addons/kubernetes-csi-addons/apis/replication.storage/v1alpha1"
+	volrepController "github.com/csi-addons/kubernetes-csi-addons/controllers/replication.storage"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	ramendrv1alpha1 "github.com/ramendr/ramen/api/v1alpha1"
+	rmnutil "github.com/ramendr/ramen/controllers/util"
+)
+
+// vrNotDemoted returns a description of the failure of the storage to demote the volume of a VolumeReplication,
+// for its current generation, or an empty string if there is none. The volume then remains primary, and is primary
+// on both clusters if the workload is primary on the peer cluster, as after a failover. Such failures are reported
+// by the conditions of the VolumeReplication:
+//   - Completed false with reason FailedToDemote, as the demotion failed
+//   - Resyncing false with reason FailedToResync, as the resync of a demoted volume, that is needed for it to follow
+//     the peer volume once both were primary, failed
+func vrNotDemoted(generation int64, status *volrep.VolumeReplicationStatus) string {
+	failures := []struct{ conditionType, reason, description string }{
+		{volrepController.ConditionCompleted, volrepController.FailedToDemote, "demotion failed"},
+		{volrepController.ConditionResyncing, volrepController.FailedToResync, "resync failed"},
+	}
+
+	for _, failure := range failures {
+		condition := meta.FindStatusCondition(status.Conditions, failure.conditionType)
+		if condition == nil || condition.ObservedGeneration != generation ||
+			condition.Status != metav1.ConditionFalse || condition.Reason != failure.reason {
+			continue
+		}
+
+		if status.Message == "" {
+			return failure.description
+		}
+
+		return fmt.Sprintf("%s: %s", failure.description, status.Message)
+	}
+
+	return ""
+}
+
+// checkVRNotDemoted records the PVC of the VolumeReplication, or VolumeGroupReplication, of a Secondary VRG as not
+// demoted, and sets its conditions to an error, if the replication status reports that its volume failed to be
+// demoted. It returns true if so.
+func (v *VRGInstance) checkVRNotDemoted(pvcNamespacedName types.NamespacedName, volRep client.Object,
+	status *volrep.VolumeReplicationStatus,
+) bool {
+	if v.instance.Spec.ReplicationState != ramendrv1alpha1.Secondary {
+		return false
+	}
+
+	description := vrNotDemoted(volRep.GetGeneration(), status)
+	if description == "" {
+		return false
+	}
+
+	v.notDemotedPVCs = append(v.notDemotedPVCs, pvcNamespacedName.String())
+
+	msg := fmt.Sprintf("Volume remains primary, %s", description)
+	v.log.Info(msg, "pvc", pvcNamespacedName.String())
+	v.updatePVCDataReadyCondition(pvcNamespacedName.Namespace, pvcNamespacedName.Name, VRGConditionReasonError, msg)
+	v.updatePVCDataProtectedCondition(pvcNamespacedName.Namespace, pvcNamespacedName.Name, VRGConditionReasonError, msg)
+
+	return true
+}
+
+// updateVRGVolumesNotDemotedCondition sets the VRG VolumesNotDemoted condition, and reports an event, if the volume
+// of any PVC was found to remain primary in this reconcile, and removes the condition otherwise. The hub detects a
+// split-brain from it while the VRG of the peer cluster is primary.
+func (v *VRGInstance) updateVRGVolumesNotDemotedCondition() {
+	if len(v.notDemotedPVCs) == 0 {
+		meta.RemoveStatusCondition(&v.instance.Status.Conditions, VRGConditionTypeVolumesNotDemoted)
+
+		return
+	}
+
+	msg := fmt.Sprintf("Volumes of PVCs %s remain primary, as the storage failed to demote them",
+		strings.Join(v.notDemotedPVCs, ", "))
+
+	setVRGConditionTypeVolumesNotDemoted(&v.instance.Status.Conditions, v.instance.Generation, msg)
+	rmnutil.ReportIfNotPresent(v.reconciler.eventRecorder, v.instance, corev1.EventTypeWarning,
+		rmnutil.EventReasonVolumesNotDemoted, msg)
+}
@@ -0,0 +1,151 @@
+// SPDX-FileCopyrightText: The RamenDR authors
+// SPDX-License-Identifier: Apache-2.0
+
+// white box testing desired for split-brain detection
+package controllers //nolint: testpackage
+
+import (
+	volrep "github.com/csi-addons/kubernetes-csi-addons/apis/replication.storage/v1alpha1"
+	volrepController "github.com/csi-addons/kubernetes-csi-addons/controllers/replication.storage"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	ramen "github.com/ramendr/ramen/api/v1alpha1"
+)
+
+var _ = Describe("VRG_SplitBrain", func() {
+	const generation = 2
+
+	// conditions as the csi-addons VolumeReplication controller sets them, without messages
+	conditions := func(observedGeneration int64, completedStatus metav1.ConditionStatus, completedReason string,
+		degradedStatus metav1.ConditionStatus, degradedReason string,
+		resyncingStatus metav1.ConditionStatus, resyncingReason string,
+	) []metav1.Condition {
+		return []metav1.Condition{
+			{
+				Type: volrepController.ConditionCompleted, Status: completedStatus, Reason: completedReason,
+				ObservedGeneration: observedGeneration,
+			},
+			{
+				Type: volrepController.ConditionDegraded, Status: degradedStatus, Reason: degradedReason,
+				ObservedGeneration: observedGeneration,
+			},
+			{
+				Type: volrepController.ConditionResyncing, Status: resyncingStatus, Reason: resyncingReason,
+				ObservedGeneration: observedGeneration,
+			},
+		}
+	}
+
+	DescribeTable("vrNotDemoted",
+		func(state volrep.State, message string, conditions []metav1.Condition, expected string) {
+			status := &volrep.VolumeReplicationStatus{
+				State:              state,
+				Message:            message,
+				ObservedGeneration: generation,
+				Conditions:         conditions,
+			}
+
+			Expect(vrNotDemoted(generation, status)).To(Equal(expected))
+		},
+		Entry("demoted", volrep.SecondaryState, "volume is marked secondary",
+			conditions(generation,
+				metav1.ConditionTrue, volrepController.Demoted,
+				metav1.ConditionTrue, volrepController.VolumeDegraded,
+				metav1.ConditionFalse, volrepController.NotResyncing),
+			""),
+		Entry("resyncing", volrep.SecondaryState, "volume is marked for resyncing",
+			conditions(generation,
+				metav1.ConditionTrue, volrepController.Demoted,
+				metav1.ConditionTrue, volrepController.VolumeDegraded,
+				metav1.ConditionTrue, volrepController.ResyncTriggered),
+			""),
+		Entry("resynced", volrep.SecondaryState, "volume is marked secondary",
+			conditions(generation,
+				metav1.ConditionTrue, volrepController.Demoted,
+				metav1.ConditionFalse, volrepController.Healthy,
+				metav1.ConditionFalse, volrepController.NotResyncing),
+			""),
+		Entry("failed to promote", volrep.UnknownState, "failed to promote volume",
+			conditions(generation,
+				metav1.ConditionFalse, volrepController.FailedToPromote,
+				metav1.ConditionTrue, volrepController.Error,
+				metav1.ConditionFalse, volrepController.NotResyncing),
+			""),
+		Entry("failed to demote", volrep.UnknownState, "rpc error: image is primary",
+			conditions(generation,
+				metav1.ConditionFalse, volrepController.FailedToDemote,
+				metav1.ConditionTrue, volrepController.Error,
+				metav1.ConditionFalse, volrepController.NotResyncing),
+			"demotion failed: rpc error: image is primary"),
+		Entry("failed to demote, for a previous generation", volrep.UnknownState, "rpc error: image is primary",
+			conditions(generation-1,
+				metav1.ConditionFalse, volrepController.FailedToDemote,
+				metav1.ConditionTrue, volrepController.Error,
+				metav1.ConditionFalse, volrepController.NotResyncing),
+			""),
+		Entry("failed to resync", volrep.UnknownState, "",
+			conditions(generation,
+				metav1.ConditionFalse, volrepController.FailedToResync,
+				metav1.ConditionTrue, volrepController.Error,
+				metav1.ConditionFalse, volrepController.FailedToResync),
+			"resync failed"),
+	)
+
+	It("reports a failed resync as such once the demotion completed", func() {
+		status := &volrep.VolumeReplicationStatus{
+			Message: "split-brain",
+			Conditions: conditions(generation,
+				metav1.ConditionTrue, volrepController.Demoted,
+				metav1.ConditionTrue, volrepController.Error,
+				metav1.ConditionFalse, volrepController.FailedToResync),
+		}
+
+		Expect(vrNotDemoted(generation, status)).To(Equal("resync failed: split-brain"))
+	})
+
+	Context("vrgsSplitBrain", func() {
+		vrg := func(state ramen.ReplicationState, statusState ramen.State, statusGeneration int64,
+			notDemoted bool,
+		) *ramen.VolumeReplicationGroup {
+			vrg := &ramen.VolumeReplicationGroup{
+				ObjectMeta: metav1.ObjectMeta{Generation: generation},
+				Spec:       ramen.VolumeReplicationGroupSpec{ReplicationState: state},
+				Status:     ramen.VolumeReplicationGroupStatus{State: statusState, ObservedGeneration: statusGeneration},
+			}
+
+			if notDemoted {
+				setVRGConditionTypeVolumesNotDemoted(&vrg.Status.Conditions, statusGeneration, "pvc ns/a not demoted")
+			}
+
+			return vrg
+		}
+
+		It("detects volumes not demoted on a cluster while the workload is primary on another", func() {
+			Expect(vrgsSplitBrain(map[string]*ramen.VolumeReplicationGroup{
+				"east": vrg(ramen.Primary, ramen.PrimaryState, generation, false),
+				"west": vrg(ramen.Secondary, ramen.UnknownState, generation, true),
+			})).To(And(ContainSubstring("east and west"), ContainSubstring("pvc ns/a not demoted")))
+		})
+
+		It("does not detect volumes not demoted while the workload is not primary on another cluster", func() {
+			Expect(vrgsSplitBrain(map[string]*ramen.VolumeReplicationGroup{
+				"east": vrg(ramen.Secondary, ramen.SecondaryState, generation, false),
+				"west": vrg(ramen.Secondary, ramen.UnknownState, generation, true),
+			})).To(BeEmpty())
+			Expect(vrgsSplitBrain(map[string]*ramen.VolumeReplicationGroup{
+				"east": vrg(ramen.Primary, ramen.PrimaryState, generation-1, false),
+				"west": vrg(ramen.Secondary, ramen.UnknownState, generation, true),
+			})).To(BeEmpty())
+		})
+
+		It("does not detect volumes not demoted reported for a previous generation", func() {
+			Expect(vrgsSplitBrain(map[string]*ramen.VolumeReplicationGroup{
+				"east": vrg(ramen.Primary, ramen.PrimaryState, generation, false),
+				"west": vrg(ramen.Secondary, ramen.UnknownState, generation-1, true),
+			})).To(BeEmpty())
+		})
+	})
+})
@@ -0,0 +1,457 @@
+// SPDX-FileCopyrightText: The RamenDR authors
+// SPDX-License-Identifier: Apache-2.0
+
+package controllers
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	ramendrv1alpha1 "github.com/ramendr/ramen/api/v1alpha1"
+	rmnutil "github.com/ramendr/ramen/controllers/util"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	// Static PVs are archived apart from the PVs of VolRep protected PVCs, which are all restored on failover
+	staticPVsS3KeySuffix = "staticvolumes/"
+
+	// Interval to check whether the application of a static volume, that is due to sync, is quiesced, and whether
+	// the sync completed for the application to resume
+	staticVolumeQuiescePollInterval = 10 * time.Second
+
+	// Workloads scaled down for static volumes to sync are labeled with their VRG, and annotated with the replicas
+	// to scale them back up to and the PVCs they were scaled down for
+	staticVolumeQuiesceLabelOwnerNamespaceName = "ramendr.openshift.io/static-volume-quiesce-owner-namespace-name"
+	staticVolumeQuiesceLabelOwnerName          = "ramendr.openshift.io/static-volume-quiesce-owner-name"
+	staticVolumeQuiesceReplicasAnnotation      = "ramendr.openshift.io/static-volume-quiesce-replicas"
+	staticVolumeQuiescePVCsAnnotation          = "ramendr.openshift.io/static-volume-quiesce-pvcs"
+)
+
+// pvStatic returns true if a PV was statically provisioned, rather than by a CSI driver or an in-tree provisioner,
+// such as an NFS or hostPath PV created by an administrator
+func pvStatic(pv *corev1.PersistentVolume) bool {
+	_, provisioned := pv.GetAnnotations()[pvProvisionedByAnnotation]
+
+	return pv.Spec.CSI == nil && !provisioned
+}
+
+// pvcStaticPV returns the static PV that the PVC is bound to, or nil if it is not bound to one
+func (v *VRGInstance) pvcStaticPV(pvc *corev1.PersistentVolumeClaim) (*corev1.PersistentVolume, error) {
+	if pvc.Spec.VolumeName == "" {
+		return nil, nil
+	}
+
+	pv, err := v.getPVFromPVC(pvc)
+	if err != nil {
+		return nil, err
+	}
+
+	if !pvStatic(&pv) {
+		return nil, nil
+	}
+
+	return &pv, nil
+}
+
+func (v *VRGInstance) staticPVsS3KeyPrefix() string {
+	return v.s3KeyPrefix() + staticPVsS3KeySuffix
+}
+
+// staticPVArchive uploads the spec of a static PV to the VRG's S3 stores, for it to be restored on a peer cluster,
+// unless it was already uploaded since the PV last changed
+func (v *VRGInstance) staticPVArchive(pv *corev1.PersistentVolume) error {
+	archivedValue := v.generateArchiveAnnotation(pv.Generation)
+	if pv.GetAnnotations()[pvcVRAnnotationArchivedKey] == archivedValue {
+		return nil
+	}
+
+	if len(v.instance.Spec.S3Profiles) == 0 {
+		return fmt.Errorf("error uploading static PV %s because VRG spec has no S3 profiles", pv.GetName())
+	}
+
+	for _, s3ProfileName := range v.instance.Spec.S3Profiles {
+		objectStore, err := v.getObjectStorer(s3ProfileName)
+		if err != nil {
+			return fmt.Errorf("error getting object store, failed to protect static PV %s, %w", pv.GetName(), err)
+		}
+
+		if err := UploadPV(objectStore, v.staticPVsS3KeyPrefix(), pv.GetName(), *pv); err != nil {
+			return fmt.Errorf("error uploading static PV %s to s3Profile %s, %w", pv.GetName(), s3ProfileName, err)
+		}
+	}
+
+	if pv.ObjectMeta.Annotations == nil {
+		pv.ObjectMeta.Annotations = map[string]string{}
+	}
+
+	pv.ObjectMeta.Annotations[pvcVRAnnotationArchivedKey] = archivedValue
+
+	if err := v.reconciler.Update(v.ctx, pv); err != nil {
+		return fmt.Errorf("failed to update static PV %s annotation %s, %w", pv.GetName(),
+			pvcVRAnnotationArchivedKey, err)
+	}
+
+	v.log.Info("Uploaded static PV", "PV", pv.GetName(), "profiles", v.instance.Spec.S3Profiles)
+
+	return nil
+}
+
+// staticPVRestore creates the static PV of a PVC, protected on a peer cluster, from its spec in the VRG's S3
+// stores, with its endpoints mapped to the equivalent endpoints on this cluster. An existing PV is left as is.
+func (v *VRGInstance) staticPVRestore(protectedPVC ramendrv1alpha1.ProtectedPVC) error {
+	pvName := protectedPVC.StaticPVName
+
+	if err := v.reconciler.Get(v.ctx, types.NamespacedName{Name: pvName}, &corev1.PersistentVolume{}); err == nil {
+		return nil
+	} else if !k8serrors.IsNotFound(err) {
+		return fmt.Errorf("failed to get static PV %s, %w", pvName, err)
+	}
+
+	pv := corev1.PersistentVolume{}
+	err := errors.New("s3Profiles empty")
+
+	for _, s3ProfileName := range v.instance.Spec.S3Profiles {
+		if s3ProfileName == NoS3StoreAvailable {
+			continue
+		}
+
+		var objectStore ObjectStorer
+
+		objectStore, err = v.getObjectStorer(s3ProfileName)
+		if err != nil {
+			continue
+		}
+
+		err = DownloadTypedObject(objectStore, v.staticPVsS3KeyPrefix(), pvName, &pv)
+		if err == nil {
+			break
+		}
+	}
+
+	if err != nil {
+		return fmt.Errorf("failed to download static PV %s, %w", pvName, err)
+	}
+
+	cleanupPVForRestore(&pv)
+	pv.ObjectMeta.Annotations = PruneAnnotations(pv.GetAnnotations())
+	pv.Spec.ClaimRef = &corev1.ObjectReference{
+		Kind:      "PersistentVolumeClaim",
+		Namespace: protectedPVC.Namespace,
+		Name:      protectedPVC.Name,
+	}
+	pv.Spec.StorageClassName = v.storageClassNameMapped(pv.Spec.StorageClassName)
+
+	if err := v.staticPVEndpointsMap(&pv); err != nil {
+		return fmt.Errorf("failed to restore static PV %s, %w", pvName, err)
+	}
+
+	if err := v.reconciler.Create(v.ctx, &pv); err != nil {
+		return fmt.Errorf("failed to create static PV %s, %w", pvName, err)
+	}
+
+	v.log.Info("Restored static PV", "PV", pvName, "PVC", client.ObjectKey{
+		Namespace: protectedPVC.Namespace, Name: protectedPVC.Name,
+	}.String())
+
+	return nil
+}
+
+// staticPVEndpointsMap rewrites the endpoints of a static PV, protected on a peer cluster, to the equivalent
+// endpoints on this cluster: the NFS server, and the node names of its node affinity. It returns an error if an
+// endpoint is not mapped, as the PV would refer to an endpoint of the peer cluster. An endpoint shared by the
+// clusters is mapped to itself.
+func (v *VRGInstance) staticPVEndpointsMap(pv *corev1.PersistentVolume) error {
+	endpointMapped := func(endpoint string) (string, error) {
+		mappedEndpoint, ok := v.instance.Spec.StaticVolumeEndpointMapping[endpoint]
+		if !ok {
+			return "", fmt.Errorf("static PV %s endpoint %s is not mapped to an endpoint of this cluster",
+				pv.GetName(), endpoint)
+		}
+
+		v.log.Info("Mapping static PV endpoint", "PV", pv.GetName(), "from", endpoint, "to", mappedEndpoint)
+
+		return mappedEndpoint, nil
+	}
+
+	if pv.Spec.NFS != nil {
+		server, err := endpointMapped(pv.Spec.NFS.Server)
+		if err != nil {
+			return err
+		}
+
+		pv.Spec.NFS.Server = server
+	}
+
+	if pv.Spec.NodeAffinity == nil || pv.Spec.NodeAffinity.Required == nil {
+		return nil
+	}
+
+	for i := range pv.Spec.NodeAffinity.Required.NodeSelectorTerms {
+		term := &pv.Spec.NodeAffinity.Required.NodeSelectorTerms[i]
+
+		for j := range term.MatchExpressions {
+			expression := &term.MatchExpressions[j]
+			if expression.Key != corev1.LabelHostname {
+				continue
+			}
+
+			for k := range expression.Values {
+				nodeName, err := endpointMapped(expression.Values[k])
+				if err != nil {
+					return err
+				}
+
+				expression.Values[k] = nodeName
+			}
+		}
+	}
+
+	return nil
+}
+
+func staticVolumeQuiesceLabels(vrg *ramendrv1alpha1.VolumeReplicationGroup) map[string]string {
+	return map[string]string{
+		staticVolumeQuiesceLabelOwnerNamespaceName: vrg.Namespace,
+		staticVolumeQuiesceLabelOwnerName:          vrg.Name,
+	}
+}
+
+// staticVolumesQuiesceReconcile quiesces the applications of the static volumes that are due to sync, by scaling
+// down the Deployments and StatefulSets of the pods using them, for their syncs to run once no pod uses them. If
+// resume is set, the workloads none of whose static volumes is due to sync any longer are scaled back up.
+func (v *VRGInstance) staticVolumesQuiesceReconcile(resume bool) error {
+	quiesce := sets.New(v.volSyncHandler.StaticVolumesQuiesce()...)
+
+	for _, pvcNamespacedName := range v.volSyncHandler.StaticVolumesQuiesce() {
+		if err := v.staticVolumeApplicationQuiesce(pvcNamespacedName); err != nil {
+			return err
+		}
+	}
+
+	if !resume {
+		return nil
+	}
+
+	workloads, err := v.staticVolumeQuiescedWorkloads()
+	if err != nil {
+		return err
+	}
+
+	for _, workload := range workloads {
+		pvcNames := strings.Split(workload.GetAnnotations()[staticVolumeQuiescePVCsAnnotation], ",")
+
+		if slices.ContainsFunc(pvcNames, func(pvcName string) bool {
+			return quiesce.Has(types.NamespacedName{Namespace: workload.GetNamespace(), Name: pvcName})
+		}) {
+			continue
+		}
+
+		if err := v.staticVolumeWorkloadResume(workload); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// staticVolumeApplicationQuiesce scales down the workloads of the application pods using a static volume. A pod
+// that is not managed by a Deployment or a StatefulSet is reported, as it cannot be quiesced, and the volume syncs
+// once it is deleted.
+func (v *VRGInstance) staticVolumeApplicationQuiesce(pvcNamespacedName types.NamespacedName) error {
+	pods := &corev1.PodList{}
+
+	if err := v.reconciler.List(v.ctx, pods,
+		client.MatchingFields{rmnutil.PodVolumePVCClaimIndexName: pvcNamespacedName.Name},
+		client.InNamespace(pvcNamespacedName.Namespace)); err != nil {
+		return fmt.Errorf("failed to list pods using static volume PVC %s, %w", pvcNamespacedName, err)
+	}
+
+	for i := range pods.Items {
+		pod := &pods.Items[i]
+
+		ownerRef := metav1.GetControllerOf(pod)
+		if ownerRef != nil && ownerRef.Kind == "Job" {
+			// VolSync movers, and jobs, complete on their own
+			continue
+		}
+
+		workload, err := v.podWorkload(pod)
+		if err != nil {
+			return err
+		}
+
+		if workload == nil {
+			v.log.Info("Pod using static volume cannot be quiesced", "pod", client.ObjectKeyFromObject(pod),
+				"pvc", pvcNamespacedName)
+			rmnutil.ReportIfNotPresent(v.reconciler.eventRecorder, v.instance, corev1.EventTypeWarning,
+				rmnutil.EventReasonStaticVolumeQuiesceFailed,
+				fmt.Sprintf("Pod %s/%s using static volume PVC %s is not managed by a Deployment or a StatefulSet,"+
+					" the PVC syncs once the pod is deleted", pod.GetNamespace(), pod.GetName(), pvcNamespacedName.Name))
+
+			continue
+		}
+
+		if err := v.staticVolumeWorkloadQuiesce(workload, pvcNamespacedName.Name); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// podWorkload returns the Deployment or StatefulSet managing a pod, or nil if there is none
+func (v *VRGInstance) podWorkload(pod *corev1.Pod) (client.Object, error) {
+	ownerRef := metav1.GetControllerOf(pod)
+	if ownerRef == nil {
+		return nil, nil
+	}
+
+	key := types.NamespacedName{Namespace: pod.GetNamespace(), Name: ownerRef.Name}
+
+	switch ownerRef.Kind {
+	case "StatefulSet":
+		statefulSet := &appsv1.StatefulSet{}
+
+		return statefulSet, v.reconciler.APIReader.Get(v.ctx, key, statefulSet)
+	case "ReplicaSet":
+		replicaSet := &appsv1.ReplicaSet{}
+		if err := v.reconciler.APIReader.Get(v.ctx, key, replicaSet); err != nil {
+			return nil, fmt.Errorf("failed to get replica set %s of pod %s, %w", key, pod.GetName(), err)
+		}
+
+		ownerRef = metav1.GetControllerOf(replicaSet)
+		if ownerRef == nil || ownerRef.Kind != "Deployment" {
+			return nil, nil
+		}
+
+		deployment := &appsv1.Deployment{}
+		key.Name = ownerRef.Name
+
+		return deployment, v.reconciler.APIReader.Get(v.ctx, key, deployment)
+	}
+
+	return nil, nil
+}
+
+// workloadReplicas returns the replicas of a Deployment or StatefulSet
+func workloadReplicas(workload client.Object) **int32 {
+	switch workload := workload.(type) {
+	case *appsv1.Deployment:
+		return &workload.Spec.Replicas
+	case *appsv1.StatefulSet:
+		return &workload.Spec.Replicas
+	}
+
+	return nil
+}
+
+// staticVolumeWorkloadQuiesce scales down a workload using a static volume that is due to sync, unless it was
+// already. Its replicas, and the PVC, are recorded for it to be scaled back up once the PVC syncs.
+func (v *VRGInstance) staticVolumeWorkloadQuiesce(workload client.Object, pvcName string) error {
+	labels := workload.GetLabels()
+	annotations := workload.GetAnnotations()
+
+	if labels[staticVolumeQuiesceLabelOwnerName] != "" {
+		pvcNames := strings.Split(annotations[staticVolumeQuiescePVCsAnnotation], ",")
+		if slices.Contains(pvcNames, pvcName) {
+			return nil
+		}
+
+		annotations[staticVolumeQuiescePVCsAnnotation] = strings.Join(append(pvcNames, pvcName), ",")
+	} else {
+		replicas := workloadReplicas(workload)
+
+		replicasValue := int32(1)
+		if *replicas != nil {
+			replicasValue = **replicas
+		}
+
+		for key, value := range staticVolumeQuiesceLabels(v.instance) {
+			rmnutil.AddLabel(workload, key, value)
+		}
+
+		rmnutil.AddAnnotation(workload, staticVolumeQuiesceReplicasAnnotation, strconv.Itoa(int(replicasValue)))
+		rmnutil.AddAnnotation(workload, staticVolumeQuiescePVCsAnnotation, pvcName)
+
+		*replicas = new(int32)
+	}
+
+	if err := v.reconciler.Update(v.ctx, workload); err != nil {
+		return fmt.Errorf("failed to quiesce workload %s of static volume PVC %s, %w",
+			client.ObjectKeyFromObject(workload), pvcName, err)
+	}
+
+	v.log.Info("Quiesced workload for static volume to sync", "workload", client.ObjectKeyFromObject(workload),
+		"pvcs", workload.GetAnnotations()[staticVolumeQuiescePVCsAnnotation])
+
+	return nil
+}
+
+// staticVolumeQuiescedWorkloads returns the Deployments and StatefulSets scaled down by the VRG for their static
+// volumes to sync
+func (v *VRGInstance) staticVolumeQuiescedWorkloads() ([]client.Object, error) {
+	workloads := []client.Object{}
+	matchingLabels := client.MatchingLabels(staticVolumeQuiesceLabels(v.instance))
+
+	deployments := &appsv1.DeploymentList{}
+	if err := v.reconciler.APIReader.List(v.ctx, deployments, matchingLabels); err != nil {
+		return nil, fmt.Errorf("failed to list quiesced deployments, %w", err)
+	}
+
+	for i := range deployments.Items {
+		workloads = append(workloads, &deployments.Items[i])
+	}
+
+	statefulSets := &appsv1.StatefulSetList{}
+	if err := v.reconciler.APIReader.List(v.ctx, statefulSets, matchingLabels); err != nil {
+		return nil, fmt.Errorf("failed to list quiesced stateful sets, %w", err)
+	}
+
+	for i := range statefulSets.Items {
+		workloads = append(workloads, &statefulSets.Items[i])
+	}
+
+	return workloads, nil
+}
+
+// staticVolumeWorkloadResume scales a workload, quiesced for its static volumes to sync, back up to its replicas
+func (v *VRGInstance) staticVolumeWorkloadResume(workload client.Object) error {
+	replicasValue, err := strconv.ParseInt(workload.GetAnnotations()[staticVolumeQuiesceReplicasAnnotation], 10, 32)
+	if err != nil {
+		return fmt.Errorf("workload %s quiesced replicas annotation invalid, %w",
+			client.ObjectKeyFromObject(workload), err)
+	}
+
+	replicas := int32(replicasValue)
+	*workloadReplicas(workload) = &replicas
+
+	labels := workload.GetLabels()
+	for key := range staticVolumeQuiesceLabels(v.instance) {
+		delete(labels, key)
+	}
+
+	annotations := workload.GetAnnotations()
+	delete(annotations, staticVolumeQuiesceReplicasAnnotation)
+	delete(annotations, staticVolumeQuiescePVCsAnnotation)
+
+	if err := v.reconciler.Update(v.ctx, workload); err != nil {
+		return fmt.Errorf("failed to resume workload %s quiesced for its static volumes to sync, %w",
+			client.ObjectKeyFromObject(workload), err)
+	}
+
+	v.log.Info("Resumed workload quiesced for its static volumes to sync", "workload",
+		client.ObjectKeyFromObject(workload), "replicas", replicas)
+
+	return nil
+}
@@ -0,0 +1,85 @@
+// SPDX-FileCopyrightText: The RamenDR authors
+// SPDX-License-Identifier: Apache-2.0
+
+// white box testing desired for static PV endpoint mapping
+package controllers //nolint: testpackage
+
+import (
+	"github.com/go-logr/logr"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	rmn "github.com/ramendr/ramen/api/v1alpha1"
+)
+
+var _ = Describe("VRG_StaticPVEndpointsMap", func() {
+	v := &VRGInstance{
+		instance: &rmn.VolumeReplicationGroup{
+			Spec: rmn.VolumeReplicationGroupSpec{
+				StaticVolumeEndpointMapping: map[string]string{
+					"nfs.west.example.com": "nfs.east.example.com",
+					"nfs.example.com":      "nfs.example.com",
+					"worker-a":             "worker-0",
+				},
+			},
+		},
+		log: logr.Discard(),
+	}
+
+	nfsPV := func(server string) *corev1.PersistentVolume {
+		return &corev1.PersistentVolume{
+			ObjectMeta: metav1.ObjectMeta{Name: "pv"},
+			Spec: corev1.PersistentVolumeSpec{
+				PersistentVolumeSource: corev1.PersistentVolumeSource{
+					NFS: &corev1.NFSVolumeSource{Server: server, Path: "/export"},
+				},
+			},
+		}
+	}
+
+	localPV := func(nodeName string) *corev1.PersistentVolume {
+		return &corev1.PersistentVolume{
+			ObjectMeta: metav1.ObjectMeta{Name: "pv"},
+			Spec: corev1.PersistentVolumeSpec{
+				NodeAffinity: &corev1.VolumeNodeAffinity{
+					Required: &corev1.NodeSelector{NodeSelectorTerms: []corev1.NodeSelectorTerm{{
+						MatchExpressions: []corev1.NodeSelectorRequirement{{
+							Key:      corev1.LabelHostname,
+							Operator: corev1.NodeSelectorOpIn,
+							Values:   []string{nodeName},
+						}},
+					}}},
+				},
+			},
+		}
+	}
+
+	It("maps the NFS server of a PV to the equivalent server on this cluster", func() {
+		pv := nfsPV("nfs.west.example.com")
+		Expect(v.staticPVEndpointsMap(pv)).To(Succeed())
+		Expect(pv.Spec.NFS.Server).To(Equal("nfs.east.example.com"))
+	})
+
+	It("keeps an NFS server shared by the clusters", func() {
+		pv := nfsPV("nfs.example.com")
+		Expect(v.staticPVEndpointsMap(pv)).To(Succeed())
+		Expect(pv.Spec.NFS.Server).To(Equal("nfs.example.com"))
+	})
+
+	It("refuses a PV whose NFS server is not mapped", func() {
+		pv := nfsPV("nfs.north.example.com")
+		Expect(v.staticPVEndpointsMap(pv)).NotTo(Succeed())
+	})
+
+	It("maps the node names of a PV's node affinity, and refuses a PV whose node name is not mapped", func() {
+		pv := localPV("worker-a")
+		Expect(v.staticPVEndpointsMap(pv)).To(Succeed())
+		Expect(pv.Spec.NodeAffinity.Required.NodeSelectorTerms[0].MatchExpressions[0].Values).To(
+			Equal([]string{"worker-0"}))
+
+		Expect(v.staticPVEndpointsMap(localPV("worker-b"))).NotTo(Succeed())
+	})
+})
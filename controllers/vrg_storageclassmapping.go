@@ -0,0 +1,129 @@
+// SPDX-FileCopyrightText: The RamenDR authors
+// SPDX-License-Identifier: Apache-2.0
+
+package controllers
+
+import (
+	"fmt"
+
+	ramendrv1alpha1 "github.com/ramendr/ramen/api/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	storagev1 "k8s.io/api/storage/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+const (
+	pvProvisionedByAnnotation          = "pv.kubernetes.io/provisioned-by"
+	pvcStorageProvisionerAnnotation    = "volume.kubernetes.io/storage-provisioner"
+	pvcStorageProvisionerAnnotationOld = "volume.beta.kubernetes.io/storage-provisioner"
+)
+
+// storageClassNameMapped returns the name of the StorageClass on this cluster that is equivalent to the named
+// StorageClass of a peer cluster, or the name itself if it is not mapped
+func (v *VRGInstance) storageClassNameMapped(storageClassName string) string {
+	if mappedName, ok := v.instance.Spec.StorageClassMapping[storageClassName]; ok {
+		return mappedName
+	}
+
+	return storageClassName
+}
+
+// storageClassGetByName returns the named StorageClass from the instance cache, or fetches and caches it
+func (v *VRGInstance) storageClassGetByName(storageClassName string) (*storagev1.StorageClass, error) {
+	if storageClass, ok := v.storageClassCache[storageClassName]; ok {
+		return storageClass, nil
+	}
+
+	storageClass := &storagev1.StorageClass{}
+	if err := v.reconciler.Get(v.ctx, types.NamespacedName{Name: storageClassName}, storageClass); err != nil {
+		return nil, fmt.Errorf("failed to get the storageclass with name %s (%w)", storageClassName, err)
+	}
+
+	v.storageClassCache[storageClassName] = storageClass
+
+	return storageClass, nil
+}
+
+// pvsStorageClassMap updates PVs, protected on a peer cluster, to use the StorageClass and the CSI driver of
+// the equivalent StorageClass on this cluster. The volume handle is preserved, and is expected to be valid
+// for the mapped CSI driver.
+func (v *VRGInstance) pvsStorageClassMap(pvList []corev1.PersistentVolume) error {
+	for i := range pvList {
+		pv := &pvList[i]
+
+		mappedName := v.storageClassNameMapped(pv.Spec.StorageClassName)
+		if mappedName == pv.Spec.StorageClassName {
+			continue
+		}
+
+		storageClass, err := v.storageClassGetByName(mappedName)
+		if err != nil {
+			return fmt.Errorf("failed to map StorageClass of PV %s (%w)", pv.GetName(), err)
+		}
+
+		v.log.Info("Mapping PV StorageClass", "PV", pv.GetName(), "from", pv.Spec.StorageClassName,
+			"to", mappedName, "provisioner", storageClass.Provisioner)
+
+		pv.Spec.StorageClassName = mappedName
+
+		if pv.Spec.CSI != nil {
+			pv.Spec.CSI.Driver = storageClass.Provisioner
+		}
+
+		if _, ok := pv.GetAnnotations()[pvProvisionedByAnnotation]; ok {
+			pv.GetAnnotations()[pvProvisionedByAnnotation] = storageClass.Provisioner
+		}
+	}
+
+	return nil
+}
+
+// pvcsStorageClassMap updates PVCs, protected on a peer cluster, to use the equivalent StorageClass on this
+// cluster
+func (v *VRGInstance) pvcsStorageClassMap(pvcList []corev1.PersistentVolumeClaim) error {
+	for i := range pvcList {
+		pvc := &pvcList[i]
+
+		if pvc.Spec.StorageClassName == nil {
+			continue
+		}
+
+		mappedName := v.storageClassNameMapped(*pvc.Spec.StorageClassName)
+		if mappedName == *pvc.Spec.StorageClassName {
+			continue
+		}
+
+		storageClass, err := v.storageClassGetByName(mappedName)
+		if err != nil {
+			return fmt.Errorf("failed to map StorageClass of PVC %s/%s (%w)", pvc.GetNamespace(), pvc.GetName(), err)
+		}
+
+		v.log.Info("Mapping PVC StorageClass", "PVC", pvc.GetNamespace()+"/"+pvc.GetName(),
+			"from", *pvc.Spec.StorageClassName, "to", mappedName)
+
+		pvc.Spec.StorageClassName = &mappedName
+
+		for _, key := range []string{pvcStorageProvisionerAnnotation, pvcStorageProvisionerAnnotationOld} {
+			if _, ok := pvc.GetAnnotations()[key]; ok {
+				pvc.GetAnnotations()[key] = storageClass.Provisioner
+			}
+		}
+	}
+
+	return nil
+}
+
+// rdSpecStorageClassMapped returns a copy of the VolSync RDSpec of a PVC, protected on a peer cluster, that uses
+// the equivalent StorageClass on this cluster for the destination and restored PVCs
+func (v *VRGInstance) rdSpecStorageClassMapped(
+	rdSpec ramendrv1alpha1.VolSyncReplicationDestinationSpec,
+) ramendrv1alpha1.VolSyncReplicationDestinationSpec {
+	if rdSpec.ProtectedPVC.StorageClassName == nil {
+		return rdSpec
+	}
+
+	mappedName := v.storageClassNameMapped(*rdSpec.ProtectedPVC.StorageClassName)
+	rdSpec.ProtectedPVC.StorageClassName = &mappedName
+
+	return rdSpec
+}
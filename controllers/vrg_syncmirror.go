@@ -0,0 +1,228 @@
+// SPDX-FileCopyrightText: The RamenDR authors
+// SPDX-License-Identifier: Apache-2.0
+
+package controllers
+
+import (
+	"fmt"
+	"strings"
+
+	volrep "github.com/csi-addons/kubernetes-csi-addons/apis/replication.storage/v1alpha1"
+	volrepController "github.com/csi-addons/kubernetes-csi-addons/controllers/replication.storage"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	ramendrv1alpha1 "github.com/ramendr/ramen/api/v1alpha1"
+)
+
+const (
+	// VolumeReplicationClass parameter, and its value, that a storage backend sets to report that the volumes of
+	// its provisioner are synchronously mirrored to the peer cluster
+	VRCParameterMirroringMode = "mirroringMode"
+	VRCMirroringModeSync      = "sync"
+)
+
+// updateProtectedPVCsForSync updates the list of ProtectedPVCs with the passed in PVC, protected by synchronous
+// mirroring of its storage. The StorageIdentifiers are recorded from its StorageClass and, if found, from the
+// VolumeReplicationClass of its provisioner.
+func (v *VRGInstance) updateProtectedPVCsForSync(pvc *corev1.PersistentVolumeClaim) error {
+	pvcNamespacedName := types.NamespacedName{Name: pvc.Name, Namespace: pvc.Namespace}
+
+	storageClass, err := v.getStorageClass(pvcNamespacedName)
+	if err != nil {
+		return fmt.Errorf("failed to get the storageclass for pvc %s (%w)", pvcNamespacedName, err)
+	}
+
+	volumeReplicationClass, err := v.selectSyncVolumeReplicationClass(pvcNamespacedName)
+	if err != nil {
+		v.log.Info("Failed to select VolumeReplicationClass", "pvc", pvcNamespacedName.String(), "error", err.Error())
+	}
+
+	protectedPVC := v.findProtectedPVC(pvc.GetNamespace(), pvc.GetName())
+	if protectedPVC == nil {
+		protectedPVC = &ramendrv1alpha1.ProtectedPVC{Namespace: pvc.GetNamespace(), Name: pvc.GetName()}
+		v.instance.Status.ProtectedPVCs = append(v.instance.Status.ProtectedPVCs, *protectedPVC)
+		protectedPVC = &v.instance.Status.ProtectedPVCs[len(v.instance.Status.ProtectedPVCs)-1]
+	}
+
+	protectedPVC.ProtectedByVolSync = false
+	protectedPVC.StorageClassName = pvc.Spec.StorageClassName
+	protectedPVC.Labels = pvc.Labels
+	protectedPVC.AccessModes = pvc.Spec.AccessModes
+	protectedPVC.Resources = pvc.Spec.Resources
+	protectedPVC.StorageIdentifiers = ramendrv1alpha1.StorageIdentifiers{}
+
+	setPVCStorageIdentifiers(protectedPVC, storageClass, volumeReplicationClass)
+
+	return nil
+}
+
+// selectSyncVolumeReplicationClass returns the VolumeReplicationClass, selected by the VRG sync spec, of the PVC's
+// provisioner, preferring one that reports synchronous mirroring. It returns nil if there is none.
+func (v *VRGInstance) selectSyncVolumeReplicationClass(
+	namespacedName types.NamespacedName,
+) (*volrep.VolumeReplicationClass, error) {
+	if !v.vrcUpdated {
+		if err := v.updateReplicationClassList(); err != nil {
+			return nil, fmt.Errorf("failed to get VolumeReplicationClass list")
+		}
+
+		v.vrcUpdated = true
+	}
+
+	storageClass, err := v.getStorageClass(namespacedName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get the storageclass of pvc %s (%w)", namespacedName, err)
+	}
+
+	var selected *volrep.VolumeReplicationClass
+
+	for index := range v.replClassList.Items {
+		replicationClass := &v.replClassList.Items[index]
+		if storageClass.Provisioner != replicationClass.Spec.Provisioner {
+			continue
+		}
+
+		if replicationClass.Spec.Parameters[VRCParameterMirroringMode] == VRCMirroringModeSync {
+			return replicationClass, nil
+		}
+
+		if selected == nil {
+			selected = replicationClass
+		}
+	}
+
+	return selected, nil
+}
+
+// syncMirroringVerify returns an error if the storage reports that the volume of the PVC is not synchronously
+// mirrored, or that its mirroring is not healthy. The mirroring health is reported by the status of a
+// VolumeReplication of the PVC, and the mirroring mode by the VolumeReplicationClass of its provisioner. It returns
+// false, and no error, if the storage reports neither, in which case the mirroring state is unknown.
+func (v *VRGInstance) syncMirroringVerify(namespacedName types.NamespacedName) (bool, error) {
+	const known = true
+
+	healthKnown, err := v.syncMirroringHealthVerify(namespacedName)
+	if err != nil {
+		return known, err
+	}
+
+	volumeReplicationClass, err := v.selectSyncVolumeReplicationClass(namespacedName)
+	if err != nil {
+		return !known, err
+	}
+
+	modeKnown, err := vrcSyncMirroringModeVerify(volumeReplicationClass)
+	if err != nil {
+		return known, fmt.Errorf("pvc %s: %w", namespacedName, err)
+	}
+
+	if !modeKnown {
+		return healthKnown, nil
+	}
+
+	protectedPVC := v.findProtectedPVC(namespacedName.Namespace, namespacedName.Name)
+	if protectedPVC == nil {
+		return known, fmt.Errorf("pvc %s is not protected", namespacedName)
+	}
+
+	if protectedPVC.StorageIdentifiers.StorageID.ID == "" {
+		return known, fmt.Errorf("StorageClass of pvc %s is missing label %s", namespacedName, StorageIDLabel)
+	}
+
+	if protectedPVC.StorageIdentifiers.ReplicationID.ID == "" {
+		return known, fmt.Errorf("VolumeReplicationClass of pvc %s is missing label %s", namespacedName,
+			VolumeReplicationIDLabel)
+	}
+
+	return known, nil
+}
+
+// vrcSyncMirroringModeVerify returns an error if the VolumeReplicationClass reports a mirroring mode other than
+// synchronous. It returns false, and no error, if there is no VolumeReplicationClass, or it does not report a
+// mirroring mode.
+func vrcSyncMirroringModeVerify(volumeReplicationClass *volrep.VolumeReplicationClass) (bool, error) {
+	if volumeReplicationClass == nil {
+		return false, nil
+	}
+
+	mirroringMode, ok := volumeReplicationClass.Spec.Parameters[VRCParameterMirroringMode]
+	if !ok {
+		return false, nil
+	}
+
+	if mirroringMode != VRCMirroringModeSync {
+		return true, fmt.Errorf("VolumeReplicationClass %s reports mirroring mode %s",
+			volumeReplicationClass.GetName(), mirroringMode)
+	}
+
+	return true, nil
+}
+
+// syncMirroringHealthVerify returns an error if the status of the VolumeReplication of the PVC, for its current
+// generation, reports the mirroring as degraded or resyncing. It returns false, and no error, if there is no such
+// status to report the mirroring health.
+func (v *VRGInstance) syncMirroringHealthVerify(namespacedName types.NamespacedName) (bool, error) {
+	const known = true
+
+	volRep := &volrep.VolumeReplication{}
+	if err := v.reconciler.Get(v.ctx, namespacedName, volRep); err != nil {
+		if !k8serrors.IsNotFound(err) && !meta.IsNoMatchError(err) {
+			v.log.Info("Failed to get VolumeReplication", "pvc", namespacedName.String(), "error", err.Error())
+		}
+
+		return !known, nil
+	}
+
+	healthKnown, err := vrSyncMirroringHealthVerify(volRep)
+	if err != nil {
+		return known, fmt.Errorf("pvc %s: %w", namespacedName, err)
+	}
+
+	return healthKnown, nil
+}
+
+// vrSyncMirroringHealthVerify returns an error if the status of the VolumeReplication, for its current generation,
+// reports the mirroring as degraded or resyncing. It returns false, and no error, if the status is not current.
+func vrSyncMirroringHealthVerify(volRep *volrep.VolumeReplication) (bool, error) {
+	if volRep.Status.ObservedGeneration != volRep.Generation {
+		return false, nil
+	}
+
+	for _, conditionType := range []string{volrepController.ConditionDegraded, volrepController.ConditionResyncing} {
+		if met, _ := isVRConditionMet(volRep, volRep.Status.Conditions, conditionType, metav1.ConditionTrue); met {
+			return true, fmt.Errorf("VolumeReplication reports mirroring %s: %s", strings.ToLower(conditionType),
+				findCondition(volRep.Status.Conditions, conditionType).Message)
+		}
+	}
+
+	return true, nil
+}
+
+// updatePVCDataProtectedConditionForSync sets the PVC DataProtected condition with the passed in reason and message
+// if the storage reports healthy synchronous mirroring of the PVC, to unknown if the storage does not report its
+// mirroring at all, and to an error otherwise
+func (v *VRGInstance) updatePVCDataProtectedConditionForSync(namespacedName types.NamespacedName,
+	reason, message string,
+) {
+	known, err := v.syncMirroringVerify(namespacedName)
+	if err != nil {
+		v.log.Info("Synchronous mirroring not verified", "pvc", namespacedName.String(), "error", err.Error())
+		v.updatePVCDataProtectedCondition(namespacedName.Namespace, namespacedName.Name, VRGConditionReasonError,
+			fmt.Sprintf("Synchronous mirroring not verified: %v", err))
+
+		return
+	}
+
+	if !known {
+		v.updatePVCDataProtectedCondition(namespacedName.Namespace, namespacedName.Name,
+			VRGConditionReasonMirroringUnknown, "Synchronous mirroring state not reported by the storage")
+
+		return
+	}
+
+	v.updatePVCDataProtectedCondition(namespacedName.Namespace, namespacedName.Name, reason, message)
+}
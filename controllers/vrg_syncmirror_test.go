@@ -0,0 +1,208 @@
+// SPDX-FileCopyrightText: The RamenDR authors
+// SPDX-License-Identifier: Apache-2.0
+
+// white box testing desired for synchronous mirroring verification
+package controllers //nolint: testpackage
+
+import (
+	"time"
+
+	volrep "github.com/csi-addons/kubernetes-csi-addons/apis/replication.storage/v1alpha1"
+	volrepController "github.com/csi-addons/kubernetes-csi-addons/controllers/replication.storage"
+	"github.com/go-logr/logr"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	ramen "github.com/ramendr/ramen/api/v1alpha1"
+)
+
+var _ = Describe("VRG_SyncMirroring", func() {
+	Context("vrcSyncMirroringModeVerify", func() {
+		vrc := func(parameters map[string]string) *volrep.VolumeReplicationClass {
+			return &volrep.VolumeReplicationClass{
+				ObjectMeta: metav1.ObjectMeta{Name: "vrc"},
+				Spec:       volrep.VolumeReplicationClassSpec{Provisioner: "p", Parameters: parameters},
+			}
+		}
+
+		It("reports an unknown mode without a VolumeReplicationClass", func() {
+			known, err := vrcSyncMirroringModeVerify(nil)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(known).To(BeFalse())
+		})
+		It("reports an unknown mode for a VolumeReplicationClass without the mirroring mode parameter", func() {
+			known, err := vrcSyncMirroringModeVerify(vrc(map[string]string{"other": "value"}))
+			Expect(err).ToNot(HaveOccurred())
+			Expect(known).To(BeFalse())
+		})
+		It("verifies a synchronous mirroring mode", func() {
+			known, err := vrcSyncMirroringModeVerify(vrc(map[string]string{
+				VRCParameterMirroringMode: VRCMirroringModeSync,
+			}))
+			Expect(err).ToNot(HaveOccurred())
+			Expect(known).To(BeTrue())
+		})
+		It("fails any other mirroring mode", func() {
+			known, err := vrcSyncMirroringModeVerify(vrc(map[string]string{VRCParameterMirroringMode: "async"}))
+			Expect(err).To(HaveOccurred())
+			Expect(known).To(BeTrue())
+		})
+	})
+
+	Context("vrSyncMirroringHealthVerify", func() {
+		vr := func(generation int64, conditions ...metav1.Condition) *volrep.VolumeReplication {
+			return &volrep.VolumeReplication{
+				ObjectMeta: metav1.ObjectMeta{Name: "pvc", Namespace: "ns", Generation: generation},
+				Status: volrep.VolumeReplicationStatus{
+					ObservedGeneration: 2,
+					Conditions:         conditions,
+				},
+			}
+		}
+		condition := func(conditionType string, status metav1.ConditionStatus) metav1.Condition {
+			return metav1.Condition{
+				Type:               conditionType,
+				Status:             status,
+				ObservedGeneration: 2,
+				Message:            conditionType + " message",
+			}
+		}
+
+		It("reports an unknown health for a status of a previous generation", func() {
+			known, err := vrSyncMirroringHealthVerify(vr(3,
+				condition(volrepController.ConditionDegraded, metav1.ConditionTrue)))
+			Expect(err).ToNot(HaveOccurred())
+			Expect(known).To(BeFalse())
+		})
+		It("verifies a mirroring that is neither degraded nor resyncing", func() {
+			known, err := vrSyncMirroringHealthVerify(vr(2,
+				condition(volrepController.ConditionDegraded, metav1.ConditionFalse),
+				condition(volrepController.ConditionResyncing, metav1.ConditionFalse)))
+			Expect(err).ToNot(HaveOccurred())
+			Expect(known).To(BeTrue())
+		})
+		It("fails a degraded mirroring", func() {
+			known, err := vrSyncMirroringHealthVerify(vr(2,
+				condition(volrepController.ConditionDegraded, metav1.ConditionTrue)))
+			Expect(err).To(MatchError(ContainSubstring("Degraded message")))
+			Expect(known).To(BeTrue())
+		})
+		It("fails a resyncing mirroring", func() {
+			known, err := vrSyncMirroringHealthVerify(vr(2,
+				condition(volrepController.ConditionDegraded, metav1.ConditionFalse),
+				condition(volrepController.ConditionResyncing, metav1.ConditionTrue)))
+			Expect(err).To(MatchError(ContainSubstring("Resyncing message")))
+			Expect(known).To(BeTrue())
+		})
+	})
+
+	Context("aggregateVolRepDataProtectedCondition", func() {
+		pvcCondition := func(reason string) []metav1.Condition {
+			protectedPVC := &ramen.ProtectedPVC{}
+			setPVCDataProtectedCondition(protectedPVC, reason, reason+" message", 1)
+
+			return protectedPVC.Conditions
+		}
+		aggregate := func(reasons ...string) *metav1.Condition {
+			v := &VRGInstance{
+				instance: &ramen.VolumeReplicationGroup{
+					ObjectMeta: metav1.ObjectMeta{Generation: 1},
+					Spec:       ramen.VolumeReplicationGroupSpec{Sync: &ramen.VRGSyncSpec{}},
+				},
+				volRepPVCs: make([]corev1.PersistentVolumeClaim, len(reasons)),
+				log:        logr.Discard(),
+			}
+
+			for _, reason := range reasons {
+				v.instance.Status.ProtectedPVCs = append(v.instance.Status.ProtectedPVCs,
+					ramen.ProtectedPVC{Conditions: pvcCondition(reason)})
+			}
+
+			return v.aggregateVolRepDataProtectedCondition()
+		}
+
+		It("reports the data protection unknown if the storage does not report the mirroring of a PVC", func() {
+			condition := aggregate(VRGConditionReasonDataProtected, VRGConditionReasonMirroringUnknown)
+			Expect(condition.Status).To(Equal(metav1.ConditionUnknown))
+			Expect(condition.Reason).To(Equal(VRGConditionReasonMirroringUnknown))
+		})
+		It("reports an error of a PVC over the unknown mirroring of another", func() {
+			condition := aggregate(VRGConditionReasonMirroringUnknown, VRGConditionReasonError)
+			Expect(condition.Status).To(Equal(metav1.ConditionFalse))
+			Expect(condition.Reason).To(Equal(VRGConditionReasonError))
+		})
+	})
+
+	Context("checkMetroMirroring", func() {
+		const homeCluster = "cluster1"
+
+		vrg := func(statusGeneration int64, lastUpdate time.Time, status metav1.ConditionStatus,
+			reason string,
+		) *ramen.VolumeReplicationGroup {
+			return &ramen.VolumeReplicationGroup{
+				ObjectMeta: metav1.ObjectMeta{Generation: 2},
+				Status: ramen.VolumeReplicationGroupStatus{
+					ObservedGeneration: statusGeneration,
+					LastUpdateTime:     metav1.NewTime(lastUpdate),
+					Conditions: []metav1.Condition{{
+						Type:               VRGConditionTypeDataProtected,
+						Status:             status,
+						Reason:             reason,
+						ObservedGeneration: statusGeneration,
+						Message:            "mirroring message",
+					}},
+				},
+			}
+		}
+		check := func(vrgs map[string]*ramen.VolumeReplicationGroup) (bool, error) {
+			d := &DRPCInstance{vrgs: vrgs, log: logr.Discard()}
+
+			return d.checkMetroMirroring(homeCluster)
+		}
+
+		It("is met if the VRG of the home cluster is not available", func() {
+			met, err := check(map[string]*ramen.VolumeReplicationGroup{})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(met).To(BeTrue())
+		})
+		It("is met if the VRG reports healthy mirroring", func() {
+			met, err := check(map[string]*ramen.VolumeReplicationGroup{
+				homeCluster: vrg(2, time.Now(), metav1.ConditionTrue, VRGConditionReasonDataProtected),
+			})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(met).To(BeTrue())
+		})
+		It("fails if the VRG reports unhealthy mirroring", func() {
+			met, err := check(map[string]*ramen.VolumeReplicationGroup{
+				homeCluster: vrg(2, time.Now(), metav1.ConditionFalse, VRGConditionReasonError),
+			})
+			Expect(err).To(MatchError(ContainSubstring("mirroring message")))
+			Expect(met).To(BeFalse())
+		})
+		It("fails if the VRG reports that the storage does not report the mirroring state", func() {
+			met, err := check(map[string]*ramen.VolumeReplicationGroup{
+				homeCluster: vrg(2, time.Now(), metav1.ConditionUnknown, VRGConditionReasonMirroringUnknown),
+			})
+			Expect(err).To(MatchError(ContainSubstring("unknown")))
+			Expect(met).To(BeFalse())
+		})
+		It("waits for a VRG with a recent status of a previous generation", func() {
+			met, err := check(map[string]*ramen.VolumeReplicationGroup{
+				homeCluster: vrg(1, time.Now(), metav1.ConditionFalse, VRGConditionReasonError),
+			})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(met).To(BeFalse())
+		})
+		It("is met if the VRG status of a previous generation is stale", func() {
+			met, err := check(map[string]*ramen.VolumeReplicationGroup{
+				homeCluster: vrg(1, time.Now().Add(-2*metroMirroringStatusMaxAge), metav1.ConditionFalse,
+					VRGConditionReasonError),
+			})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(met).To(BeTrue())
+		})
+	})
+})
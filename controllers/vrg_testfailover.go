@@ -0,0 +1,253 @@
+// SPDX-FileCopyrightText: The RamenDR authors
+// SPDX-License-Identifier: Apache-2.0
+
+package controllers
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	ramendrv1alpha1 "github.com/ramendr/ramen/api/v1alpha1"
+	"github.com/ramendr/ramen/controllers/kubeobjects"
+	"github.com/ramendr/ramen/controllers/volsync"
+	"golang.org/x/exp/maps"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// reconcileTestFailover restores a copy of the protected PVCs and kube objects into the namespaces mapped by the
+// test failover spec, or tears the copy down once the test failover is no longer requested. Replication into
+// the protected namespaces is not affected by either.
+func (v *VRGInstance) reconcileTestFailover(result *ctrl.Result) {
+	if v.instance.Spec.TestFailover == nil {
+		if err := v.testFailoverCleanup(); err != nil {
+			v.log.Info("Test failover cleanup failed", "error", err)
+
+			result.Requeue = true
+		}
+
+		return
+	}
+
+	if condition := findCondition(v.instance.Status.Conditions, VRGConditionTypeTestFailover); condition != nil &&
+		condition.Status == metav1.ConditionTrue && condition.ObservedGeneration == v.instance.Generation {
+		return
+	}
+
+	if err := v.testFailoverRestore(result); err != nil {
+		reason := VRGConditionReasonError
+
+		switch {
+		case errors.Is(err, kubeobjects.RequestProcessingError{}) || errors.Is(err, errTestFailoverProgressing):
+			reason = VRGConditionReasonProgressing
+		case errors.Is(err, errTestFailoverUnsupported):
+			reason = VRGConditionReasonTestFailoverUnsupported
+		}
+
+		v.log.Info("Test failover not complete", "reason", reason, "error", err)
+		setVRGConditionTypeTestFailover(&v.instance.Status.Conditions, v.instance.Generation,
+			metav1.ConditionFalse, reason, err.Error())
+
+		// An unsupported test failover is retried only once the VRG is updated
+		result.Requeue = result.Requeue || reason != VRGConditionReasonTestFailoverUnsupported
+
+		return
+	}
+
+	setVRGConditionTypeTestFailover(&v.instance.Status.Conditions, v.instance.Generation,
+		metav1.ConditionTrue, VRGConditionReasonTestFailoverRestored,
+		fmt.Sprintf("Restored %d PVCs into test failover namespaces", len(v.instance.Spec.VolSync.RDSpec)))
+}
+
+var (
+	errTestFailoverProgressing = errors.New("waiting for replicated data to restore")
+	errTestFailoverUnsupported = errors.New("test failover is not supported")
+)
+
+func (v *VRGInstance) testFailoverRestore(result *ctrl.Result) error {
+	if v.instance.Spec.ReplicationState != ramendrv1alpha1.Secondary {
+		return fmt.Errorf("test failover is supported only by a Secondary VRG")
+	}
+
+	// A copy of a VolumeReplication protected volume can only be made by promoting the replicated volume, which
+	// would stop its replication, as the storage does not snapshot or clone a secondary volume. Hence only VolSync
+	// protected PVCs are restored.
+	if pvcs := v.testFailoverVolRepPVCs(); len(pvcs) != 0 {
+		return fmt.Errorf("%w for PVCs protected by VolumeReplication, as a copy of their volumes can be made only "+
+			"by promoting them, which would stop their replication: %s", errTestFailoverUnsupported,
+			strings.Join(pvcs, ", "))
+	}
+
+	namespaceMapping := v.instance.Spec.TestFailover.NamespaceMapping
+	if err := v.testFailoverValidate(namespaceMapping); err != nil {
+		return err
+	}
+
+	for _, targetNamespace := range sets.List(sets.New(maps.Values(namespaceMapping)...)) {
+		if err := v.testFailoverNamespaceEnsure(targetNamespace); err != nil {
+			return err
+		}
+	}
+
+	pvcsRestored := 0
+
+	for _, rdSpec := range v.instance.Spec.VolSync.RDSpec {
+		restored, err := v.volSyncHandler.EnsurePVCForTestFailover(v.rdSpecStorageClassMapped(rdSpec),
+			namespaceMapping[rdSpec.ProtectedPVC.Namespace])
+		if err != nil {
+			return err
+		}
+
+		if restored {
+			pvcsRestored++
+		}
+	}
+
+	if pvcsRestored != len(v.instance.Spec.VolSync.RDSpec) {
+		return fmt.Errorf("%w, restored %d of %d PVCs", errTestFailoverProgressing,
+			pvcsRestored, len(v.instance.Spec.VolSync.RDSpec))
+	}
+
+	return v.testFailoverKubeObjectsRecover(result, namespaceMapping)
+}
+
+// testFailoverVolRepPVCs returns the names of the PVCs of this VRG that are protected by VolumeReplication
+func (v *VRGInstance) testFailoverVolRepPVCs() []string {
+	pvcs := sets.New[string]()
+
+	for i := range v.volRepPVCs {
+		pvcs.Insert(v.volRepPVCs[i].Namespace + "/" + v.volRepPVCs[i].Name)
+	}
+
+	for i := range v.instance.Status.ProtectedPVCs {
+		if protectedPVC := &v.instance.Status.ProtectedPVCs[i]; !protectedPVC.ProtectedByVolSync {
+			pvcs.Insert(protectedPVC.Namespace + "/" + protectedPVC.Name)
+		}
+	}
+
+	return sets.List(pvcs)
+}
+
+// testFailoverValidate ensures every namespace with PVCs to restore is mapped, and that no namespace is mapped
+// into a namespace that is protected, as the restored copy would then interfere with the workload
+func (v *VRGInstance) testFailoverValidate(namespaceMapping map[string]string) error {
+	protectedNamespaces := sets.New(v.recipeElements.PvcSelector.NamespaceNames...)
+
+	for _, rdSpec := range v.instance.Spec.VolSync.RDSpec {
+		protectedNamespaces.Insert(rdSpec.ProtectedPVC.Namespace)
+
+		if namespaceMapping[rdSpec.ProtectedPVC.Namespace] == "" {
+			return fmt.Errorf("test failover namespace mapping is missing namespace %s",
+				rdSpec.ProtectedPVC.Namespace)
+		}
+	}
+
+	for sourceNamespace, targetNamespace := range namespaceMapping {
+		if protectedNamespaces.Has(targetNamespace) || targetNamespace == v.instance.Namespace {
+			return fmt.Errorf("test failover namespace mapping %s to %s targets a protected namespace",
+				sourceNamespace, targetNamespace)
+		}
+	}
+
+	return nil
+}
+
+// testFailoverNamespaceEnsure creates the target namespace, labeled so that it is deleted on cleanup. An existing
+// namespace is used only if it was created for a test failover of this VRG.
+func (v *VRGInstance) testFailoverNamespaceEnsure(name string) error {
+	labels := volsync.TestFailoverLabels(v.instance)
+
+	namespace := &corev1.Namespace{}
+	if err := v.reconciler.Get(v.ctx, types.NamespacedName{Name: name}, namespace); err != nil {
+		if !k8serrors.IsNotFound(err) {
+			return fmt.Errorf("failed to get test failover namespace %s (%w)", name, err)
+		}
+
+		namespace = &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: name, Labels: labels}}
+		if err := v.reconciler.Create(v.ctx, namespace); err != nil {
+			return fmt.Errorf("failed to create test failover namespace %s (%w)", name, err)
+		}
+
+		v.log.Info("Created test failover namespace", "namespace", name)
+
+		return nil
+	}
+
+	for key, value := range labels {
+		if namespace.GetLabels()[key] != value {
+			return fmt.Errorf("namespace %s exists and was not created for a test failover of this VRG", name)
+		}
+	}
+
+	return nil
+}
+
+func (v *VRGInstance) testFailoverKubeObjectsRecover(result *ctrl.Result, namespaceMapping map[string]string) error {
+	if v.kubeObjectProtectionDisabled("test failover recovery") {
+		return nil
+	}
+
+	err := errors.New("s3Profiles empty")
+
+	for _, s3ProfileName := range v.instance.Spec.S3Profiles {
+		if s3ProfileName == NoS3StoreAvailable {
+			continue
+		}
+
+		var objectStore ObjectStorer
+
+		var s3StoreProfile ramendrv1alpha1.S3StoreProfile
+
+		objectStore, s3StoreProfile, err = v.reconciler.ObjStoreGetter.ObjectStore(
+			v.ctx, v.reconciler.APIReader, s3ProfileName, v.namespacedName, v.log)
+		if err != nil {
+			v.log.Error(err, "Kube objects test failover recovery object store inaccessible", "profile", s3ProfileName)
+
+			continue
+		}
+
+		return v.kubeObjectsRecover(result, s3StoreProfile, objectStore, namespaceMapping)
+	}
+
+	return err
+}
+
+// testFailoverCleanup deletes the resources and namespaces created for a test failover of this VRG
+func (v *VRGInstance) testFailoverCleanup() error {
+	if findCondition(v.instance.Status.Conditions, VRGConditionTypeTestFailover) == nil {
+		return nil
+	}
+
+	namespaces := &corev1.NamespaceList{}
+	if err := v.reconciler.List(v.ctx, namespaces,
+		client.MatchingLabels(volsync.TestFailoverLabels(v.instance))); err != nil {
+		return fmt.Errorf("failed to list test failover namespaces (%w)", err)
+	}
+
+	namespaceNames := make([]string, 0, len(namespaces.Items))
+	for i := range namespaces.Items {
+		namespaceNames = append(namespaceNames, namespaces.Items[i].GetName())
+	}
+
+	if err := v.volSyncHandler.CleanupTestFailover(namespaceNames); err != nil {
+		return err
+	}
+
+	for i := range namespaces.Items {
+		if err := v.reconciler.Delete(v.ctx, &namespaces.Items[i]); err != nil && !k8serrors.IsNotFound(err) {
+			return fmt.Errorf("failed to delete test failover namespace %s (%w)", namespaces.Items[i].GetName(), err)
+		}
+	}
+
+	meta.RemoveStatusCondition(&v.instance.Status.Conditions, VRGConditionTypeTestFailover)
+	v.log.Info("Test failover cleaned up", "namespaces", namespaceNames)
+
+	return nil
+}
@@ -0,0 +1,457 @@
+// SPDX-FileCopyrightText: The RamenDR authors
+// SPDX-License-Identifier: Apache-2.0
+
+package controllers
+
+import (
+	"context"
+	"crypto/md5"
+	"encoding/hex"
+	"fmt"
+	"strings"
+
+	volrep "github.com/csi-addons/kubernetes-csi-addons/apis/replication.storage/v1alpha1"
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/validation"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	rmnutil "github.com/ramendr/ramen/controllers/util"
+)
+
+// VolumeGroupReplication resources are defined by csi-addons in the same API group as VolumeReplication resources.
+// They are handled as unstructured objects, as the csi-addons API version Ramen builds with does not define them.
+const (
+	VolumeGroupReplicationKind      = "VolumeGroupReplication"
+	VolumeGroupReplicationClassKind = "VolumeGroupReplicationClass"
+
+	// PVC label whose value is the name of the VolumeGroupReplication resource that replicates the PVC
+	ConsistencyGroupLabel = "ramendr.openshift.io/consistency-group"
+)
+
+func volGroupRepNew() *unstructured.Unstructured {
+	vgr := &unstructured.Unstructured{}
+	vgr.SetGroupVersionKind(volrep.GroupVersion.WithKind(VolumeGroupReplicationKind))
+
+	return vgr
+}
+
+func volGroupRepClassListNew() *unstructured.UnstructuredList {
+	vgrClassList := &unstructured.UnstructuredList{}
+	vgrClassList.SetGroupVersionKind(volrep.GroupVersion.WithKind(VolumeGroupReplicationClassKind + "List"))
+
+	return vgrClassList
+}
+
+func (r *VolumeReplicationGroupReconciler) addVolGroupRepWatches(ctrlBuilder *builder.Builder) *builder.Builder {
+	return ctrlBuilder.Watches(volGroupRepNew(),
+		handler.EnqueueRequestsFromMapFunc(r.VGRMapFunc),
+		builder.WithPredicates(rmnutil.CreateOrDeleteOrResourceVersionUpdatePredicate{}),
+	)
+}
+
+func (r *VolumeReplicationGroupReconciler) VGRMapFunc(ctx context.Context, obj client.Object) []reconcile.Request {
+	log := ctrl.Log.WithName("vgrmap").WithName("VolumeReplicationGroup")
+
+	return filterVRGDependentObjects(r.Client, obj,
+		log.WithValues("vgr", types.NamespacedName{Name: obj.GetName(), Namespace: obj.GetNamespace()}))
+}
+
+// volGroupRepEnabled returns true if the VRG replicates its VolRep PVCs in consistency groups
+func (v *VRGInstance) volGroupRepEnabled() bool {
+	return v.ramenConfig.VolumeGroupReplication.FeatureEnabled &&
+		v.instance.Spec.Async != nil &&
+		v.instance.Spec.Async.VolumeGroupReplicationClassSelector != nil
+}
+
+// volGroupRepName returns the name of the VolumeGroupReplication resource for PVCs of a StorageClass that are
+// replicated using a VolumeReplicationClass
+func (v *VRGInstance) volGroupRepName(storageClassName, volumeReplicationClassName string) string {
+	return VolGroupRepName(v.instance.Name, storageClassName, volumeReplicationClassName)
+}
+
+// VolGroupRepName returns the name of the VolumeGroupReplication resource of a VRG for PVCs of a StorageClass that
+// are replicated using a VolumeReplicationClass. The name is also the value of the ConsistencyGroupLabel of the
+// PVCs, so it is kept to the label value length limit: the VRG name is truncated, and the whole of the VRG,
+// StorageClass and VolumeReplicationClass names hashed, should it be too long to be suffixed as is.
+func VolGroupRepName(vrgName, storageClassName, volumeReplicationClassName string) string {
+	hash := md5.Sum([]byte(storageClassName + "/" + volumeReplicationClassName))
+
+	name := vrgName + "-" + hex.EncodeToString(hash[:4])
+	if len(name) <= validation.LabelValueMaxLength {
+		return name
+	}
+
+	hash = md5.Sum([]byte(vrgName + "/" + storageClassName + "/" + volumeReplicationClassName))
+	suffix := "-" + hex.EncodeToString(hash[:8])
+
+	return strings.TrimRight(vrgName[:validation.LabelValueMaxLength-len(suffix)], "-._") + suffix
+}
+
+func (v *VRGInstance) volRepPVCGet(pvcNamespacedName types.NamespacedName) *corev1.PersistentVolumeClaim {
+	for idx := range v.volRepPVCs {
+		pvc := &v.volRepPVCs[idx]
+		if pvc.Namespace == pvcNamespacedName.Namespace && pvc.Name == pvcNamespacedName.Name {
+			return pvc
+		}
+	}
+
+	return nil
+}
+
+// selectVolumeGroupReplicationClass returns the name of the VolumeGroupReplicationClass that matches the
+// provisioner of the pvc's StorageClass and the pvc's schedule
+func (v *VRGInstance) selectVolumeGroupReplicationClass(pvcNamespacedName types.NamespacedName) (string, error) {
+	storageClass, err := v.getStorageClass(pvcNamespacedName)
+	if err != nil {
+		return "", err
+	}
+
+	pvcSchedulingInterval, err := v.pvcSchedulingInterval(v.volRepPVCGet(pvcNamespacedName))
+	if err != nil {
+		return "", err
+	}
+
+	labelSelector := v.instance.Spec.Async.VolumeGroupReplicationClassSelector
+
+	selector, err := metav1.LabelSelectorAsSelector(labelSelector)
+	if err != nil {
+		return "", fmt.Errorf("error with VolumeGroupReplicationClass selector, %w", err)
+	}
+
+	vgrClassList := volGroupRepClassListNew()
+	if err := v.reconciler.List(v.ctx, vgrClassList, client.MatchingLabelsSelector{Selector: selector}); err != nil {
+		return "", fmt.Errorf("failed to list VolumeGroupReplicationClasses, %w", err)
+	}
+
+	for _, vgrClass := range vgrClassList.Items {
+		provisioner, _, _ := unstructured.NestedString(vgrClass.Object, "spec", "provisioner")
+		if provisioner != storageClass.Provisioner {
+			continue
+		}
+
+		schedulingInterval, _, _ := unstructured.NestedString(vgrClass.Object, "spec", "parameters", "schedulingInterval")
+		if v.replicationClassMatchesSchedule(vgrClass.GetAnnotations(), schedulingInterval, pvcSchedulingInterval) {
+			return vgrClass.GetName(), nil
+		}
+	}
+
+	return "", fmt.Errorf("no VolumeGroupReplicationClass found to match provisioner and schedule %s/%s",
+		storageClass.Provisioner, pvcSchedulingInterval)
+}
+
+// processVGR processes the VolumeGroupReplication resource that replicates a pvc, to change its state to the
+// desired state, and updates the pvc's status from the group's status. It returns the same values as
+// createOrUpdateVR.
+func (v *VRGInstance) processVGR(pvcNamespacedName types.NamespacedName, state volrep.ReplicationState,
+	log logr.Logger,
+) (bool, bool, error) {
+	const requeue = true
+
+	pvc := v.volRepPVCGet(pvcNamespacedName)
+	if pvc == nil || pvc.Spec.StorageClassName == nil {
+		return requeue, false, fmt.Errorf("failed to get the storageclass of pvc %s", pvcNamespacedName)
+	}
+
+	volumeReplicationClass, err := v.selectVolumeReplicationClass(pvcNamespacedName)
+	if err != nil {
+		return requeue, false, fmt.Errorf("failed to find the appropriate VolumeReplicationClass (%s) %w",
+			v.instance.Name, err)
+	}
+
+	vgrNamespacedName := types.NamespacedName{
+		Namespace: pvc.Namespace,
+		Name:      v.volGroupRepName(*pvc.Spec.StorageClassName, volumeReplicationClass.GetName()),
+	}
+	log = log.WithValues("vgr", vgrNamespacedName.String())
+
+	if err := v.pvcConsistencyGroupLabelSet(pvc, vgrNamespacedName.Name, log); err != nil {
+		msg := "Failed to add consistency group label to PVC"
+		v.updatePVCDataReadyCondition(pvc.Namespace, pvc.Name, VRGConditionReasonError, msg)
+
+		return requeue, false, err
+	}
+
+	vgr := volGroupRepNew()
+
+	err = v.reconciler.Get(v.ctx, vgrNamespacedName, vgr)
+	if err != nil {
+		if !k8serrors.IsNotFound(err) {
+			msg := "Failed to get VolumeGroupReplication resource"
+			v.updatePVCDataReadyCondition(pvc.Namespace, pvc.Name, VRGConditionReasonErrorUnknown, msg)
+
+			return requeue, false, fmt.Errorf("failed to get VolumeGroupReplication resource (%s), %w",
+				vgrNamespacedName, err)
+		}
+
+		if err := v.createVGR(vgrNamespacedName, pvcNamespacedName, volumeReplicationClass.GetName(), state,
+			log); err != nil {
+			rmnutil.ReportIfNotPresent(v.reconciler.eventRecorder, v.instance, corev1.EventTypeWarning,
+				rmnutil.EventReasonVRCreateFailed, err.Error())
+
+			msg := "Failed to create VolumeGroupReplication resource"
+			v.updatePVCDataReadyCondition(pvc.Namespace, pvc.Name, VRGConditionReasonError, msg)
+
+			return requeue, false, err
+		}
+
+		msg := "Created VolumeGroupReplication resource for PVC"
+		v.updatePVCDataReadyCondition(pvc.Namespace, pvc.Name, VRGConditionReasonProgressing, msg)
+
+		return !requeue, false, nil
+	}
+
+	return v.updateVGR(vgr, pvc, state, log)
+}
+
+func (v *VRGInstance) pvcConsistencyGroupLabelSet(pvc *corev1.PersistentVolumeClaim, vgrName string,
+	log logr.Logger,
+) error {
+	if pvc.GetLabels()[ConsistencyGroupLabel] == vgrName {
+		return nil
+	}
+
+	labels := pvc.GetLabels()
+	if labels == nil {
+		labels = map[string]string{}
+	}
+
+	labels[ConsistencyGroupLabel] = vgrName
+	pvc.SetLabels(labels)
+
+	if err := v.reconciler.Update(v.ctx, pvc); err != nil {
+		return fmt.Errorf("failed to update PVC (%s/%s) consistency group label, %w", pvc.Namespace, pvc.Name, err)
+	}
+
+	log.Info("Added consistency group label to PVC", "pvc", pvc.Name)
+
+	return nil
+}
+
+func (v *VRGInstance) createVGR(vgrNamespacedName, pvcNamespacedName types.NamespacedName,
+	volumeReplicationClassName string, state volrep.ReplicationState, log logr.Logger,
+) error {
+	vgrClassName, err := v.selectVolumeGroupReplicationClass(pvcNamespacedName)
+	if err != nil {
+		return err
+	}
+
+	vgr := volGroupRepNew()
+	vgr.SetNamespace(vgrNamespacedName.Namespace)
+	vgr.SetName(vgrNamespacedName.Name)
+	vgr.SetLabels(rmnutil.OwnerLabels(v.instance))
+	vgr.Object["spec"] = map[string]interface{}{
+		"volumeGroupReplicationClassName": vgrClassName,
+		"volumeReplicationClassName":      volumeReplicationClassName,
+		"replicationState":                string(state),
+		"autoResync":                      v.autoResync(state),
+		"source": map[string]interface{}{
+			"selector": map[string]interface{}{
+				"matchLabels": map[string]interface{}{
+					ConsistencyGroupLabel: vgrNamespacedName.Name,
+				},
+			},
+		},
+	}
+
+	if !vrgInAdminNamespace(v.instance, v.ramenConfig) {
+		if err := ctrl.SetControllerReference(v.instance, vgr, v.reconciler.Scheme); err != nil {
+			return fmt.Errorf("failed to set owner reference to VolumeGroupReplication resource (%s), %w",
+				vgrNamespacedName, err)
+		}
+	}
+
+	log.Info("Creating VolumeGroupReplication resource", "class", vgrClassName, "state", state)
+
+	if err := v.reconciler.Create(v.ctx, vgr); err != nil && !k8serrors.IsAlreadyExists(err) {
+		return fmt.Errorf("failed to create VolumeGroupReplication resource (%s), %w", vgrNamespacedName, err)
+	}
+
+	return nil
+}
+
+func (v *VRGInstance) updateVGR(vgr *unstructured.Unstructured, pvc *corev1.PersistentVolumeClaim,
+	state volrep.ReplicationState, log logr.Logger,
+) (bool, bool, error) {
+	const requeue = true
+
+	currentState, _, _ := unstructured.NestedString(vgr.Object, "spec", "replicationState")
+	currentAutoResync, _, _ := unstructured.NestedBool(vgr.Object, "spec", "autoResync")
+
+	if currentState == string(state) && currentAutoResync == v.autoResync(state) {
+		return !requeue, v.checkVGRStatus(vgr, pvc, log), nil
+	}
+
+	if err := unstructured.SetNestedField(vgr.Object, string(state), "spec", "replicationState"); err != nil {
+		return requeue, false, err
+	}
+
+	if err := unstructured.SetNestedField(vgr.Object, v.autoResync(state), "spec", "autoResync"); err != nil {
+		return requeue, false, err
+	}
+
+	if err := v.reconciler.Update(v.ctx, vgr); err != nil {
+		rmnutil.ReportIfNotPresent(v.reconciler.eventRecorder, v.instance, corev1.EventTypeWarning,
+			rmnutil.EventReasonVRUpdateFailed, err.Error())
+
+		msg := "Failed to update VolumeGroupReplication resource"
+		v.updatePVCDataReadyCondition(pvc.Namespace, pvc.Name, VRGConditionReasonError, msg)
+
+		return requeue, false, fmt.Errorf("failed to update VolumeGroupReplication resource (%s/%s) as %s, %w",
+			vgr.GetNamespace(), vgr.GetName(), state, err)
+	}
+
+	log.Info("Updated VolumeGroupReplication resource", "state", state)
+
+	msg := "Updated VolumeGroupReplication resource for PVC"
+	v.updatePVCDataReadyCondition(pvc.Namespace, pvc.Name, VRGConditionReasonProgressing, msg)
+
+	return !requeue, false, nil
+}
+
+// checkVGRStatus checks if the VolumeGroupReplication resource has the desired status for the current
+// generation, and reports it in the status of the pvc, as a VolumeReplication resource's status would be
+func (v *VRGInstance) checkVGRStatus(vgr *unstructured.Unstructured, pvc *corev1.PersistentVolumeClaim,
+	log logr.Logger,
+) bool {
+	status, included, err := VolGroupRepStatus(vgr, pvc.Name)
+	if err != nil {
+		log.Info("Failed to convert VolumeGroupReplication status", "error", err)
+
+		msg := "VolumeGroupReplication resource status invalid"
+		v.updatePVCDataReadyCondition(pvc.Namespace, pvc.Name, VRGConditionReasonError, msg)
+
+		return false
+	}
+
+	if !included {
+		msg := "PVC not yet part of VolumeGroupReplication resource status"
+		v.updatePVCDataReadyCondition(pvc.Namespace, pvc.Name, VRGConditionReasonProgressing, msg)
+
+		return false
+	}
+
+	// The group's status applies to each of its PVCs
+	return v.checkVRStatus(types.NamespacedName{Namespace: pvc.Namespace, Name: pvc.Name}, vgr, status)
+}
+
+// VolGroupRepStatus returns the replication status of a VolumeGroupReplication resource, which has the same
+// fields as that of a VolumeReplication resource, and whether its status lists a pvc as a member of the group
+func VolGroupRepStatus(vgr *unstructured.Unstructured, pvcName string,
+) (*volrep.VolumeReplicationStatus, bool, error) {
+	status := &volrep.VolumeReplicationStatus{}
+
+	statusMap, found, err := unstructured.NestedMap(vgr.Object, "status")
+	if err != nil {
+		return nil, false, err
+	}
+
+	if !found {
+		return status, false, nil
+	}
+
+	if err := runtime.DefaultUnstructuredConverter.FromUnstructured(statusMap, status); err != nil {
+		return nil, false, err
+	}
+
+	pvcRefs, _, _ := unstructured.NestedSlice(statusMap, "persistentVolumeClaimsRefList")
+
+	for _, pvcRef := range pvcRefs {
+		if ref, ok := pvcRef.(map[string]interface{}); ok && ref["name"] == pvcName {
+			return status, true, nil
+		}
+	}
+
+	return status, false, nil
+}
+
+// reconcileMissingVGR is reconcileMissingVR for a pvc replicated by a VolumeGroupReplication resource
+func (v *VRGInstance) reconcileMissingVGR(pvc *corev1.PersistentVolumeClaim, log logr.Logger) (bool, bool) {
+	const (
+		requeue   = true
+		vrMissing = true
+	)
+
+	vgrName := pvc.GetLabels()[ConsistencyGroupLabel]
+	if vgrName != "" {
+		vgr := volGroupRepNew()
+
+		err := v.reconciler.Get(v.ctx, types.NamespacedName{Namespace: pvc.Namespace, Name: vgrName}, vgr)
+		if err == nil {
+			return !vrMissing, !requeue
+		}
+
+		if !k8serrors.IsNotFound(err) {
+			log.Info("Requeuing due to failure in getting VGR resource", "errorValue", err)
+
+			return !vrMissing, requeue
+		}
+	}
+
+	log.Info("Preparing PVC as VGR is detected as missing or deleted")
+
+	if err := v.preparePVCForVRDeletion(pvc, log); err != nil {
+		log.Info("Requeuing due to failure in preparing PersistentVolumeClaim for deletion",
+			"errorValue", err)
+
+		return vrMissing, requeue
+	}
+
+	return vrMissing, !requeue
+}
+
+// pvcRemoveFromVGR removes a pvc from its consistency group, and deletes the group's VolumeGroupReplication
+// resource if the pvc is its last member
+func (v *VRGInstance) pvcRemoveFromVGR(pvc *corev1.PersistentVolumeClaim, log logr.Logger) error {
+	vgrName := pvc.GetLabels()[ConsistencyGroupLabel]
+	if vgrName == "" {
+		return nil
+	}
+
+	pvcList := &corev1.PersistentVolumeClaimList{}
+	if err := v.reconciler.List(v.ctx, pvcList, client.InNamespace(pvc.Namespace),
+		client.MatchingLabels{ConsistencyGroupLabel: vgrName}); err != nil {
+		return fmt.Errorf("failed to list PVCs of consistency group %s, %w", vgrName, err)
+	}
+
+	if len(pvcList.Items) <= 1 {
+		vgr := volGroupRepNew()
+		vgr.SetNamespace(pvc.Namespace)
+		vgr.SetName(vgrName)
+
+		if err := v.reconciler.Delete(v.ctx, vgr); err != nil && !k8serrors.IsNotFound(err) {
+			return fmt.Errorf("failed to delete VolumeGroupReplication resource (%s/%s), %w",
+				pvc.Namespace, vgrName, err)
+		}
+
+		log.Info("Deleted VolumeGroupReplication resource", "vgr", vgrName)
+	}
+
+	delete(pvc.Labels, ConsistencyGroupLabel)
+
+	if err := v.reconciler.Update(v.ctx, pvc); err != nil {
+		return fmt.Errorf("failed to update PVC (%s/%s) to remove consistency group label, %w",
+			pvc.Namespace, pvc.Name, err)
+	}
+
+	return nil
+}
+
+// deleteVROrRemoveFromVGR deletes the VolumeReplication resource of a pvc, or removes the pvc from its
+// VolumeGroupReplication resource
+func (v *VRGInstance) deleteVROrRemoveFromVGR(pvc *corev1.PersistentVolumeClaim, log logr.Logger) error {
+	if v.volGroupRepEnabled() {
+		return v.pvcRemoveFromVGR(pvc, log)
+	}
+
+	return v.deleteVR(types.NamespacedName{Name: pvc.Name, Namespace: pvc.Namespace}, log)
+}
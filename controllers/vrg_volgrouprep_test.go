@@ -0,0 +1,91 @@
+// SPDX-FileCopyrightText: The RamenDR authors
+// SPDX-License-Identifier: Apache-2.0
+
+package controllers_test
+
+import (
+	"strings"
+
+	volrepController "github.com/csi-addons/kubernetes-csi-addons/controllers/replication.storage"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/util/validation"
+
+	"github.com/ramendr/ramen/controllers"
+)
+
+var _ = Describe("VolumeGroupReplication", func() {
+	Context("VolGroupRepName", func() {
+		It("suffixes a short VRG name with a hash of the StorageClass and VolumeReplicationClass", func() {
+			name := controllers.VolGroupRepName("vrg", "sc", "vrc")
+			Expect(name).To(HavePrefix("vrg-"))
+			Expect(name).To(HaveLen(len("vrg-") + 8))
+			Expect(controllers.VolGroupRepName("vrg", "sc", "vrc")).To(Equal(name))
+			Expect(controllers.VolGroupRepName("vrg", "sc", "vrc2")).ToNot(Equal(name))
+		})
+		It("fits a long VRG name to the label value length limit", func() {
+			vrgName := strings.Repeat("a", validation.LabelValueMaxLength)
+			name := controllers.VolGroupRepName(vrgName, "sc", "vrc")
+			Expect(len(name)).To(BeNumerically("<=", validation.LabelValueMaxLength))
+			Expect(validation.IsValidLabelValue(name)).To(BeEmpty())
+			Expect(controllers.VolGroupRepName(vrgName, "sc", "vrc")).To(Equal(name))
+			Expect(controllers.VolGroupRepName(vrgName+"b", "sc", "vrc")).ToNot(Equal(name))
+			Expect(controllers.VolGroupRepName(vrgName, "sc2", "vrc")).ToNot(Equal(name))
+		})
+	})
+	Context("VolGroupRepStatus", func() {
+		vgrWithStatus := func(status map[string]interface{}) *unstructured.Unstructured {
+			vgr := &unstructured.Unstructured{Object: map[string]interface{}{}}
+			if status != nil {
+				vgr.Object["status"] = status
+			}
+
+			return vgr
+		}
+		It("reports no status and no membership if the status is not yet set", func() {
+			status, included, err := controllers.VolGroupRepStatus(vgrWithStatus(nil), "pvc1")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(included).To(BeFalse())
+			Expect(status.Conditions).To(BeEmpty())
+		})
+		It("decodes the replication status and reports the membership of listed PVCs", func() {
+			vgr := vgrWithStatus(map[string]interface{}{
+				"state":              "Primary",
+				"observedGeneration": int64(2),
+				"conditions": []interface{}{
+					map[string]interface{}{
+						"type":               volrepController.ConditionCompleted,
+						"status":             string(metav1.ConditionTrue),
+						"reason":             "Promoted",
+						"observedGeneration": int64(2),
+						"lastTransitionTime": "2023-01-01T00:00:00Z",
+					},
+				},
+				"persistentVolumeClaimsRefList": []interface{}{
+					map[string]interface{}{"name": "pvc1"},
+					map[string]interface{}{"name": "pvc2"},
+				},
+			})
+			status, included, err := controllers.VolGroupRepStatus(vgr, "pvc2")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(included).To(BeTrue())
+			Expect(string(status.State)).To(Equal("Primary"))
+			Expect(status.ObservedGeneration).To(Equal(int64(2)))
+			Expect(status.Conditions).To(HaveLen(1))
+			Expect(status.Conditions[0].Type).To(Equal(volrepController.ConditionCompleted))
+
+			_, included, err = controllers.VolGroupRepStatus(vgr, "pvc3")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(included).To(BeFalse())
+		})
+		It("fails on a status that does not decode", func() {
+			_, _, err := controllers.VolGroupRepStatus(vgrWithStatus(map[string]interface{}{
+				"observedGeneration": "two",
+			}), "pvc1")
+			Expect(err).To(HaveOccurred())
+		})
+	})
+})
@@ -18,6 +18,7 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	storagev1 "k8s.io/api/storage/v1"
 	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 	ctrl "sigs.k8s.io/controller-runtime"
@@ -62,7 +63,7 @@ func (v *VRGInstance) reconcileVolRepsAsPrimary() {
 		}
 
 		// If VR did not reach primary state, it is fine to still upload the PV and continue processing
-		requeueResult, _, err := v.processVRAsPrimary(pvcNamespacedName, log)
+		requeueResult, vrPrimary, err := v.processVRAsPrimary(pvcNamespacedName, log)
 		if requeueResult {
 			v.requeue()
 		}
@@ -74,6 +75,16 @@ func (v *VRGInstance) reconcileVolRepsAsPrimary() {
 			continue
 		}
 
+		if vrPrimary {
+			if err := v.pvcExpandToRestoreCapacity(pvc, log); err != nil {
+				log.Info("Requeuing due to failure to expand PVC", "errorValue", err)
+
+				v.requeue()
+
+				continue
+			}
+		}
+
 		// Protect the PVC's PV object stored in etcd by uploading it to S3
 		// store(s).  Note that the VRG is responsible only to protect the PV
 		// object of each PVC of the subscription.  However, the PVC object
@@ -211,9 +222,8 @@ func (v *VRGInstance) isPVCInUse(pvc *corev1.PersistentVolumeClaim, log logr.Log
 
 // updateProtectedPVCs updates the list of ProtectedPVCs with the passed in PVC
 func (v *VRGInstance) updateProtectedPVCs(pvc *corev1.PersistentVolumeClaim) error {
-	// IF MetroDR, skip PVC update
 	if v.instance.Spec.Sync != nil {
-		return nil
+		return v.updateProtectedPVCsForSync(pvc)
 	}
 
 	pvcNamespacedName := types.NamespacedName{Name: pvc.Name, Namespace: pvc.Namespace}
@@ -233,6 +243,11 @@ func (v *VRGInstance) updateProtectedPVCs(pvc *corev1.PersistentVolumeClaim) err
 			v.instance.Name, err)
 	}
 
+	schedulingInterval, err := v.pvcSchedulingInterval(pvc)
+	if err != nil {
+		return fmt.Errorf("invalid scheduling interval for pvc %s (%w)", pvcNamespacedName, err)
+	}
+
 	protectedPVC := v.findProtectedPVC(pvc.GetNamespace(), pvc.GetName())
 	if protectedPVC == nil {
 		protectedPVC = &ramendrv1alpha1.ProtectedPVC{Namespace: pvc.GetNamespace(), Name: pvc.GetName()}
@@ -240,6 +255,7 @@ func (v *VRGInstance) updateProtectedPVCs(pvc *corev1.PersistentVolumeClaim) err
 	}
 
 	protectedPVC.ProtectedByVolSync = false
+	protectedPVC.SchedulingInterval = schedulingInterval
 	protectedPVC.StorageClassName = pvc.Spec.StorageClassName
 	protectedPVC.Labels = pvc.Labels
 	protectedPVC.AccessModes = pvc.Spec.AccessModes
@@ -264,6 +280,10 @@ func setPVCStorageIdentifiers(
 		}
 	}
 
+	if volumeReplicationClass == nil {
+		return
+	}
+
 	if value, ok := volumeReplicationClass.Labels[VolumeReplicationIDLabel]; ok {
 		protectedPVC.StorageIdentifiers.ReplicationID.ID = value
 		if modes, ok := volumeReplicationClass.Labels[MModesLabel]; ok {
@@ -551,9 +571,24 @@ func (v *VRGInstance) isArchivedAlready(pvc *corev1.PersistentVolumeClaim, log l
 		return false
 	}
 
+	// A resized PVC needs its PV and PVC cluster data archived again with the new capacity. PVCs archived before
+	// the capacity was recorded are presumed archived with their current capacity.
+	if archivedCapacity, ok := pvc.ObjectMeta.Annotations[pvcVRAnnotationArchivedCapacity]; ok && archivedCapacity !=
+		pvcCapacity(pvc) {
+		log.Info("PVC capacity changed since it was archived", "archived", archivedCapacity,
+			"current", pvcCapacity(pvc))
+
+		return false
+	}
+
 	return true
 }
 
+// pvcCapacity returns the current storage capacity of the PVC as reported in its status
+func pvcCapacity(pvc *corev1.PersistentVolumeClaim) string {
+	return pvc.Status.Capacity.Storage().String()
+}
+
 // Upload PV to the list of S3 stores in the VRG spec
 func (v *VRGInstance) uploadPVandPVCtoS3Stores(pvc *corev1.PersistentVolumeClaim, log logr.Logger) (err error) {
 	if v.isArchivedAlready(pvc, log) {
@@ -861,9 +896,7 @@ func (v *VRGInstance) reconcileVRForDeletion(pvc *corev1.PersistentVolumeClaim,
 func (v *VRGInstance) undoPVCFinalizersAndPVRetention(pvc *corev1.PersistentVolumeClaim, log logr.Logger) bool {
 	const requeue = true
 
-	pvcNamespacedName := types.NamespacedName{Name: pvc.Name, Namespace: pvc.Namespace}
-
-	if err := v.deleteVR(pvcNamespacedName, log); err != nil {
+	if err := v.deleteVROrRemoveFromVGR(pvc, log); err != nil {
 		log.Info("Requeuing due to failure in finalizing VolumeReplication resource for PersistentVolumeClaim",
 			"errorValue", err)
 
@@ -900,6 +933,10 @@ func (v *VRGInstance) reconcileMissingVR(pvc *corev1.PersistentVolumeClaim, log
 		return !vrMissing, !requeue
 	}
 
+	if v.volGroupRepEnabled() {
+		return v.reconcileMissingVGR(pvc, log)
+	}
+
 	volRep := &volrep.VolumeReplication{}
 	vrNamespacedName := types.NamespacedName{Name: pvc.Name, Namespace: pvc.Namespace}
 
@@ -1006,6 +1043,10 @@ func (v *VRGInstance) s3StoreDo(do func(ObjectStorer) error, msg, s3ProfileName
 //   - a boolean indicating if VR is already at the desired state
 //   - any errors during processing
 func (v *VRGInstance) processVRAsPrimary(vrNamespacedName types.NamespacedName, log logr.Logger) (bool, bool, error) {
+	if v.volGroupRepEnabled() {
+		return v.processVGR(vrNamespacedName, volrep.Primary, log)
+	}
+
 	if v.instance.Spec.Async != nil {
 		return v.createOrUpdateVR(vrNamespacedName, volrep.Primary, log)
 	}
@@ -1020,7 +1061,7 @@ func (v *VRGInstance) processVRAsPrimary(vrNamespacedName types.NamespacedName,
 	if v.instance.Spec.Sync != nil {
 		msg := "PVC in the VolumeReplicationGroup is ready for use"
 		v.updatePVCDataReadyCondition(vrNamespacedName.Namespace, vrNamespacedName.Name, VRGConditionReasonReady, msg)
-		v.updatePVCDataProtectedCondition(vrNamespacedName.Namespace, vrNamespacedName.Name, VRGConditionReasonReady, msg)
+		v.updatePVCDataProtectedConditionForSync(vrNamespacedName, VRGConditionReasonReady, msg)
 		v.updatePVCLastSyncTime(vrNamespacedName.Namespace, vrNamespacedName.Name, nil)
 		v.updatePVCLastSyncDuration(vrNamespacedName.Namespace, vrNamespacedName.Name, nil)
 		v.updatePVCLastSyncBytes(vrNamespacedName.Namespace, vrNamespacedName.Name, nil)
@@ -1038,6 +1079,10 @@ func (v *VRGInstance) processVRAsPrimary(vrNamespacedName types.NamespacedName,
 //   - a boolean indicating if VR is already at the desired state
 //   - any errors during processing
 func (v *VRGInstance) processVRAsSecondary(vrNamespacedName types.NamespacedName, log logr.Logger) (bool, bool, error) {
+	if v.volGroupRepEnabled() {
+		return v.processVGR(vrNamespacedName, volrep.Secondary, log)
+	}
+
 	if v.instance.Spec.Async != nil {
 		return v.createOrUpdateVR(vrNamespacedName, volrep.Secondary, log)
 	}
@@ -1052,8 +1097,7 @@ func (v *VRGInstance) processVRAsSecondary(vrNamespacedName types.NamespacedName
 	if v.instance.Spec.Sync != nil {
 		msg := "VolumeReplication resource for the pvc as Secondary is in sync with Primary"
 		v.updatePVCDataReadyCondition(vrNamespacedName.Namespace, vrNamespacedName.Name, VRGConditionReasonReplicated, msg)
-		v.updatePVCDataProtectedCondition(vrNamespacedName.Namespace, vrNamespacedName.Name, VRGConditionReasonDataProtected,
-			msg)
+		v.updatePVCDataProtectedConditionForSync(vrNamespacedName, VRGConditionReasonDataProtected, msg)
 		v.updatePVCLastSyncTime(vrNamespacedName.Namespace, vrNamespacedName.Name, nil)
 		v.updatePVCLastSyncDuration(vrNamespacedName.Namespace, vrNamespacedName.Name, nil)
 		v.updatePVCLastSyncBytes(vrNamespacedName.Namespace, vrNamespacedName.Name, nil)
@@ -1144,11 +1188,18 @@ func (v *VRGInstance) updateVR(volRep *volrep.VolumeReplication,
 ) (bool, bool, error) {
 	const requeue = true
 
+	if state == volrep.Secondary && vrResyncPending(volRep) {
+		log.Info("Waiting for VolumeReplication to process the requested resync")
+
+		return requeue, false, nil
+	}
+
 	// If state is already as desired, check the status
 	if volRep.Spec.ReplicationState == state && volRep.Spec.AutoResync == v.autoResync(state) {
 		log.Info("VolumeReplication and VolumeReplicationGroup state and autoresync match. Proceeding to status check")
 
-		return !requeue, v.checkVRStatus(volRep), nil
+		return !requeue, v.checkVRStatus(types.NamespacedName{Namespace: volRep.Namespace, Name: volRep.Name},
+			volRep, &volRep.Status), nil
 	}
 
 	volRep.Spec.ReplicationState = state
@@ -1257,33 +1308,99 @@ func (v *VRGInstance) selectVolumeReplicationClass(
 			namespacedName, err)
 	}
 
+	pvcSchedulingInterval, err := v.pvcSchedulingInterval(v.volRepPVCGet(namespacedName))
+	if err != nil {
+		return nil, fmt.Errorf("invalid scheduling interval for pvc %s (%w)", namespacedName, err)
+	}
+
 	for index := range v.replClassList.Items {
 		replicationClass := &v.replClassList.Items[index]
 		if storageClass.Provisioner != replicationClass.Spec.Provisioner {
 			continue
 		}
 
-		schedulingInterval, found := replicationClass.Spec.Parameters["schedulingInterval"]
-		if !found {
-			// schedule not present in parameters of this replicationClass.
-			continue
-		}
-
-		// ReplicationClass that matches both VRG schedule and pvc provisioner
-		if schedulingInterval == v.instance.Spec.Async.SchedulingInterval {
+		// ReplicationClass that matches both pvc schedule and pvc provisioner
+		if v.replicationClassMatchesSchedule(replicationClass.GetAnnotations(),
+			replicationClass.Spec.Parameters["schedulingInterval"], pvcSchedulingInterval) {
 			v.log.Info(fmt.Sprintf("Found VolumeReplicationClass that matches provisioner and schedule %s/%s",
-				storageClass.Provisioner, v.instance.Spec.Async.SchedulingInterval))
+				storageClass.Provisioner, pvcSchedulingInterval))
 
 			return replicationClass, nil
 		}
 	}
 
 	v.log.Info(fmt.Sprintf("No VolumeReplicationClass found to match provisioner and schedule %s/%s",
-		storageClass.Provisioner, v.instance.Spec.Async.SchedulingInterval))
+		storageClass.Provisioner, pvcSchedulingInterval))
 
 	return nil, fmt.Errorf("no VolumeReplicationClass found to match provisioner and schedule")
 }
 
+// pvcSchedulingInterval returns the interval for replicating the pvc's data, which the pvc's scheduling interval
+// annotation overrides. If the annotation is invalid, the VRG's scheduling interval is returned with an error.
+func (v *VRGInstance) pvcSchedulingInterval(pvc *corev1.PersistentVolumeClaim) (string, error) {
+	schedulingInterval := ""
+	if v.instance.Spec.Async != nil {
+		schedulingInterval = v.instance.Spec.Async.SchedulingInterval
+	}
+
+	if pvc == nil {
+		return schedulingInterval, nil
+	}
+
+	override, ok := pvc.GetAnnotations()[PVCSchedulingIntervalAnnotation]
+	if !ok {
+		return schedulingInterval, nil
+	}
+
+	if err := rmnutil.ValidateSchedulingInterval(override); err != nil {
+		return schedulingInterval, err
+	}
+
+	return override, nil
+}
+
+// replicationClassMatchesSchedule returns true if a VolumeReplicationClass, or VolumeGroupReplicationClass,
+// replicates on the pvc's schedule and honours the VRG's blackout windows. The VRG's cron schedule, unless the
+// pvc's scheduling interval annotation overrides it, must equal the class' schedule annotation, and otherwise
+// the pvc's scheduling interval must equal the class' schedulingInterval parameter. The VRG's blackout windows
+// must equal the class' blackout windows annotation.
+func (v *VRGInstance) replicationClassMatchesSchedule(classAnnotations map[string]string,
+	classSchedulingInterval, pvcSchedulingInterval string,
+) bool {
+	async := v.instance.Spec.Async
+	if async == nil {
+		return classSchedulingInterval != "" && classSchedulingInterval == pvcSchedulingInterval
+	}
+
+	if async.Schedule != "" && pvcSchedulingInterval == async.SchedulingInterval {
+		if classAnnotations[rmnutil.ScheduleAnnotation] != async.Schedule {
+			return false
+		}
+	} else if classSchedulingInterval == "" || classSchedulingInterval != pvcSchedulingInterval {
+		return false
+	}
+
+	return classAnnotations[rmnutil.BlackoutWindowsAnnotation] == rmnutil.ReplicationWindowsString(async.BlackoutWindows)
+}
+
+// updateVRGGroupSchedulingInterval sets the group's scheduling interval to the longest of its PVCs'
+func (v *VRGInstance) updateVRGGroupSchedulingInterval() {
+	groupSchedulingInterval := ""
+	groupSchedulingIntervalSeconds := 0.0
+
+	for _, protectedPVC := range v.instance.Status.ProtectedPVCs {
+		seconds, err := rmnutil.SchedulingIntervalSeconds(protectedPVC.SchedulingInterval)
+		if err != nil || seconds <= groupSchedulingIntervalSeconds {
+			continue
+		}
+
+		groupSchedulingInterval = protectedPVC.SchedulingInterval
+		groupSchedulingIntervalSeconds = seconds
+	}
+
+	v.instance.Status.GroupSchedulingInterval = groupSchedulingInterval
+}
+
 // getStorageClass inspects the PVCs being protected by this VRG instance for the passed in namespacedName, and
 // returns its corresponding StorageClass resource from an instance cache if available, or fetches it from the API
 // server and stores it in an instance cache before returning the StorageClass
@@ -1315,49 +1432,52 @@ func (v *VRGInstance) getStorageClass(namespacedName types.NamespacedName) (*sto
 		return nil, fmt.Errorf("missing StorageClass name for pvc (%s)", namespacedName)
 	}
 
-	if storageClass, ok := v.storageClassCache[*scName]; ok {
-		return storageClass, nil
-	}
-
-	storageClass := &storagev1.StorageClass{}
-	if err := v.reconciler.Get(v.ctx, types.NamespacedName{Name: *scName}, storageClass); err != nil {
+	storageClass, err := v.storageClassGetByName(*scName)
+	if err != nil {
 		v.log.Info(fmt.Sprintf("Failed to get the storageclass %s", *scName))
 
-		return nil, fmt.Errorf("failed to get the storageclass with name %s (%w)",
-			*scName, err)
+		return nil, err
 	}
 
-	v.storageClassCache[*scName] = storageClass
-
 	return storageClass, nil
 }
 
 // checkVRStatus checks if the VolumeReplication resource has the desired status for the
 // current generation and returns true if so, false otherwise
-func (v *VRGInstance) checkVRStatus(volRep *volrep.VolumeReplication) bool {
+func (v *VRGInstance) checkVRStatus(pvcNamespacedName types.NamespacedName, volRep client.Object,
+	status *volrep.VolumeReplicationStatus,
+) bool {
 	// When the generation in the status is updated, VRG would get a reconcile
 	// as it owns VolumeReplication resource.
-	if volRep.Generation != volRep.Status.ObservedGeneration {
+	if volRep.GetGeneration() != status.ObservedGeneration {
 		v.log.Info(fmt.Sprintf("Generation mismatch in status for VolumeReplication resource (%s/%s)",
-			volRep.Name, volRep.Namespace))
+			volRep.GetName(), volRep.GetNamespace()))
 
 		msg := "VolumeReplication generation not updated in status"
-		v.updatePVCDataReadyCondition(volRep.Namespace, volRep.Name, VRGConditionReasonProgressing, msg)
+		v.updatePVCDataReadyCondition(pvcNamespacedName.Namespace, pvcNamespacedName.Name, VRGConditionReasonProgressing, msg)
 
 		return false
 	}
 
+	// A volume that failed to resync is remediated while it is reported as not demoted
+	resyncRequested := v.vrDegradedRemediate(pvcNamespacedName, volRep, status,
+		v.log.WithValues("pvc", pvcNamespacedName.String()))
+
+	if v.checkVRNotDemoted(pvcNamespacedName, volRep, status) || resyncRequested {
+		return false
+	}
+
 	switch {
 	case v.instance.Spec.ReplicationState == ramendrv1alpha1.Primary:
-		return v.validateVRStatus(volRep, ramendrv1alpha1.Primary)
+		return v.validateVRStatus(pvcNamespacedName, volRep, status, ramendrv1alpha1.Primary)
 	case v.instance.Spec.ReplicationState == ramendrv1alpha1.Secondary:
-		return v.validateVRStatus(volRep, ramendrv1alpha1.Secondary)
+		return v.validateVRStatus(pvcNamespacedName, volRep, status, ramendrv1alpha1.Secondary)
 	default:
 		v.log.Info(fmt.Sprintf("invalid Replication State %s for VolumeReplicationGroup (%s:%s)",
 			string(v.instance.Spec.ReplicationState), v.instance.Name, v.instance.Namespace))
 
 		msg := "VolumeReplicationGroup state invalid"
-		v.updatePVCDataReadyCondition(volRep.Namespace, volRep.Name, VRGConditionReasonError, msg)
+		v.updatePVCDataReadyCondition(pvcNamespacedName.Namespace, pvcNamespacedName.Name, VRGConditionReasonError, msg)
 
 		return false
 	}
@@ -1368,7 +1488,9 @@ func (v *VRGInstance) checkVRStatus(volRep *volrep.VolumeReplication) bool {
 //   - When replication state is Primary, only Completed condition is checked.
 //   - When replication state is Secondary, all 3 conditions for Completed/Degraded/Resyncing is
 //     checked and ensured healthy.
-func (v *VRGInstance) validateVRStatus(volRep *volrep.VolumeReplication, state ramendrv1alpha1.ReplicationState) bool {
+func (v *VRGInstance) validateVRStatus(pvcNamespacedName types.NamespacedName, volRep client.Object,
+	status *volrep.VolumeReplicationStatus, state ramendrv1alpha1.ReplicationState,
+) bool {
 	var (
 		stateString string
 		action      string
@@ -1384,33 +1506,35 @@ func (v *VRGInstance) validateVRStatus(volRep *volrep.VolumeReplication, state r
 	}
 
 	// it should be completed
-	conditionMet, msg := isVRConditionMet(volRep, volrepController.ConditionCompleted, metav1.ConditionTrue)
+	conditionMet, msg := isVRConditionMet(volRep, status.Conditions, volrepController.ConditionCompleted,
+		metav1.ConditionTrue)
 	if !conditionMet {
 		defaultMsg := fmt.Sprintf("VolumeReplication resource for pvc not %s to %s", action, stateString)
-		v.updatePVCDataReadyConditionHelper(volRep.Namespace, volRep.Name, VRGConditionReasonError, msg,
+		v.updatePVCDataReadyConditionHelper(pvcNamespacedName.Namespace, pvcNamespacedName.Name, VRGConditionReasonError, msg,
 			defaultMsg)
 
-		v.updatePVCDataProtectedConditionHelper(volRep.Namespace, volRep.Name, VRGConditionReasonError, msg,
+		v.updatePVCDataProtectedConditionHelper(pvcNamespacedName.Namespace, pvcNamespacedName.Name,
+			VRGConditionReasonError, msg,
 			defaultMsg)
 
-		v.log.Info(fmt.Sprintf("%s (VolRep: %s/%s)", defaultMsg, volRep.Name, volRep.Namespace))
+		v.log.Info(fmt.Sprintf("%s (VolRep: %s/%s)", defaultMsg, volRep.GetName(), volRep.GetNamespace()))
 
 		return false
 	}
 
 	// if primary, all checks are completed
 	if state == ramendrv1alpha1.Secondary {
-		return v.validateAdditionalVRStatusForSecondary(volRep)
+		return v.validateAdditionalVRStatusForSecondary(pvcNamespacedName, volRep, status)
 	}
 
 	msg = "PVC in the VolumeReplicationGroup is ready for use"
-	v.updatePVCDataReadyCondition(volRep.Namespace, volRep.Name, VRGConditionReasonReady, msg)
-	v.updatePVCDataProtectedCondition(volRep.Namespace, volRep.Name, VRGConditionReasonReady, msg)
-	v.updatePVCLastSyncTime(volRep.Namespace, volRep.Name, volRep.Status.LastSyncTime)
-	v.updatePVCLastSyncDuration(volRep.Namespace, volRep.Name, volRep.Status.LastSyncDuration)
-	v.updatePVCLastSyncBytes(volRep.Namespace, volRep.Name, volRep.Status.LastSyncBytes)
-	v.log.Info(fmt.Sprintf("VolumeReplication resource %s/%s is ready for use", volRep.Name,
-		volRep.Namespace))
+	v.updatePVCDataReadyCondition(pvcNamespacedName.Namespace, pvcNamespacedName.Name, VRGConditionReasonReady, msg)
+	v.updatePVCDataProtectedCondition(pvcNamespacedName.Namespace, pvcNamespacedName.Name, VRGConditionReasonReady, msg)
+	v.updatePVCLastSyncTime(pvcNamespacedName.Namespace, pvcNamespacedName.Name, status.LastSyncTime)
+	v.updatePVCLastSyncDuration(pvcNamespacedName.Namespace, pvcNamespacedName.Name, status.LastSyncDuration)
+	v.updatePVCLastSyncBytes(pvcNamespacedName.Namespace, pvcNamespacedName.Name, status.LastSyncBytes)
+	v.log.Info(fmt.Sprintf("VolumeReplication resource %s/%s is ready for use", volRep.GetName(),
+		volRep.GetNamespace()))
 
 	return true
 }
@@ -1432,92 +1556,105 @@ func (v *VRGInstance) validateVRStatus(volRep *volrep.VolumeReplication, state r
 // With 2nd condition being met,
 // ProtectedPVC.Conditions[DataReady] = True
 // ProtectedPVC.Conditions[DataProtected] = True
-func (v *VRGInstance) validateAdditionalVRStatusForSecondary(volRep *volrep.VolumeReplication) bool {
-	v.updatePVCLastSyncTime(volRep.Namespace, volRep.Name, nil)
-	v.updatePVCLastSyncDuration(volRep.Namespace, volRep.Name, nil)
-	v.updatePVCLastSyncBytes(volRep.Namespace, volRep.Name, nil)
-
-	conditionMet, _ := isVRConditionMet(volRep, volrepController.ConditionResyncing, metav1.ConditionTrue)
+func (v *VRGInstance) validateAdditionalVRStatusForSecondary(pvcNamespacedName types.NamespacedName,
+	volRep client.Object, status *volrep.VolumeReplicationStatus,
+) bool {
+	v.updatePVCLastSyncTime(pvcNamespacedName.Namespace, pvcNamespacedName.Name, nil)
+	v.updatePVCLastSyncDuration(pvcNamespacedName.Namespace, pvcNamespacedName.Name, nil)
+	v.updatePVCLastSyncBytes(pvcNamespacedName.Namespace, pvcNamespacedName.Name, nil)
+
+	conditionMet, _ := isVRConditionMet(volRep, status.Conditions, volrepController.ConditionResyncing,
+		metav1.ConditionTrue)
 	if !conditionMet {
-		return v.checkResyncCompletionAsSecondary(volRep)
+		return v.checkResyncCompletionAsSecondary(pvcNamespacedName, volRep, status)
 	}
 
-	conditionMet, msg := isVRConditionMet(volRep, volrepController.ConditionDegraded, metav1.ConditionTrue)
+	conditionMet, msg := isVRConditionMet(volRep, status.Conditions, volrepController.ConditionDegraded,
+		metav1.ConditionTrue)
 	if !conditionMet {
-		v.updatePVCDataProtectedConditionHelper(volRep.Namespace, volRep.Name, VRGConditionReasonError, msg,
+		v.updatePVCDataProtectedConditionHelper(pvcNamespacedName.Namespace, pvcNamespacedName.Name,
+			VRGConditionReasonError, msg,
 			"VolumeReplication resource for pvc is not in Degraded condition while resyncing")
 
-		v.updatePVCDataReadyConditionHelper(volRep.Namespace, volRep.Name, VRGConditionReasonError, msg,
+		v.updatePVCDataReadyConditionHelper(pvcNamespacedName.Namespace, pvcNamespacedName.Name, VRGConditionReasonError, msg,
 			"VolumeReplication resource for pvc is not in Degraded condition while resyncing")
 
 		v.log.Info(fmt.Sprintf("VolumeReplication resource is not in degraded condition while"+
-			" resyncing is true (%s/%s)", volRep.Name, volRep.Namespace))
+			" resyncing is true (%s/%s)", volRep.GetName(), volRep.GetNamespace()))
 
 		return false
 	}
 
 	msg = "VolumeReplication resource for the pvc is syncing as Secondary"
-	v.updatePVCDataReadyCondition(volRep.Namespace, volRep.Name, VRGConditionReasonReplicating, msg)
-	v.updatePVCDataProtectedCondition(volRep.Namespace, volRep.Name, VRGConditionReasonReplicating, msg)
+	v.updatePVCDataReadyCondition(pvcNamespacedName.Namespace, pvcNamespacedName.Name, VRGConditionReasonReplicating, msg)
+	v.updatePVCDataProtectedCondition(pvcNamespacedName.Namespace, pvcNamespacedName.Name,
+		VRGConditionReasonReplicating, msg)
 
 	v.log.Info(fmt.Sprintf("VolumeReplication resource for the pvc is syncing as Secondary (%s/%s)",
-		volRep.Name, volRep.Namespace))
+		volRep.GetName(), volRep.GetNamespace()))
 
 	return true
 }
 
 // checkResyncCompletionAsSecondary returns true if resync status is complete as secondary, false otherwise
-func (v *VRGInstance) checkResyncCompletionAsSecondary(volRep *volrep.VolumeReplication) bool {
-	conditionMet, msg := isVRConditionMet(volRep, volrepController.ConditionResyncing, metav1.ConditionFalse)
+func (v *VRGInstance) checkResyncCompletionAsSecondary(pvcNamespacedName types.NamespacedName, volRep client.Object,
+	status *volrep.VolumeReplicationStatus,
+) bool {
+	conditionMet, msg := isVRConditionMet(volRep, status.Conditions, volrepController.ConditionResyncing,
+		metav1.ConditionFalse)
 	if !conditionMet {
 		defaultMsg := "VolumeReplication resource for pvc not syncing as Secondary"
-		v.updatePVCDataReadyConditionHelper(volRep.Namespace, volRep.Name, VRGConditionReasonError, msg,
+		v.updatePVCDataReadyConditionHelper(pvcNamespacedName.Namespace, pvcNamespacedName.Name, VRGConditionReasonError, msg,
 			defaultMsg)
 
-		v.updatePVCDataProtectedConditionHelper(volRep.Namespace, volRep.Name, VRGConditionReasonError, msg,
+		v.updatePVCDataProtectedConditionHelper(pvcNamespacedName.Namespace, pvcNamespacedName.Name,
+			VRGConditionReasonError, msg,
 			defaultMsg)
 
-		v.log.Info(fmt.Sprintf("%s (VolRep: %s/%s)", defaultMsg, volRep.Name, volRep.Namespace))
+		v.log.Info(fmt.Sprintf("%s (VolRep: %s/%s)", defaultMsg, volRep.GetName(), volRep.GetNamespace()))
 
 		return false
 	}
 
-	conditionMet, msg = isVRConditionMet(volRep, volrepController.ConditionDegraded, metav1.ConditionFalse)
+	conditionMet, msg = isVRConditionMet(volRep, status.Conditions, volrepController.ConditionDegraded,
+		metav1.ConditionFalse)
 	if !conditionMet {
 		defaultMsg := "VolumeReplication resource for pvc is not syncing and is degraded as Secondary"
-		v.updatePVCDataReadyConditionHelper(volRep.Namespace, volRep.Name, VRGConditionReasonError, msg,
+		v.updatePVCDataReadyConditionHelper(pvcNamespacedName.Namespace, pvcNamespacedName.Name, VRGConditionReasonError, msg,
 			defaultMsg)
 
-		v.updatePVCDataProtectedConditionHelper(volRep.Namespace, volRep.Name, VRGConditionReasonError, msg,
+		v.updatePVCDataProtectedConditionHelper(pvcNamespacedName.Namespace, pvcNamespacedName.Name,
+			VRGConditionReasonError, msg,
 			defaultMsg)
 
-		v.log.Info(fmt.Sprintf("%s (VolRep: %s/%s)", defaultMsg, volRep.Name, volRep.Namespace))
+		v.log.Info(fmt.Sprintf("%s (VolRep: %s/%s)", defaultMsg, volRep.GetName(), volRep.GetNamespace()))
 
 		return false
 	}
 
 	msg = "VolumeReplication resource for the pvc as Secondary is in sync with Primary"
-	v.updatePVCDataReadyCondition(volRep.Namespace, volRep.Name, VRGConditionReasonReplicated, msg)
-	v.updatePVCDataProtectedCondition(volRep.Namespace, volRep.Name, VRGConditionReasonDataProtected, msg)
+	v.updatePVCDataReadyCondition(pvcNamespacedName.Namespace, pvcNamespacedName.Name, VRGConditionReasonReplicated, msg)
+	v.updatePVCDataProtectedCondition(pvcNamespacedName.Namespace, pvcNamespacedName.Name,
+		VRGConditionReasonDataProtected, msg)
 
 	v.log.Info(fmt.Sprintf("data sync completed as both degraded and resyncing are false for"+
-		" secondary VolRep (%s/%s)", volRep.Name, volRep.Namespace))
+		" secondary VolRep (%s/%s)", volRep.GetName(), volRep.GetNamespace()))
 
 	return true
 }
 
-func isVRConditionMet(volRep *volrep.VolumeReplication,
+func isVRConditionMet(volRep client.Object, conditions []metav1.Condition,
 	conditionType string,
 	desiredStatus metav1.ConditionStatus,
 ) (bool, string) {
-	volRepCondition := findCondition(volRep.Status.Conditions, conditionType)
+	volRepCondition := findCondition(conditions, conditionType)
 	if volRepCondition == nil {
 		msg := fmt.Sprintf("Failed to get the %s condition from status of VolumeReplication resource.", conditionType)
 
 		return false, msg
 	}
 
-	if volRep.Generation != volRepCondition.ObservedGeneration {
+	if volRep.GetGeneration() != volRepCondition.ObservedGeneration {
 		msg := fmt.Sprintf("Stale generation for condition %s from status of VolumeReplication resource.", conditionType)
 
 		return false, msg
@@ -1645,6 +1782,8 @@ func setPVCDataReadyCondition(protectedPVC *ramendrv1alpha1.ProtectedPVC, reason
 		setVRGDataProgressingCondition(&protectedPVC.Conditions, observedGeneration, message)
 	case reason == VRGConditionReasonErrorUnknown:
 		setVRGDataErrorUnknownCondition(&protectedPVC.Conditions, observedGeneration, message)
+	case reason == VRGConditionReasonMirroringUnknown:
+		setVRGAsDataProtectedUnknownCondition(&protectedPVC.Conditions, observedGeneration, message)
 	default:
 		// if appropriate reason is not provided, then treat it as an unknown condition.
 		message = "Unknown reason: " + reason
@@ -1674,6 +1813,8 @@ func setPVCDataProtectedCondition(protectedPVC *ramendrv1alpha1.ProtectedPVC, re
 		setVRGAsDataNotProtectedCondition(&protectedPVC.Conditions, observedGeneration, message)
 	case reason == VRGConditionReasonErrorUnknown:
 		setVRGDataErrorUnknownCondition(&protectedPVC.Conditions, observedGeneration, message)
+	case reason == VRGConditionReasonMirroringUnknown:
+		setVRGAsDataProtectedUnknownCondition(&protectedPVC.Conditions, observedGeneration, message)
 	default:
 		// if appropriate reason is not provided, then treat it as an unknown condition.
 		message = "Unknown reason: " + reason
@@ -1784,6 +1925,7 @@ func (v *VRGInstance) addArchivedAnnotationForPVC(pvc *corev1.PersistentVolumeCl
 	}
 
 	pvc.ObjectMeta.Annotations[pvcVRAnnotationArchivedKey] = v.generateArchiveAnnotation(pvc.Generation)
+	pvc.ObjectMeta.Annotations[pvcVRAnnotationArchivedCapacity] = pvcCapacity(pvc)
 
 	if err := v.reconciler.Update(v.ctx, pvc); err != nil {
 		log.Error(err, "Failed to update PersistentVolumeClaim annotation")
@@ -1898,7 +2040,7 @@ func (v *VRGInstance) restorePVsAndPVCsFromS3(result *ctrl.Result) (int, error)
 
 		v.log.Info(fmt.Sprintf("Restored %d PVs and %d PVCs using profile %s", pvCount, pvcCount, s3ProfileName))
 
-		return pvCount + pvcCount, v.kubeObjectsRecover(result, s3StoreProfile, objectStore)
+		return pvCount + pvcCount, v.kubeObjectsRecover(result, s3StoreProfile, objectStore, nil)
 	}
 
 	if NoS3 {
@@ -1928,6 +2070,10 @@ func (v *VRGInstance) restorePVsFromObjectStore(objectStore ObjectStorer, s3Prof
 		return 0, fmt.Errorf("%s: %w", errMsg, err)
 	}
 
+	if err = v.pvsStorageClassMap(pvList); err != nil {
+		return 0, err
+	}
+
 	return restoreClusterDataObjects(v, pvList, "PV", cleanupPVForRestore, v.validateExistingPV)
 }
 
@@ -1941,9 +2087,20 @@ func (v *VRGInstance) restorePVCsFromObjectStore(objectStore ObjectStorer, s3Pro
 
 	v.log.Info(fmt.Sprintf("Found %d PVCs in s3 store using profile %s", len(pvcList), s3ProfileName))
 
+	if err = v.pvcsStorageClassMap(pvcList); err != nil {
+		return 0, err
+	}
+
+	pvcsDataVolumeAdopt(pvcList)
+
 	v.volRepPVCs = append(v.volRepPVCs, pvcList...)
 
-	return restoreClusterDataObjects(v, pvcList, "PVC", cleanupPVCForRestore, v.validateExistingPVC)
+	cleanupForRestore := func(pvc *corev1.PersistentVolumeClaim) {
+		cleanupPVCForRestore(pvc)
+		v.pvcRequestExistingPVCapacity(pvc)
+	}
+
+	return restoreClusterDataObjects(v, pvcList, "PVC", cleanupForRestore, v.validateExistingPVC)
 }
 
 // checkPVClusterData returns an error if there are PVs in the input pvList
@@ -2114,6 +2271,68 @@ func (v *VRGInstance) validateExistingPV(pv *corev1.PersistentVolume) error {
 	return fmt.Errorf("found existing PV (%s) not restored by Ramen and not matching with backed up PV", existingPV.Name)
 }
 
+// pvcRequestExistingPVCapacity lowers the storage request of a PVC to be restored to the capacity of its
+// existing PV, if the volume was expanded on the primary after the PV was restored, so that the PVC binds to
+// the PV. The expanded capacity is recorded in the PVC, to request it once the PVC is bound and its volume
+// primary, thus expanding the volume as well.
+func (v *VRGInstance) pvcRequestExistingPVCapacity(pvc *corev1.PersistentVolumeClaim) {
+	capacity := pvc.Spec.Resources.Requests.Storage()
+	if capacity.IsZero() || pvc.Spec.VolumeName == "" {
+		return
+	}
+
+	pv := &corev1.PersistentVolume{}
+	if err := v.reconciler.Get(v.ctx, types.NamespacedName{Name: pvc.Spec.VolumeName}, pv); err != nil {
+		return
+	}
+
+	pvCapacity := pv.Spec.Capacity.Storage()
+	if pvCapacity.IsZero() || pvCapacity.Cmp(*capacity) >= 0 {
+		return
+	}
+
+	v.log.Info("Restoring PVC with the capacity of its existing PV, to be expanded once bound", "PVC", pvc.Name,
+		"capacity", pvCapacity, "expandedCapacity", capacity)
+
+	if pvc.ObjectMeta.Annotations == nil {
+		pvc.ObjectMeta.Annotations = map[string]string{}
+	}
+
+	pvc.ObjectMeta.Annotations[pvcAnnotationRestoreCapacity] = capacity.String()
+	pvc.Spec.Resources.Requests[corev1.ResourceStorage] = pvCapacity.DeepCopy()
+}
+
+// pvcRestoreCapacity returns the capacity a restored PVC is to be expanded to, if any, or its storage request
+func pvcRestoreCapacity(pvc *corev1.PersistentVolumeClaim) *resource.Quantity {
+	if value, ok := pvc.GetAnnotations()[pvcAnnotationRestoreCapacity]; ok {
+		if capacity, err := resource.ParseQuantity(value); err == nil {
+			return &capacity
+		}
+	}
+
+	return pvc.Spec.Resources.Requests.Storage()
+}
+
+// pvcExpandToRestoreCapacity requests the capacity recorded in a restored PVC that was bound to a PV with a
+// smaller capacity, which has the CSI resizer expand the volume and its file system
+func (v *VRGInstance) pvcExpandToRestoreCapacity(pvc *corev1.PersistentVolumeClaim, log logr.Logger) error {
+	if _, ok := pvc.GetAnnotations()[pvcAnnotationRestoreCapacity]; !ok || pvc.Status.Phase != corev1.ClaimBound {
+		return nil
+	}
+
+	if rmnutil.PVCExpandStorageRequest(pvc, pvcRestoreCapacity(pvc)) {
+		log.Info("Expanding restored PVC", "capacity", pvc.Spec.Resources.Requests.Storage())
+	}
+
+	delete(pvc.ObjectMeta.Annotations, pvcAnnotationRestoreCapacity)
+
+	if err := v.reconciler.Update(v.ctx, pvc); err != nil {
+		return fmt.Errorf("failed to expand restored PVC %s/%s, %w", pvc.Namespace, pvc.Name, err)
+	}
+
+	return nil
+}
+
 // validateExistingPVC validates if an existing PVC matches the passed in PVC for certain fields. Returns error
 // if a match fails or a match is not possible given the state of the existing PVC
 func (v *VRGInstance) validateExistingPVC(pvc *corev1.PersistentVolumeClaim) error {
@@ -2140,6 +2359,16 @@ func (v *VRGInstance) validateExistingPVC(pvc *corev1.PersistentVolumeClaim) err
 
 	v.log.Info(fmt.Sprintf("PVC %s exists and bound to desired PV %s", pvcNSName.String(), existingPVC.Spec.VolumeName))
 
+	// The PVC may have been expanded on the primary after the existing PVC was restored
+	if rmnutil.PVCExpandStorageRequest(existingPVC, pvcRestoreCapacity(pvc)) {
+		v.log.Info(fmt.Sprintf("Expanding existing PVC %s to %s", pvcNSName.String(),
+			existingPVC.Spec.Resources.Requests.Storage()))
+
+		if err := v.reconciler.Update(v.ctx, existingPVC); err != nil {
+			return fmt.Errorf("failed to expand existing PVC %s (%w)", pvcNSName.String(), err)
+		}
+	}
+
 	return nil
 }
 
@@ -2349,6 +2578,7 @@ func (v *VRGInstance) aggregateVolRepDataProtectedCondition() *metav1.Condition
 
 	vrgProtected := true
 	vrgReplicating := false
+	vrgMirroringUnknown := ""
 
 	for _, protectedPVC := range v.instance.Status.ProtectedPVCs {
 		if protectedPVC.ProtectedByVolSync {
@@ -2360,12 +2590,21 @@ func (v *VRGInstance) aggregateVolRepDataProtectedCondition() *metav1.Condition
 		if condition == nil {
 			vrgProtected = false
 			vrgReplicating = false
+			vrgMirroringUnknown = ""
 
 			v.log.Info(fmt.Sprintf("Failed to find condition %s for vrg", VRGConditionTypeDataProtected))
 
 			break
 		}
 
+		// The storage does not report the synchronous mirroring state of the PVC
+		if condition.Reason == VRGConditionReasonMirroringUnknown {
+			vrgProtected = false
+			vrgMirroringUnknown = condition.Message
+
+			continue
+		}
+
 		// VRGConditionReasonReplicating => VRG secondary, VRGConditionReasonReady => VRG Primary
 		if condition.Reason == VRGConditionReasonReplicating ||
 			condition.Reason == VRGConditionReasonReady {
@@ -2381,6 +2620,7 @@ func (v *VRGInstance) aggregateVolRepDataProtectedCondition() *metav1.Condition
 			// Even a single pvc seeing error means, entire VRG marks this
 			// condition as error. Set vrgReplicating to false
 			vrgReplicating = false
+			vrgMirroringUnknown = ""
 
 			v.log.Info(fmt.Sprintf("Condition %s has error reason %s for vrg",
 				VRGConditionTypeDataProtected, condition.Reason))
@@ -2397,6 +2637,12 @@ func (v *VRGInstance) aggregateVolRepDataProtectedCondition() *metav1.Condition
 		return newVRGAsDataProtectedCondition(v.instance.Generation, msg)
 	}
 
+	if vrgMirroringUnknown != "" {
+		v.log.Info("Marking VRG data protection unknown, as the storage does not report the mirroring state")
+
+		return newVRGAsDataProtectedUnknownCondition(v.instance.Generation, vrgMirroringUnknown)
+	}
+
 	if vrgReplicating {
 		v.log.Info("Marking VRG data protection false with replicating reason")
 
@@ -7,13 +7,16 @@ import (
 	"fmt"
 	"reflect"
 	"strings"
+	"time"
 
 	"github.com/go-logr/logr"
 	ramendrv1alpha1 "github.com/ramendr/ramen/api/v1alpha1"
 	"github.com/ramendr/ramen/controllers/util"
 	"github.com/ramendr/ramen/controllers/volsync"
+	"golang.org/x/exp/slices"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
 )
 
 func (v *VRGInstance) restorePVsAndPVCsForVolSync() (int, error) {
@@ -28,6 +31,7 @@ func (v *VRGInstance) restorePVsAndPVCsForVolSync() (int, error) {
 	numPVsRestored := 0
 
 	for _, rdSpec := range v.instance.Spec.VolSync.RDSpec {
+		rdSpec = v.rdSpecStorageClassMapped(rdSpec)
 		failoverAction := v.instance.Spec.Action == ramendrv1alpha1.VRGActionFailover
 		// Create a PVC from snapshot or for direct copy
 		err := v.volSyncHandler.EnsurePVCfromRD(rdSpec, failoverAction)
@@ -74,6 +78,9 @@ func (v *VRGInstance) reconcileVolSyncAsPrimary(finalSyncPrepared *bool) (requeu
 		v.instance.Status.FinalSyncComplete = v.instance.Spec.RunFinalSync
 	}
 
+	// The ReplicationDestinations, and their services, are deleted once primary
+	v.instance.Status.VolSyncRDNodePorts = nil
+
 	if len(v.volSyncPVCs) == 0 {
 		finalSyncComplete()
 
@@ -82,6 +89,8 @@ func (v *VRGInstance) reconcileVolSyncAsPrimary(finalSyncPrepared *bool) (requeu
 
 	v.log.Info(fmt.Sprintf("Reconciling VolSync as Primary. %d VolSyncPVCs", len(v.volSyncPVCs)))
 
+	v.volSyncKeysStatusUpdate()
+
 	// Cleanup - this VRG is primary, cleanup if necessary
 	// remove any ReplicationDestinations (that would have been created when this VRG was secondary) if they
 	// are not in the RDSpec list
@@ -93,6 +102,14 @@ func (v *VRGInstance) reconcileVolSyncAsPrimary(finalSyncPrepared *bool) (requeu
 		return
 	}
 
+	if err := v.volSyncReplicationSourcesStagger(); err != nil {
+		v.log.Error(err, "Failed to stagger the ReplicationSources")
+
+		requeue = true
+
+		return
+	}
+
 	for _, pvc := range v.volSyncPVCs {
 		requeuePVC := v.reconcilePVCAsVolSyncPrimary(pvc)
 		if requeuePVC {
@@ -100,23 +117,72 @@ func (v *VRGInstance) reconcileVolSyncAsPrimary(finalSyncPrepared *bool) (requeu
 		}
 	}
 
+	// Applications are resumed once each ReplicationSource is reconciled, and unless they are to be relocated
+	resume := !requeue && !v.instance.Spec.PrepareForFinalSync && !v.instance.Spec.RunFinalSync
+
+	if err := v.staticVolumesQuiesceReconcile(resume); err != nil {
+		v.log.Error(err, "Failed to quiesce or resume the applications of static volumes")
+
+		requeue = true
+	}
+
+	v.volSyncBlackoutWindowDelaySet()
+	v.volSyncScheduleDelaySet()
+	v.volSyncStaticVolumesDelaySet()
+
 	if requeue {
 		v.log.Info("Not all ReplicationSources completed setup. We'll retry...")
 
 		return requeue
 	}
 
+	if v.instance.Spec.RunFinalSync && v.volSyncCleanupAfterFinalSync() {
+		return true
+	}
+
 	finalSyncComplete()
 	v.log.Info("Successfully reconciled VolSync as Primary")
 
 	return requeue
 }
 
+// volSyncCleanupAfterFinalSync removes the PVCs once the final sync of all of them is complete, and not before,
+// so that the workload can be restored from them if the relocation is aborted
+func (v *VRGInstance) volSyncCleanupAfterFinalSync() (requeue bool) {
+	for _, pvc := range v.volSyncPVCs {
+		protectedPVC := FindProtectedPVC(v.instance, pvc.Namespace, pvc.Name)
+		if protectedPVC == nil {
+			continue
+		}
+
+		err := v.volSyncHandler.CleanupAfterFinalSync(ramendrv1alpha1.VolSyncReplicationSourceSpec{
+			ProtectedPVC: *protectedPVC,
+		})
+		if err != nil {
+			v.log.Info("Failed to cleanup PVC after final sync", "pvc", pvc.Name, "error", err)
+
+			requeue = true
+		}
+	}
+
+	return requeue
+}
+
 func (v *VRGInstance) reconcilePVCAsVolSyncPrimary(pvc corev1.PersistentVolumeClaim) (requeue bool) {
+	schedulingInterval, schedulingIntervalErr := v.pvcSchedulingInterval(&pvc)
+
+	staticPV, err := v.pvcStaticPV(&pvc)
+	if err != nil {
+		v.log.Info("Failed to check PV of PVC", "pvc", pvc.Name, "error", err)
+
+		return true
+	}
+
 	newProtectedPVC := &ramendrv1alpha1.ProtectedPVC{
 		Name:               pvc.Name,
 		Namespace:          pvc.Namespace,
 		ProtectedByVolSync: true,
+		SchedulingInterval: schedulingInterval,
 		StorageClassName:   pvc.Spec.StorageClassName,
 		Annotations:        protectedPVCAnnotations(pvc),
 		Labels:             pvc.Labels,
@@ -124,28 +190,63 @@ func (v *VRGInstance) reconcilePVCAsVolSyncPrimary(pvc corev1.PersistentVolumeCl
 		Resources:          pvc.Spec.Resources,
 	}
 
+	if staticPV != nil {
+		newProtectedPVC.StaticPVName = staticPV.GetName()
+	}
+
 	protectedPVC := FindProtectedPVC(v.instance, pvc.Namespace, pvc.Name)
 	if protectedPVC == nil {
 		protectedPVC = newProtectedPVC
 		v.instance.Status.ProtectedPVCs = append(v.instance.Status.ProtectedPVCs, *protectedPVC)
 	} else if !reflect.DeepEqual(protectedPVC, newProtectedPVC) {
 		newProtectedPVC.Conditions = protectedPVC.Conditions
+		newProtectedPVC.LastSyncBytes = protectedPVC.LastSyncBytes
+		newProtectedPVC.FinalSyncComplete = protectedPVC.FinalSyncComplete
 		newProtectedPVC.DeepCopyInto(protectedPVC)
 	}
 
+	if schedulingIntervalErr != nil {
+		v.log.Info("Invalid PVC scheduling interval", "pvc", pvc.Name, "error", schedulingIntervalErr)
+
+		setVRGConditionTypeVolSyncRepSourceSetupError(&protectedPVC.Conditions, v.instance.Generation,
+			schedulingIntervalErr.Error())
+
+		return true
+	}
+
 	// Not much need for VolSyncReplicationSourceSpec anymore - but keeping it around in case we want
 	// to add anything to it later to control anything in the ReplicationSource
 	rsSpec := ramendrv1alpha1.VolSyncReplicationSourceSpec{
 		ProtectedPVC: *protectedPVC,
 	}
 
-	err := v.volSyncHandler.PreparePVC(util.ProtectedPVCNamespacedName(*protectedPVC),
+	err = v.volSyncHandler.PreparePVC(util.ProtectedPVCNamespacedName(*protectedPVC),
 		v.instance.Spec.PrepareForFinalSync,
-		v.volSyncHandler.IsCopyMethodDirect())
+		v.volSyncHandler.IsCopyMethodDirect() || staticPV != nil)
 	if err != nil {
 		return true
 	}
 
+	// Once the PVC is restored and no longer replicated to, its recovery points are not needed
+	if len(v.instance.Spec.VolSync.RDSpec) == 0 {
+		if err := v.volSyncHandler.DeleteRecoveryPoints(&pvc); err != nil {
+			v.log.Info("Failed to delete recovery points of PVC", "pvc", pvc.Name, "error", err)
+
+			return true
+		}
+	}
+
+	if staticPV != nil {
+		if err := v.staticPVArchive(staticPV); err != nil {
+			v.log.Info("Failed to archive static PV", "pvc", pvc.Name, "error", err)
+
+			setVRGConditionTypeVolSyncRepSourceSetupError(&protectedPVC.Conditions, v.instance.Generation,
+				"Static PV archive failed")
+
+			return true
+		}
+	}
+
 	// reconcile RS and if runFinalSync is true, then one final sync will be run
 	finalSyncComplete, rs, err := v.volSyncHandler.ReconcileRS(rsSpec, v.instance.Spec.RunFinalSync)
 	if err != nil {
@@ -169,6 +270,15 @@ func (v *VRGInstance) reconcilePVCAsVolSyncPrimary(pvc corev1.PersistentVolumeCl
 		protectedPVC.LastSyncDuration = rs.Status.LastSyncDuration
 	}
 
+	// Bytes of a failed sync are not reported, so keep those of the most recent successful one
+	if lastSyncBytes := volsync.ReplicationSourceLastSyncBytes(rs); lastSyncBytes != nil {
+		protectedPVC.LastSyncBytes = lastSyncBytes
+	}
+
+	protectedPVC.SyncInProgress, protectedPVC.SyncStartTime, protectedPVC.EstimatedSyncCompletionTime =
+		volsync.ReplicationSourceSyncProgress(rs)
+	protectedPVC.FinalSyncComplete = v.instance.Spec.RunFinalSync && finalSyncComplete
+
 	return v.instance.Spec.RunFinalSync && !finalSyncComplete
 }
 
@@ -195,15 +305,131 @@ func (v *VRGInstance) reconcileVolSyncAsSecondary() bool {
 	v.instance.Status.PrepareForFinalSyncComplete = false
 	v.instance.Status.FinalSyncComplete = false
 
-	return v.reconcileRDSpecForDeletionOrReplication()
+	if v.volSyncHandler.IsMoverRestic() {
+		v.volSyncBlackoutWindowDelaySet()
+		v.volSyncScheduleDelaySet()
+	}
+
+	v.volSyncKeysStatusUpdate()
+
+	requeue := v.reconcileRDSpecForDeletionOrReplication()
+
+	v.volSyncRDNodePortsStatusUpdate()
+
+	return requeue
+}
+
+// volSyncRDNodePortsStatusUpdate reports the node ports of the NodePort services of the ReplicationDestinations, for
+// the hub to pass them to the primary VRG. A node port is kept, until its PVC is no longer replicated to, while its
+// ReplicationDestination fails to reconcile.
+func (v *VRGInstance) volSyncRDNodePortsStatusUpdate() {
+	nodePorts := v.volSyncHandler.RDNodePorts()
+
+	for _, nodePort := range v.instance.Status.VolSyncRDNodePorts {
+		reported := slices.ContainsFunc(nodePorts, func(reported ramendrv1alpha1.VolSyncNodePort) bool {
+			return reported.Namespace == nodePort.Namespace && reported.Name == nodePort.Name
+		})
+		replicated := slices.ContainsFunc(v.instance.Spec.VolSync.RDSpec,
+			func(rdSpec ramendrv1alpha1.VolSyncReplicationDestinationSpec) bool {
+				return rdSpec.ProtectedPVC.Namespace == nodePort.Namespace && rdSpec.ProtectedPVC.Name == nodePort.Name
+			})
+
+		if !reported && replicated {
+			nodePorts = append(nodePorts, nodePort)
+		}
+	}
+
+	v.instance.Status.VolSyncRDNodePorts = nodePorts
+}
+
+// volSyncKeysStatusUpdate reports the identities of the keys of the VolSync pre-shared key secret, and when they
+// were observed, for the hub to rotate the key once the movers have restarted with them
+func (v *VRGInstance) volSyncKeysStatusUpdate() {
+	identities, err := v.volSyncHandler.GetPSKIdentities()
+	if err != nil {
+		v.log.Info("Failed to get VolSync pre-shared key identities", "error", err)
+
+		return
+	}
+
+	if len(identities) == 0 {
+		return
+	}
+
+	if v.instance.Status.VolSyncKeys != nil && slices.Equal(v.instance.Status.VolSyncKeys.Identities, identities) {
+		return
+	}
+
+	v.instance.Status.VolSyncKeys = &ramendrv1alpha1.VolSyncKeysStatus{
+		Identities:   identities,
+		ObservedTime: metav1.Now(),
+	}
+}
+
+// volSyncReplicationSourcesStagger caps the number of ReplicationSources of the VolSync PVCs that sync at the
+// same time, if the VolSync profile requests it. A ReplicationSource waiting for others to complete is resumed by
+// a reconcile on a status update of another.
+func (v *VRGInstance) volSyncReplicationSourcesStagger() error {
+	pvcs := make([]types.NamespacedName, 0, len(v.volSyncPVCs))
+	for _, pvc := range v.volSyncPVCs {
+		pvcs = append(pvcs, types.NamespacedName{Namespace: pvc.Namespace, Name: pvc.Name})
+	}
+
+	waiting, err := v.volSyncHandler.StaggerReplicationSources(pvcs)
+	if err != nil {
+		return err
+	}
+
+	if waiting > 0 {
+		v.log.Info("ReplicationSources waiting for others to complete syncing", "count", waiting)
+	}
+
+	return nil
+}
+
+// volSyncBlackoutWindowDelaySet requeues the VRG when a blackout window starts or ends, to pause or resume its
+// ReplicationSources or restic ReplicationDestinations
+func (v *VRGInstance) volSyncBlackoutWindowDelaySet() {
+	if _, next := v.volSyncHandler.BlackoutWindowActive(); !next.IsZero() {
+		delaySetIfLess(&v.result, time.Until(next), v.log)
+	}
+}
+
+// volSyncScheduleDelaySet requeues the VRG when a schedule that VolSync does not accept, and that is triggered
+// manually instead, is next due
+func (v *VRGInstance) volSyncScheduleDelaySet() {
+	if next := v.volSyncHandler.ScheduledSyncNext(); !next.IsZero() {
+		delaySetIfLess(&v.result, time.Until(next), v.log)
+	}
+}
+
+// volSyncStaticVolumesDelaySet requeues the VRG while the application of a static volume is quiesced for it to
+// sync, to trigger the sync once the application stops using it, and to resume the application once it completes
+func (v *VRGInstance) volSyncStaticVolumesDelaySet() {
+	if len(v.volSyncHandler.StaticVolumesQuiesce()) != 0 {
+		delaySetIfLess(&v.result, staticVolumeQuiescePollInterval, v.log)
+	}
 }
 
 func (v *VRGInstance) reconcileRDSpecForDeletionOrReplication() bool {
 	requeue := false
 
 	for _, rdSpec := range v.instance.Spec.VolSync.RDSpec {
+		rdSpec = v.rdSpecStorageClassMapped(rdSpec)
 		v.log.Info("Reconcile RD as Secondary", "RDSpec", rdSpec)
 
+		if volsync.IsStaticVolume(rdSpec.ProtectedPVC) {
+			if err := v.staticPVRestore(rdSpec.ProtectedPVC); err != nil {
+				v.log.Error(err, "Failed to restore static PV")
+				util.ReportIfNotPresent(v.reconciler.eventRecorder, v.instance, corev1.EventTypeWarning,
+					util.EventReasonStaticVolumeRestoreFailed, err.Error())
+
+				requeue = true
+
+				break
+			}
+		}
+
 		rd, err := v.volSyncHandler.ReconcileRD(rdSpec)
 		if err != nil {
 			v.log.Error(err, "Failed to reconcile VolSync Replication Destination")
@@ -218,6 +444,14 @@ func (v *VRGInstance) reconcileRDSpecForDeletionOrReplication() bool {
 				rdSpec.ProtectedPVC.Name))
 
 			requeue = true
+
+			continue
+		}
+
+		if err := v.volSyncRecoveryPointsReconcile(rdSpec); err != nil {
+			v.log.Error(err, "Failed to reconcile VolSync recovery points")
+
+			requeue = true
 		}
 	}
 
@@ -228,6 +462,33 @@ func (v *VRGInstance) reconcileRDSpecForDeletionOrReplication() bool {
 	return requeue
 }
 
+// volSyncRecoveryPointsReconcile retains the recovery points of the PVC of a ReplicationDestination and lists them
+// in the PVC's status
+func (v *VRGInstance) volSyncRecoveryPointsReconcile(rdSpec ramendrv1alpha1.VolSyncReplicationDestinationSpec) error {
+	recoveryPoints, err := v.volSyncHandler.ReconcileRecoveryPoints(rdSpec)
+	if err != nil {
+		return err
+	}
+
+	protectedPVC := FindProtectedPVC(v.instance, rdSpec.ProtectedPVC.Namespace, rdSpec.ProtectedPVC.Name)
+	if protectedPVC != nil {
+		protectedPVC.RecoveryPoints = recoveryPoints
+
+		return nil
+	}
+
+	if len(recoveryPoints) == 0 {
+		return nil
+	}
+
+	protectedPVC = &ramendrv1alpha1.ProtectedPVC{}
+	rdSpec.ProtectedPVC.DeepCopyInto(protectedPVC)
+	protectedPVC.RecoveryPoints = recoveryPoints
+	v.instance.Status.ProtectedPVCs = append(v.instance.Status.ProtectedPVCs, *protectedPVC)
+
+	return nil
+}
+
 func (v *VRGInstance) aggregateVolSyncDataReadyCondition() *metav1.Condition {
 	dataReadyCondition := &metav1.Condition{
 		Status:             metav1.ConditionTrue,
@@ -446,3 +707,74 @@ func (v *VRGInstance) cleanupResources() error {
 
 	return nil
 }
+
+// volSyncProfile returns the VolSync profile of the VRG's DRPolicy, passed in its async spec, if any, or else the
+// profile of the RamenConfig
+func (v *VRGInstance) volSyncProfile() *ramendrv1alpha1.VolSyncProfile {
+	if v.instance.Spec.Async != nil && v.instance.Spec.Async.VolSyncProfile != nil {
+		return v.instance.Spec.Async.VolSyncProfile
+	}
+
+	return v.ramenConfig.VolSyncProfile
+}
+
+// volSyncResticRepository returns the restic repository of the VRG's PVCs, in the S3 store of the profile's restic
+// S3 profile, or of the VRG's first S3 profile
+func (v *VRGInstance) volSyncResticRepository(profile *ramendrv1alpha1.VolSyncProfile,
+) (*volsync.ResticRepository, error) {
+	s3ProfileName := profile.ResticS3ProfileName
+	if s3ProfileName == "" {
+		if len(v.instance.Spec.S3Profiles) == 0 || v.instance.Spec.S3Profiles[0] == NoS3StoreAvailable {
+			return nil, fmt.Errorf("no S3 profile for restic repository")
+		}
+
+		s3ProfileName = v.instance.Spec.S3Profiles[0]
+	}
+
+	s3StoreProfile := RamenConfigS3StoreProfilePointerGet(v.ramenConfig, s3ProfileName)
+	if s3StoreProfile == nil {
+		return nil, fmt.Errorf("s3 profile %s not found in RamenConfig", s3ProfileName)
+	}
+
+	accessKeyID, secretAccessKey, err := GetS3Secret(v.ctx, v.reconciler.APIReader, s3StoreProfile.S3SecretRef)
+	if err != nil {
+		return nil, err
+	}
+
+	return &volsync.ResticRepository{
+		URL: fmt.Sprintf("s3:%s/%s/%svolsync", strings.TrimSuffix(s3StoreProfile.S3CompatibleEndpoint, "/"),
+			s3StoreProfile.S3Bucket, v.s3KeyPrefix()),
+		Region:          s3StoreProfile.S3Region,
+		AccessKeyID:     accessKeyID,
+		SecretAccessKey: secretAccessKey,
+		CACertificates:  s3StoreProfile.CACertificates,
+	}, nil
+}
+
+// volSyncProfileSet sets the VolSync profile and peer clusters of the VRG, and the restic repository of its Restic
+// mover
+func (v *VRGInstance) volSyncProfileSet() {
+	profile := v.volSyncProfile()
+	v.volSyncHandler.SetProfile(profile)
+
+	if v.instance.Spec.Action == ramendrv1alpha1.VRGActionFailover {
+		v.volSyncHandler.SetRecoveryPointTime(v.instance.Spec.VolSync.RecoveryPointTime)
+	}
+
+	v.volSyncHandler.SetPeerClusters(v.instance.GetAnnotations()[DestinationClusterAnnotationKey],
+		v.instance.Spec.VolSync.Peers)
+	v.volSyncHandler.SetPeerRDNodePorts(v.instance.Spec.VolSync.RDNodePorts)
+
+	if profile == nil || profile.Mover != ramendrv1alpha1.VolSyncMoverRestic {
+		return
+	}
+
+	repository, err := v.volSyncResticRepository(profile)
+	if err != nil {
+		v.log.Info("Failed to get restic repository", "error", err)
+
+		return
+	}
+
+	v.volSyncHandler.SetResticRepository(repository)
+}
@@ -11,6 +11,7 @@ import (
 	. "github.com/onsi/gomega"
 
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
@@ -19,6 +20,7 @@ import (
 	volsyncv1alpha1 "github.com/backube/volsync/api/v1alpha1"
 	snapv1 "github.com/kubernetes-csi/external-snapshotter/client/v4/apis/volumesnapshot/v1"
 	ramendrv1alpha1 "github.com/ramendr/ramen/api/v1alpha1"
+	"github.com/ramendr/ramen/controllers"
 	"github.com/ramendr/ramen/controllers/volsync"
 	storagev1 "k8s.io/api/storage/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
@@ -210,6 +212,64 @@ var _ = Describe("VolumeReplicationGroupVolSyncController", func() {
 						Expect(*rs2.Spec.Trigger.Schedule).To(Equal("0 */1 * * *")) // scheduling interval was set to 1h
 					})
 				})
+
+				Context("When the final sync is canceled before it completes", func() {
+					It("Should keep the PVCs to restore the workload from, and resume the scheduled syncs", func() {
+						Eventually(func() error {
+							Expect(k8sClient.Get(testCtx, client.ObjectKeyFromObject(testVsrg), testVsrg)).To(Succeed())
+							testVsrg.Spec.PrepareForFinalSync = true
+
+							return k8sClient.Update(testCtx, testVsrg)
+						}, testMaxWait, testInterval).Should(Succeed())
+
+						Eventually(func() bool {
+							Expect(k8sClient.Get(testCtx, client.ObjectKeyFromObject(testVsrg), testVsrg)).To(Succeed())
+
+							return testVsrg.Status.PrepareForFinalSyncComplete
+						}, testMaxWait, testInterval).Should(BeTrue())
+
+						Eventually(func() error {
+							Expect(k8sClient.Get(testCtx, client.ObjectKeyFromObject(testVsrg), testVsrg)).To(Succeed())
+							testVsrg.Spec.RunFinalSync = true
+
+							return k8sClient.Update(testCtx, testVsrg)
+						}, testMaxWait, testInterval).Should(Succeed())
+
+						// The PVCs are mounted, so the final sync does not complete
+						Consistently(func() bool {
+							Expect(k8sClient.Get(testCtx, client.ObjectKeyFromObject(testVsrg), testVsrg)).To(Succeed())
+
+							return testVsrg.Status.FinalSyncComplete
+						}, 2*time.Second, testInterval).Should(BeFalse())
+
+						Eventually(func() error {
+							Expect(k8sClient.Get(testCtx, client.ObjectKeyFromObject(testVsrg), testVsrg)).To(Succeed())
+							testVsrg.Spec.PrepareForFinalSync = false
+							testVsrg.Spec.RunFinalSync = false
+
+							return k8sClient.Update(testCtx, testVsrg)
+						}, testMaxWait, testInterval).Should(Succeed())
+
+						for i := range boundPvcs {
+							pvc := &corev1.PersistentVolumeClaim{}
+							Expect(k8sClient.Get(testCtx, client.ObjectKeyFromObject(&boundPvcs[i]), pvc)).To(Succeed())
+							Expect(pvc.GetDeletionTimestamp()).To(BeNil())
+							Expect(pvc.GetOwnerReferences()).To(ContainElement(
+								HaveField("Name", Equal(testVsrg.GetName()))))
+
+							rs := &volsyncv1alpha1.ReplicationSource{}
+							Eventually(func() *string {
+								Expect(k8sClient.Get(testCtx, client.ObjectKeyFromObject(&boundPvcs[i]), rs)).To(Succeed())
+
+								if rs.Spec.Trigger == nil {
+									return nil
+								}
+
+								return rs.Spec.Trigger.Schedule
+							}, testMaxWait, testInterval).Should(HaveValue(Equal("0 */1 * * *")))
+						}
+					})
+				})
 			})
 		})
 	})
@@ -366,6 +426,74 @@ var _ = Describe("VolumeReplicationGroupVolSyncController", func() {
 						Expect(k8sClient.Status().Update(testCtx, rd1)).To(Succeed())
 					})
 				})
+
+				Context("When a test failover is requested", func() {
+					var testFailoverNamespaceName string
+
+					testFailoverCondition := func() *metav1.Condition {
+						vrg := &ramendrv1alpha1.VolumeReplicationGroup{}
+						Expect(k8sClient.Get(testCtx, client.ObjectKeyFromObject(testVrg), vrg)).To(Succeed())
+
+						return meta.FindStatusCondition(vrg.Status.Conditions, controllers.VRGConditionTypeTestFailover)
+					}
+
+					JustBeforeEach(func() {
+						testFailoverNamespaceName = testNamespace.GetName() + "-test"
+
+						Eventually(func() error {
+							if err := k8sClient.Get(testCtx, client.ObjectKeyFromObject(testVrg), testVrg); err != nil {
+								return err
+							}
+
+							testVrg.Spec.TestFailover = &ramendrv1alpha1.TestFailoverSpec{
+								NamespaceMapping: map[string]string{testNamespace.GetName(): testFailoverNamespaceName},
+							}
+
+							return k8sClient.Update(testCtx, testVrg)
+						}, testMaxWait, testInterval).Should(Succeed())
+					})
+
+					It("Should create the target namespace and wait for the replicated data to restore", func() {
+						namespace := &corev1.Namespace{}
+						Eventually(func() error {
+							return k8sClient.Get(testCtx, types.NamespacedName{Name: testFailoverNamespaceName}, namespace)
+						}, testMaxWait, testInterval).Should(Succeed())
+
+						for key, value := range volsync.TestFailoverLabels(testVrg) {
+							Expect(namespace.GetLabels()).To(HaveKeyWithValue(key, value))
+						}
+
+						Eventually(testFailoverCondition, testMaxWait, testInterval).Should(And(
+							Not(BeNil()),
+							HaveField("Status", metav1.ConditionFalse),
+							HaveField("Reason", controllers.VRGConditionReasonProgressing),
+						))
+					})
+
+					It("Should delete the target namespace once the test failover is no longer requested", func() {
+						Eventually(testFailoverCondition, testMaxWait, testInterval).ShouldNot(BeNil())
+
+						Eventually(func() error {
+							if err := k8sClient.Get(testCtx, client.ObjectKeyFromObject(testVrg), testVrg); err != nil {
+								return err
+							}
+
+							testVrg.Spec.TestFailover = nil
+
+							return k8sClient.Update(testCtx, testVrg)
+						}, testMaxWait, testInterval).Should(Succeed())
+
+						Eventually(testFailoverCondition, testMaxWait, testInterval).Should(BeNil())
+
+						Eventually(func() bool {
+							namespace := &corev1.Namespace{}
+							err := k8sClient.Get(testCtx, types.NamespacedName{Name: testFailoverNamespaceName}, namespace)
+
+							// envtest has no namespace controller to finalize the deletion
+							return errors.IsNotFound(err) || (err == nil && !namespace.GetDeletionTimestamp().IsZero())
+						}, testMaxWait, testInterval).Should(BeTrue())
+					})
+				})
 			})
 		})
 	})
@@ -0,0 +1,638 @@
+// SPDX-FileCopyrightText: The RamenDR authors
+// SPDX-License-Identifier: Apache-2.0
+
+package controllers
+
+import (
+	"errors"
+	"fmt"
+
+	snapv1 "github.com/kubernetes-csi/external-snapshotter/client/v4/apis/volumesnapshot/v1"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	ramendrv1alpha1 "github.com/ramendr/ramen/api/v1alpha1"
+	"github.com/ramendr/ramen/controllers/util"
+	"github.com/ramendr/ramen/controllers/volsync"
+)
+
+const (
+	// VolumeSnapshots are archived apart from PVs and PVCs, which are all restored on failover
+	volumeSnapshotsS3KeySuffix = "volumesnapshots/"
+
+	// VolumeSnapshotPVCLabel labels a PVC restored from a VolumeSnapshot, for VolSync to replicate the snapshot's
+	// data. Its value is the name of the VolumeSnapshot.
+	VolumeSnapshotPVCLabel = volsync.VolumeSnapshotPVCLabel
+
+	// volumeSnapshotUIDAnnotation records the UID of the VolumeSnapshot that a PVC was restored from, or that was
+	// restored from the PVC. A PVC restored on a peer cluster has none, and the VolumeSnapshot is restored from it.
+	volumeSnapshotUIDAnnotation = "ramendr.openshift.io/volumesnapshot-uid"
+
+	volumeSnapshotPVCNamePrefix = "ramen-vs-"
+)
+
+func volumeSnapshotPVCName(volumeSnapshotName string) string {
+	return volumeSnapshotPVCNamePrefix + volumeSnapshotName
+}
+
+func (v *VRGInstance) volumeSnapshotsS3KeyPrefix() string {
+	return v.s3KeyPrefix() + volumeSnapshotsS3KeySuffix
+}
+
+// volumeSnapshotPVCsAppend appends the PVCs restored from protected VolumeSnapshots that are not already in the
+// list, for VolSync to replicate them
+func (v *VRGInstance) volumeSnapshotPVCsAppend(pvcList *corev1.PersistentVolumeClaimList) error {
+	if v.instance.Spec.VolumeSnapshotProtection == nil {
+		return nil
+	}
+
+	pvcs := sets.New[types.NamespacedName]()
+	for i := range pvcList.Items {
+		pvcs.Insert(client.ObjectKeyFromObject(&pvcList.Items[i]))
+	}
+
+	for _, namespaceName := range v.recipeElements.PvcSelector.NamespaceNames {
+		volumeSnapshotPVCs := corev1.PersistentVolumeClaimList{}
+		if err := v.reconciler.List(v.ctx, &volumeSnapshotPVCs, client.InNamespace(namespaceName),
+			client.HasLabels{VolumeSnapshotPVCLabel}); err != nil {
+			return fmt.Errorf("failed to list VolumeSnapshot PVCs in namespace %s (%w)", namespaceName, err)
+		}
+
+		for i := range volumeSnapshotPVCs.Items {
+			pvc := &volumeSnapshotPVCs.Items[i]
+			if pvcs.Has(client.ObjectKeyFromObject(pvc)) {
+				continue
+			}
+
+			pvcList.Items = append(pvcList.Items, *pvc)
+			pvcs.Insert(client.ObjectKeyFromObject(pvc))
+		}
+	}
+
+	return nil
+}
+
+// reconcileVolumeSnapshotsAsPrimary protects the selected, ready, VolumeSnapshots of the protected PVCs. The
+// VolumeSnapshot and VolumeSnapshotContent of a PVC that the storage replicates are archived to the S3 stores, if
+// the storage replicates its snapshots. A PVC that VolSync replicates has a PVC restored from its VolumeSnapshot,
+// which VolSync replicates as well. It returns true if a VolumeSnapshot is yet to be protected.
+func (v *VRGInstance) reconcileVolumeSnapshotsAsPrimary() (requeue bool) {
+	if v.instance.Spec.VolumeSnapshotProtection == nil {
+		if err := v.volumeSnapshotProtectionDelete(); err != nil {
+			v.log.Info("Failed to delete VolumeSnapshot protection", "error", err)
+
+			return true
+		}
+
+		return false
+	}
+
+	protectedVolumeSnapshots, err := v.volumeSnapshotPVCsReconcile()
+	if err != nil {
+		v.log.Info("Failed to reconcile VolumeSnapshot PVCs", "error", err)
+
+		requeue = true
+	}
+
+	volumeSnapshots, err := v.volumeSnapshotsList()
+	if err != nil {
+		v.log.Info("Failed to list VolumeSnapshots", "error", err)
+
+		return true
+	}
+
+	storageReplicatesSnapshots := v.instance.Spec.VolumeSnapshotProtection.StorageReplicatesSnapshots
+
+	volRepPVCs := sets.New[types.NamespacedName]()
+	for i := range v.volRepPVCs {
+		volRepPVCs.Insert(client.ObjectKeyFromObject(&v.volRepPVCs[i]))
+	}
+
+	volSyncPVCs := map[types.NamespacedName]*corev1.PersistentVolumeClaim{}
+	for i := range v.volSyncPVCs {
+		volSyncPVCs[client.ObjectKeyFromObject(&v.volSyncPVCs[i])] = &v.volSyncPVCs[i]
+	}
+
+	for i := range volumeSnapshots {
+		vs := &volumeSnapshots[i]
+		log := v.log.WithValues("VolumeSnapshot", client.ObjectKeyFromObject(vs).String())
+
+		sourcePVCName := types.NamespacedName{Namespace: vs.Namespace}
+		if vs.Spec.Source.PersistentVolumeClaimName != nil {
+			sourcePVCName.Name = *vs.Spec.Source.PersistentVolumeClaimName
+		}
+
+		sourcePVC, volSync := volSyncPVCs[sourcePVCName]
+		volRep := storageReplicatesSnapshots &&
+			(volRepPVCs.Has(sourcePVCName) || vs.GetAnnotations()[RestoreAnnotation] == RestoredByRamen)
+
+		// A VolumeSnapshot restored from a VolumeSnapshot PVC is protected by it
+		if !volSync && !volRep || volSync && sourcePVC.GetLabels()[VolumeSnapshotPVCLabel] != "" {
+			continue
+		}
+
+		if vs.Status == nil || vs.Status.ReadyToUse == nil || !*vs.Status.ReadyToUse {
+			log.Info("VolumeSnapshot not ready to protect")
+
+			requeue = true
+
+			continue
+		}
+
+		protectedVolumeSnapshot := ramendrv1alpha1.ProtectedVolumeSnapshot{Namespace: vs.Namespace, Name: vs.Name}
+
+		if volSync {
+			protectedVolumeSnapshot.PVCName, err = v.volumeSnapshotPVCEnsure(vs, sourcePVC)
+		} else {
+			protectedVolumeSnapshot.VolumeSnapshotContentName, protectedVolumeSnapshot.Archived, err =
+				v.volumeSnapshotArchive(vs)
+		}
+
+		if err != nil {
+			log.Info("Failed to protect VolumeSnapshot", "error", err)
+
+			requeue = true
+
+			continue
+		}
+
+		protectedVolumeSnapshots = append(protectedVolumeSnapshots, protectedVolumeSnapshot)
+	}
+
+	if err := v.volumeSnapshotsArchivePrune(protectedVolumeSnapshots); err != nil {
+		v.log.Info("Failed to delete archived VolumeSnapshots no longer protected", "error", err)
+
+		requeue = true
+	}
+
+	v.instance.Status.ProtectedVolumeSnapshots = protectedVolumeSnapshots
+
+	return requeue
+}
+
+// volumeSnapshotSelector returns the selector of the VolumeSnapshots protected by the VRG
+func (v *VRGInstance) volumeSnapshotSelector() (labels.Selector, error) {
+	labelSelector := v.instance.Spec.VolumeSnapshotProtection.VolumeSnapshotSelector
+	if labelSelector == nil {
+		return labels.Everything(), nil
+	}
+
+	selector, err := metav1.LabelSelectorAsSelector(labelSelector)
+	if err != nil {
+		return nil, fmt.Errorf("invalid VolumeSnapshot selector (%w)", err)
+	}
+
+	return selector, nil
+}
+
+// volumeSnapshotsList returns the VolumeSnapshots in the protected namespaces selected by the VRG
+func (v *VRGInstance) volumeSnapshotsList() ([]snapv1.VolumeSnapshot, error) {
+	selector, err := v.volumeSnapshotSelector()
+	if err != nil {
+		return nil, err
+	}
+
+	volumeSnapshots := []snapv1.VolumeSnapshot{}
+
+	for _, namespaceName := range v.recipeElements.PvcSelector.NamespaceNames {
+		volumeSnapshotList := snapv1.VolumeSnapshotList{}
+		if err := v.reconciler.List(v.ctx, &volumeSnapshotList, client.InNamespace(namespaceName),
+			client.MatchingLabelsSelector{Selector: selector}); err != nil {
+			return nil, fmt.Errorf("failed to list VolumeSnapshots in namespace %s (%w)", namespaceName, err)
+		}
+
+		volumeSnapshots = append(volumeSnapshots, volumeSnapshotList.Items...)
+	}
+
+	return volumeSnapshots, nil
+}
+
+// volumeSnapshotPVCEnsure creates a PVC from the VolumeSnapshot of a PVC that VolSync replicates, for VolSync to
+// replicate the snapshot's data, unless it exists
+func (v *VRGInstance) volumeSnapshotPVCEnsure(vs *snapv1.VolumeSnapshot, sourcePVC *corev1.PersistentVolumeClaim,
+) (string, error) {
+	pvcName := volumeSnapshotPVCName(vs.Name)
+
+	err := v.reconciler.Get(v.ctx, types.NamespacedName{Namespace: vs.Namespace, Name: pvcName},
+		&corev1.PersistentVolumeClaim{})
+	if err == nil || !k8serrors.IsNotFound(err) {
+		return pvcName, err
+	}
+
+	storage := sourcePVC.Spec.Resources.Requests[corev1.ResourceStorage]
+	if vs.Status.RestoreSize != nil && vs.Status.RestoreSize.Cmp(storage) > 0 {
+		storage = *vs.Status.RestoreSize
+	}
+
+	// The VolumeSnapshot's labels are kept for the VolumeSnapshot restored from the PVC to be selected alike
+	pvcLabels := map[string]string{}
+	util.UpdateStringMap(&pvcLabels, vs.GetLabels())
+	pvcLabels[VolumeSnapshotPVCLabel] = vs.Name
+
+	pvc := &corev1.PersistentVolumeClaim{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        pvcName,
+			Namespace:   vs.Namespace,
+			Labels:      pvcLabels,
+			Annotations: map[string]string{volumeSnapshotUIDAnnotation: string(vs.UID)},
+		},
+		Spec: corev1.PersistentVolumeClaimSpec{
+			AccessModes:      sourcePVC.Spec.AccessModes,
+			StorageClassName: sourcePVC.Spec.StorageClassName,
+			VolumeMode:       sourcePVC.Spec.VolumeMode,
+			DataSource: &corev1.TypedLocalObjectReference{
+				APIGroup: &snapv1.SchemeGroupVersion.Group,
+				Kind:     "VolumeSnapshot",
+				Name:     vs.Name,
+			},
+			Resources: corev1.VolumeResourceRequirements{
+				Requests: corev1.ResourceList{corev1.ResourceStorage: storage},
+			},
+		},
+	}
+
+	if err := v.reconciler.Create(v.ctx, pvc); err != nil {
+		return pvcName, fmt.Errorf("failed to create PVC %s from VolumeSnapshot (%w)", pvcName, err)
+	}
+
+	v.log.Info("Created VolumeSnapshot PVC", "VolumeSnapshot", vs.Name, "PVC", pvcName, "namespace", vs.Namespace)
+
+	return pvcName, nil
+}
+
+// volumeSnapshotPVCsReconcile restores the VolumeSnapshots of the VolumeSnapshot PVCs restored from a peer
+// cluster, and deletes the VolumeSnapshot PVCs whose VolumeSnapshot was deleted, or is no longer selected. It
+// returns the VolumeSnapshots protected by a VolumeSnapshot PVC that were restored from one.
+func (v *VRGInstance) volumeSnapshotPVCsReconcile() ([]ramendrv1alpha1.ProtectedVolumeSnapshot, error) {
+	protectedVolumeSnapshots := []ramendrv1alpha1.ProtectedVolumeSnapshot{}
+	errs := []error{}
+
+	selector, err := v.volumeSnapshotSelector()
+	if err != nil {
+		return protectedVolumeSnapshots, err
+	}
+
+	for i := range v.volSyncPVCs {
+		pvc := &v.volSyncPVCs[i]
+		vsName := pvc.GetLabels()[VolumeSnapshotPVCLabel]
+
+		if vsName == "" {
+			continue
+		}
+
+		vs := &snapv1.VolumeSnapshot{}
+
+		err := v.reconciler.Get(v.ctx, types.NamespacedName{Namespace: pvc.Namespace, Name: vsName}, vs)
+		if err != nil && !k8serrors.IsNotFound(err) {
+			errs = append(errs, err)
+
+			continue
+		}
+
+		vsUID, annotated := pvc.GetAnnotations()[volumeSnapshotUIDAnnotation]
+
+		switch {
+		case !annotated && err != nil:
+			err = v.volumeSnapshotRestoreFromPVC(pvc, vsName)
+		case !annotated:
+			err = v.volumeSnapshotPVCAnnotate(pvc, vs)
+		case err != nil || string(vs.UID) != vsUID || !selector.Matches(labels.Set(vs.GetLabels())):
+			err = v.volumeSnapshotPVCDelete(pvc)
+
+			if err == nil {
+				continue
+			}
+		}
+
+		if err != nil {
+			errs = append(errs, err)
+
+			continue
+		}
+
+		// A VolumeSnapshot of the PVC it was restored from is skipped by the VolumeSnapshots list
+		if !annotated || vs.Spec.Source.PersistentVolumeClaimName != nil &&
+			*vs.Spec.Source.PersistentVolumeClaimName == pvc.Name {
+			protectedVolumeSnapshots = append(protectedVolumeSnapshots, ramendrv1alpha1.ProtectedVolumeSnapshot{
+				Namespace: pvc.Namespace, Name: vsName, PVCName: pvc.Name,
+			})
+		}
+	}
+
+	return protectedVolumeSnapshots, errors.Join(errs...)
+}
+
+// volumeSnapshotRestoreFromPVC creates a VolumeSnapshot of a VolumeSnapshot PVC restored from a peer cluster
+func (v *VRGInstance) volumeSnapshotRestoreFromPVC(pvc *corev1.PersistentVolumeClaim, vsName string) error {
+	volumeSnapshotClassName, err := v.volSyncHandler.GetVolumeSnapshotClassFromPVCStorageClass(
+		pvc.Spec.StorageClassName)
+	if err != nil {
+		return err
+	}
+
+	vsLabels := map[string]string{}
+	util.UpdateStringMap(&vsLabels, pvc.GetLabels())
+	delete(vsLabels, VolumeSnapshotPVCLabel)
+
+	vs := &snapv1.VolumeSnapshot{
+		ObjectMeta: metav1.ObjectMeta{Name: vsName, Namespace: pvc.Namespace, Labels: vsLabels},
+		Spec: snapv1.VolumeSnapshotSpec{
+			Source:                  snapv1.VolumeSnapshotSource{PersistentVolumeClaimName: &pvc.Name},
+			VolumeSnapshotClassName: &volumeSnapshotClassName,
+		},
+	}
+	addRestoreAnnotation(vs)
+
+	if err := v.reconciler.Create(v.ctx, vs); err != nil {
+		return fmt.Errorf("failed to restore VolumeSnapshot %s from PVC %s (%w)", vsName, pvc.Name, err)
+	}
+
+	v.log.Info("Restored VolumeSnapshot from PVC", "VolumeSnapshot", vsName, "PVC", pvc.Name,
+		"namespace", pvc.Namespace)
+
+	return v.volumeSnapshotPVCAnnotate(pvc, vs)
+}
+
+func (v *VRGInstance) volumeSnapshotPVCAnnotate(pvc *corev1.PersistentVolumeClaim, vs *snapv1.VolumeSnapshot) error {
+	if pvc.Annotations == nil {
+		pvc.Annotations = map[string]string{}
+	}
+
+	pvc.Annotations[volumeSnapshotUIDAnnotation] = string(vs.UID)
+
+	if err := v.reconciler.Update(v.ctx, pvc); err != nil {
+		return fmt.Errorf("failed to annotate VolumeSnapshot PVC %s (%w)", pvc.Name, err)
+	}
+
+	return nil
+}
+
+// volumeSnapshotPVCDelete deletes a VolumeSnapshot PVC, and its replication
+func (v *VRGInstance) volumeSnapshotPVCDelete(pvc *corev1.PersistentVolumeClaim) error {
+	if err := v.volSyncHandler.DeleteRS(pvc.Name, pvc.Namespace); err != nil {
+		return err
+	}
+
+	if err := v.reconciler.Delete(v.ctx, pvc); err != nil && !k8serrors.IsNotFound(err) {
+		return fmt.Errorf("failed to delete VolumeSnapshot PVC %s (%w)", pvc.Name, err)
+	}
+
+	v.pvcStatusDeleteIfPresent(pvc.Namespace, pvc.Name, v.log)
+	v.log.Info("Deleted VolumeSnapshot PVC", "PVC", pvc.Name, "namespace", pvc.Namespace)
+
+	return nil
+}
+
+// volumeSnapshotPVCsDelete deletes the VolumeSnapshot PVCs in the protected namespaces, and their replication
+func (v *VRGInstance) volumeSnapshotPVCsDelete() error {
+	errs := []error{}
+
+	for _, namespaceName := range v.recipeElements.PvcSelector.NamespaceNames {
+		volumeSnapshotPVCs := corev1.PersistentVolumeClaimList{}
+		if err := v.reconciler.List(v.ctx, &volumeSnapshotPVCs, client.InNamespace(namespaceName),
+			client.HasLabels{VolumeSnapshotPVCLabel}); err != nil {
+			errs = append(errs, fmt.Errorf("failed to list VolumeSnapshot PVCs in namespace %s (%w)",
+				namespaceName, err))
+
+			continue
+		}
+
+		for i := range volumeSnapshotPVCs.Items {
+			if err := v.volumeSnapshotPVCDelete(&volumeSnapshotPVCs.Items[i]); err != nil {
+				errs = append(errs, err)
+			}
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// volumeSnapshotProtectionDelete deletes the VolumeSnapshot PVCs, and the VolumeSnapshots archived to the S3
+// stores, once VolumeSnapshot protection is disabled
+func (v *VRGInstance) volumeSnapshotProtectionDelete() error {
+	if err := v.volumeSnapshotPVCsDelete(); err != nil {
+		return err
+	}
+
+	if err := v.volumeSnapshotsArchivePrune(nil); err != nil {
+		return err
+	}
+
+	v.instance.Status.ProtectedVolumeSnapshots = nil
+
+	return nil
+}
+
+// volumeSnapshotArchived returns the UID and generation of a VolumeSnapshot, as reported in the VRG status once it
+// is uploaded to the S3 stores
+func volumeSnapshotArchived(vs *snapv1.VolumeSnapshot) string {
+	return fmt.Sprintf("%s-%d", vs.UID, vs.Generation)
+}
+
+// volumeSnapshotArchivedBefore returns true if the VRG status reports a VolumeSnapshot uploaded to the S3 stores
+// since it last changed
+func (v *VRGInstance) volumeSnapshotArchivedBefore(vs *snapv1.VolumeSnapshot) bool {
+	for _, protectedVolumeSnapshot := range v.instance.Status.ProtectedVolumeSnapshots {
+		if protectedVolumeSnapshot.Namespace == vs.Namespace && protectedVolumeSnapshot.Name == vs.Name {
+			return protectedVolumeSnapshot.Archived == volumeSnapshotArchived(vs)
+		}
+	}
+
+	return false
+}
+
+// volumeSnapshotArchive uploads a VolumeSnapshot and its VolumeSnapshotContent to the VRG's S3 stores, unless they
+// were already uploaded since the VolumeSnapshot last changed. The VolumeSnapshot is left as is, and the upload is
+// tracked in the VRG status instead.
+func (v *VRGInstance) volumeSnapshotArchive(vs *snapv1.VolumeSnapshot) (string, string, error) {
+	if vs.Status.BoundVolumeSnapshotContentName == nil {
+		return "", "", fmt.Errorf("VolumeSnapshot %s not bound to a VolumeSnapshotContent", vs.Name)
+	}
+
+	vscName := *vs.Status.BoundVolumeSnapshotContentName
+	archived := volumeSnapshotArchived(vs)
+
+	if v.volumeSnapshotArchivedBefore(vs) {
+		return vscName, archived, nil
+	}
+
+	vsc := &snapv1.VolumeSnapshotContent{}
+	if err := v.reconciler.Get(v.ctx, types.NamespacedName{Name: vscName}, vsc); err != nil {
+		return vscName, "", fmt.Errorf("failed to get VolumeSnapshotContent %s (%w)", vscName, err)
+	}
+
+	vsNamespacedName := client.ObjectKeyFromObject(vs).String()
+
+	for _, s3ProfileName := range v.instance.Spec.S3Profiles {
+		objectStore, err := v.getObjectStorer(s3ProfileName)
+		if err != nil {
+			return vscName, "", fmt.Errorf("error getting object store %s (%w)", s3ProfileName, err)
+		}
+
+		if err := uploadTypedObject(objectStore, v.volumeSnapshotsS3KeyPrefix(), vscName, *vsc); err != nil {
+			return vscName, "", fmt.Errorf("error uploading VolumeSnapshotContent to s3Profile %s (%w)",
+				s3ProfileName, err)
+		}
+
+		if err := uploadTypedObject(objectStore, v.volumeSnapshotsS3KeyPrefix(), vsNamespacedName, *vs); err != nil {
+			return vscName, "", fmt.Errorf("error uploading VolumeSnapshot to s3Profile %s (%w)", s3ProfileName, err)
+		}
+	}
+
+	v.log.Info("Uploaded VolumeSnapshot", "VolumeSnapshot", vsNamespacedName, "VolumeSnapshotContent", vscName,
+		"profiles", v.instance.Spec.S3Profiles)
+
+	return vscName, archived, nil
+}
+
+// volumeSnapshotsArchivePrune deletes from the VRG's S3 stores the VolumeSnapshots, and their contents, that were
+// protected and no longer are
+func (v *VRGInstance) volumeSnapshotsArchivePrune(protectedVolumeSnapshots []ramendrv1alpha1.ProtectedVolumeSnapshot,
+) error {
+	protected := sets.New[types.NamespacedName]()
+	for _, protectedVolumeSnapshot := range protectedVolumeSnapshots {
+		protected.Insert(types.NamespacedName{
+			Namespace: protectedVolumeSnapshot.Namespace, Name: protectedVolumeSnapshot.Name,
+		})
+	}
+
+	for _, protectedVolumeSnapshot := range v.instance.Status.ProtectedVolumeSnapshots {
+		vsNamespacedName := types.NamespacedName{
+			Namespace: protectedVolumeSnapshot.Namespace, Name: protectedVolumeSnapshot.Name,
+		}
+
+		if protectedVolumeSnapshot.VolumeSnapshotContentName == "" || protected.Has(vsNamespacedName) {
+			continue
+		}
+
+		for _, s3ProfileName := range v.instance.Spec.S3Profiles {
+			objectStore, err := v.getObjectStorer(s3ProfileName)
+			if err != nil {
+				return fmt.Errorf("error getting object store %s (%w)", s3ProfileName, err)
+			}
+
+			if err := DeleteTypedObject(objectStore, v.volumeSnapshotsS3KeyPrefix(), vsNamespacedName.String(),
+				snapv1.VolumeSnapshot{}); err != nil {
+				return err
+			}
+
+			if err := DeleteTypedObject(objectStore, v.volumeSnapshotsS3KeyPrefix(),
+				protectedVolumeSnapshot.VolumeSnapshotContentName, snapv1.VolumeSnapshotContent{}); err != nil {
+				return err
+			}
+		}
+
+		v.log.Info("Deleted archived VolumeSnapshot", "VolumeSnapshot", vsNamespacedName.String())
+	}
+
+	return nil
+}
+
+// volumeSnapshotsRestore restores the VolumeSnapshots, and their contents, archived from a peer cluster, if the
+// storage replicates snapshots, as the snapshot handles of the contents must be valid on this cluster. Existing
+// VolumeSnapshots and contents are left as is.
+func (v *VRGInstance) volumeSnapshotsRestore() (int, error) {
+	if v.instance.Spec.VolumeSnapshotProtection == nil ||
+		!v.instance.Spec.VolumeSnapshotProtection.StorageReplicatesSnapshots {
+		return 0, nil
+	}
+
+	err := errors.New("s3Profiles empty")
+
+	for _, s3ProfileName := range v.instance.Spec.S3Profiles {
+		if s3ProfileName == NoS3StoreAvailable {
+			return 0, nil
+		}
+
+		var objectStore ObjectStorer
+
+		objectStore, err = v.getObjectStorer(s3ProfileName)
+		if err != nil {
+			continue
+		}
+
+		vscs := []snapv1.VolumeSnapshotContent{}
+		if err = DownloadTypedObjects(objectStore, v.volumeSnapshotsS3KeyPrefix(), &vscs); err != nil {
+			continue
+		}
+
+		vss := []snapv1.VolumeSnapshot{}
+		if err = DownloadTypedObjects(objectStore, v.volumeSnapshotsS3KeyPrefix(), &vss); err != nil {
+			continue
+		}
+
+		return v.volumeSnapshotsCreate(vscs, vss)
+	}
+
+	return 0, fmt.Errorf("failed to download VolumeSnapshots (%w)", err)
+}
+
+func (v *VRGInstance) volumeSnapshotsCreate(vscs []snapv1.VolumeSnapshotContent, vss []snapv1.VolumeSnapshot,
+) (int, error) {
+	numRestored := 0
+
+	for i := range vscs {
+		vsc := &vscs[i]
+		snapshotHandle := vsc.Status.SnapshotHandle
+
+		if snapshotHandle == nil {
+			snapshotHandle = vsc.Spec.Source.SnapshotHandle
+		}
+
+		if snapshotHandle == nil {
+			return numRestored, fmt.Errorf("VolumeSnapshotContent %s has no snapshot handle", vsc.Name)
+		}
+
+		vsc.ObjectMeta = metav1.ObjectMeta{
+			Name:        vsc.Name,
+			Labels:      vsc.Labels,
+			Annotations: PruneAnnotations(vsc.Annotations),
+		}
+		vsc.Spec.Source = snapv1.VolumeSnapshotContentSource{SnapshotHandle: snapshotHandle}
+		vsc.Spec.VolumeSnapshotRef = corev1.ObjectReference{
+			Namespace: vsc.Spec.VolumeSnapshotRef.Namespace,
+			Name:      vsc.Spec.VolumeSnapshotRef.Name,
+		}
+		vsc.Spec.DeletionPolicy = snapv1.VolumeSnapshotContentRetain
+		vsc.Status = nil
+		addRestoreAnnotation(vsc)
+
+		if err := v.reconciler.Create(v.ctx, vsc); err != nil && !k8serrors.IsAlreadyExists(err) {
+			return numRestored, fmt.Errorf("failed to restore VolumeSnapshotContent %s (%w)", vsc.Name, err)
+		}
+	}
+
+	for i := range vss {
+		vs := &vss[i]
+		vscName := vs.Status.BoundVolumeSnapshotContentName
+
+		vs.ObjectMeta = metav1.ObjectMeta{
+			Name:        vs.Name,
+			Namespace:   vs.Namespace,
+			Labels:      vs.Labels,
+			Annotations: PruneAnnotations(vs.Annotations),
+		}
+		vs.Spec.Source = snapv1.VolumeSnapshotSource{VolumeSnapshotContentName: vscName}
+		vs.Status = nil
+		addRestoreAnnotation(vs)
+
+		if err := v.reconciler.Create(v.ctx, vs); err != nil {
+			if k8serrors.IsAlreadyExists(err) {
+				continue
+			}
+
+			return numRestored, fmt.Errorf("failed to restore VolumeSnapshot %s/%s (%w)", vs.Namespace, vs.Name, err)
+		}
+
+		numRestored++
+	}
+
+	v.log.Info("Restored VolumeSnapshots", "count", numRestored)
+
+	return numRestored, nil
+}
@@ -0,0 +1,42 @@
+// SPDX-FileCopyrightText: The RamenDR authors
+// SPDX-License-Identifier: Apache-2.0
+
+// white box testing desired for VolumeSnapshot archive tracking
+package controllers //nolint: testpackage
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	snapv1 "github.com/kubernetes-csi/external-snapshotter/client/v4/apis/volumesnapshot/v1"
+	rmn "github.com/ramendr/ramen/api/v1alpha1"
+)
+
+var _ = Describe("VRG_VolumeSnapshotArchive", func() {
+	vs := &snapv1.VolumeSnapshot{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: "vs", UID: "uid", Generation: 1},
+	}
+
+	vrgInstance := func(archived string) *VRGInstance {
+		return &VRGInstance{instance: &rmn.VolumeReplicationGroup{
+			Status: rmn.VolumeReplicationGroupStatus{
+				ProtectedVolumeSnapshots: []rmn.ProtectedVolumeSnapshot{
+					{Namespace: vs.Namespace, Name: vs.Name, Archived: archived},
+				},
+			},
+		}}
+	}
+
+	It("skips the upload of a VolumeSnapshot unchanged since it was last uploaded", func() {
+		Expect(vrgInstance(volumeSnapshotArchived(vs)).volumeSnapshotArchivedBefore(vs)).To(BeTrue())
+	})
+
+	It("uploads a VolumeSnapshot changed, or recreated, since it was last uploaded", func() {
+		Expect(vrgInstance("uid-0").volumeSnapshotArchivedBefore(vs)).To(BeFalse())
+		Expect(vrgInstance("olduid-1").volumeSnapshotArchivedBefore(vs)).To(BeFalse())
+		Expect(vrgInstance("").volumeSnapshotArchivedBefore(vs)).To(BeFalse())
+		Expect((&VRGInstance{instance: &rmn.VolumeReplicationGroup{}}).volumeSnapshotArchivedBefore(vs)).To(BeFalse())
+	})
+})
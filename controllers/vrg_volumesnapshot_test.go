@@ -0,0 +1,196 @@
+// SPDX-FileCopyrightText: The RamenDR authors
+// SPDX-License-Identifier: Apache-2.0
+
+package controllers_test
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	volsyncv1alpha1 "github.com/backube/volsync/api/v1alpha1"
+	snapv1 "github.com/kubernetes-csi/external-snapshotter/client/v4/apis/volumesnapshot/v1"
+	ramendrv1alpha1 "github.com/ramendr/ramen/api/v1alpha1"
+	"github.com/ramendr/ramen/controllers"
+)
+
+var _ = Describe("VolumeReplicationGroupVolumeSnapshots", func() {
+	var testNamespace *corev1.Namespace
+	var testCtx context.Context
+	var cancel context.CancelFunc
+
+	testMatchLabels := map[string]string{
+		"ramentest": "backmysnapshotsup",
+	}
+
+	vsMatchLabels := map[string]string{
+		"ramentest": "protectme",
+	}
+
+	BeforeEach(func() {
+		testCtx, cancel = context.WithCancel(context.TODO())
+
+		testNamespace = &corev1.Namespace{
+			ObjectMeta: metav1.ObjectMeta{
+				GenerateName: "vs-",
+			},
+		}
+		Expect(k8sClient.Create(testCtx, testNamespace)).To(Succeed())
+		Expect(testNamespace.GetName()).NotTo(BeEmpty())
+	})
+
+	AfterEach(func() {
+		Expect(k8sClient.Delete(testCtx, testNamespace)).To(Succeed())
+
+		cancel()
+	})
+
+	Context("When a VolumeSnapshot of a PVC protected by VolSync is ready", func() {
+		var testVrg *ramendrv1alpha1.VolumeReplicationGroup
+		var vs *snapv1.VolumeSnapshot
+		var vsPVCName types.NamespacedName
+
+		JustBeforeEach(func() {
+			testVrg = &ramendrv1alpha1.VolumeReplicationGroup{
+				ObjectMeta: metav1.ObjectMeta{
+					GenerateName: "test-vrg-vs-",
+					Namespace:    testNamespace.GetName(),
+				},
+				Spec: ramendrv1alpha1.VolumeReplicationGroupSpec{
+					ReplicationState: ramendrv1alpha1.Primary,
+					Async: &ramendrv1alpha1.VRGAsyncSpec{
+						SchedulingInterval: "1h",
+					},
+					PVCSelector: metav1.LabelSelector{
+						MatchLabels: testMatchLabels,
+					},
+					S3Profiles: []string{s3Profiles[0].S3ProfileName},
+					VolSync:    ramendrv1alpha1.VolSyncSpec{},
+					VolumeSnapshotProtection: &ramendrv1alpha1.VolumeSnapshotProtectionSpec{
+						VolumeSnapshotSelector: &metav1.LabelSelector{MatchLabels: vsMatchLabels},
+					},
+				},
+			}
+			Expect(k8sClient.Create(testCtx, testVrg)).To(Succeed())
+
+			createSecret(testVrg.GetName(), testNamespace.Name)
+			createSC()
+			createVSC()
+
+			pvc := createPVCBoundToRunningPod(testCtx, testNamespace.GetName(), testMatchLabels, nil)
+
+			Eventually(func() int {
+				if err := k8sClient.Get(testCtx, client.ObjectKeyFromObject(testVrg), testVrg); err != nil {
+					return 0
+				}
+
+				return len(testVrg.Status.ProtectedPVCs)
+			}, testMaxWait, testInterval).Should(Equal(1))
+
+			vsClassName := testVolumeSnapshotClass
+			pvcName := pvc.GetName()
+			vs = &snapv1.VolumeSnapshot{
+				ObjectMeta: metav1.ObjectMeta{
+					GenerateName: "test-vs-",
+					Namespace:    testNamespace.GetName(),
+					Labels:       vsMatchLabels,
+				},
+				Spec: snapv1.VolumeSnapshotSpec{
+					Source:                  snapv1.VolumeSnapshotSource{PersistentVolumeClaimName: &pvcName},
+					VolumeSnapshotClassName: &vsClassName,
+				},
+			}
+			Expect(k8sClient.Create(testCtx, vs)).To(Succeed())
+
+			readyToUse := true
+			restoreSize := resource.MustParse("2Gi")
+			vs.Status = &snapv1.VolumeSnapshotStatus{ReadyToUse: &readyToUse, RestoreSize: &restoreSize}
+			Expect(k8sClient.Status().Update(testCtx, vs)).To(Succeed())
+
+			vsPVCName = types.NamespacedName{Namespace: vs.Namespace, Name: "ramen-vs-" + vs.Name}
+
+			Eventually(func() error {
+				return k8sClient.Get(testCtx, vsPVCName, &corev1.PersistentVolumeClaim{})
+			}, testMaxWait, testInterval).Should(Succeed())
+		})
+
+		It("protects it with a PVC restored from it, leaving the VolumeSnapshot as is", func() {
+			vsPVC := &corev1.PersistentVolumeClaim{}
+			Expect(k8sClient.Get(testCtx, vsPVCName, vsPVC)).To(Succeed())
+			Expect(vsPVC.GetLabels()).To(HaveKeyWithValue(controllers.VolumeSnapshotPVCLabel, vs.Name))
+			Expect(vsPVC.Spec.DataSource).NotTo(BeNil())
+			Expect(vsPVC.Spec.DataSource.Name).To(Equal(vs.Name))
+			Expect(vsPVC.Spec.Resources.Requests.Storage().String()).To(Equal("2Gi"))
+
+			Eventually(func() []ramendrv1alpha1.ProtectedVolumeSnapshot {
+				Expect(k8sClient.Get(testCtx, client.ObjectKeyFromObject(testVrg), testVrg)).To(Succeed())
+
+				return testVrg.Status.ProtectedVolumeSnapshots
+			}, testMaxWait, testInterval).Should(ContainElement(ramendrv1alpha1.ProtectedVolumeSnapshot{
+				Namespace: vs.Namespace, Name: vs.Name, PVCName: vsPVCName.Name,
+			}))
+
+			Expect(k8sClient.Get(testCtx, client.ObjectKeyFromObject(vs), vs)).To(Succeed())
+			Expect(vs.GetAnnotations()).To(BeEmpty())
+		})
+
+		It("replicates the PVC restored from it directly, without waiting for a pod to mount it", func() {
+			rs := &volsyncv1alpha1.ReplicationSource{}
+			Eventually(func() error {
+				return k8sClient.Get(testCtx, vsPVCName, rs)
+			}, testMaxWait, testInterval).Should(Succeed())
+
+			Expect(rs.Spec.SourcePVC).To(Equal(vsPVCName.Name))
+			Expect(rs.Spec.RsyncTLS).NotTo(BeNil())
+			Expect(rs.Spec.RsyncTLS.CopyMethod).To(Equal(volsyncv1alpha1.CopyMethodDirect))
+		})
+
+		It("deletes the PVC restored from it, and its replication, once it is no longer selected", func() {
+			Eventually(func() error {
+				return k8sClient.Get(testCtx, vsPVCName, &volsyncv1alpha1.ReplicationSource{})
+			}, testMaxWait, testInterval).Should(Succeed())
+
+			Expect(k8sClient.Get(testCtx, client.ObjectKeyFromObject(vs), vs)).To(Succeed())
+			vs.SetLabels(nil)
+			Expect(k8sClient.Update(testCtx, vs)).To(Succeed())
+
+			Eventually(func() bool {
+				vsPVC := &corev1.PersistentVolumeClaim{}
+				err := k8sClient.Get(testCtx, vsPVCName, vsPVC)
+
+				return k8serrors.IsNotFound(err) || err == nil && !vsPVC.GetDeletionTimestamp().IsZero()
+			}, testMaxWait, testInterval).Should(BeTrue())
+
+			Eventually(func() bool {
+				return k8serrors.IsNotFound(k8sClient.Get(testCtx, vsPVCName, &volsyncv1alpha1.ReplicationSource{}))
+			}, testMaxWait, testInterval).Should(BeTrue())
+		})
+
+		It("deletes the VolumeSnapshot PVC once VolumeSnapshot protection is disabled", func() {
+			Expect(k8sClient.Get(testCtx, client.ObjectKeyFromObject(testVrg), testVrg)).To(Succeed())
+			testVrg.Spec.VolumeSnapshotProtection = nil
+			Expect(k8sClient.Update(testCtx, testVrg)).To(Succeed())
+
+			Eventually(func() bool {
+				vsPVC := &corev1.PersistentVolumeClaim{}
+				err := k8sClient.Get(testCtx, vsPVCName, vsPVC)
+
+				return k8serrors.IsNotFound(err) || err == nil && !vsPVC.GetDeletionTimestamp().IsZero()
+			}, testMaxWait, testInterval).Should(BeTrue())
+
+			Eventually(func() []ramendrv1alpha1.ProtectedVolumeSnapshot {
+				Expect(k8sClient.Get(testCtx, client.ObjectKeyFromObject(testVrg), testVrg)).To(Succeed())
+
+				return testVrg.Status.ProtectedVolumeSnapshots
+			}, testMaxWait, testInterval).Should(BeEmpty())
+		})
+	})
+})
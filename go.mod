@@ -21,6 +21,7 @@ require (
 	github.com/prometheus/client_golang v1.16.0
 	github.com/ramendr/ramen/api v0.0.0-20240117171503-e11c56eac24d
 	github.com/ramendr/recipe v0.0.0-20230817160432-729dc7fd8932
+	github.com/robfig/cron/v3 v3.0.1
 	github.com/stolostron/multicloud-operators-foundation v0.0.0-20220824091202-e9cd9710d009
 	github.com/stolostron/multicloud-operators-placementrule v1.2.4-1-20220311-8eedb3f.0.20230828200208-cd3c119a7fa0
 	github.com/vmware-tanzu/velero v1.9.1
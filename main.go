@@ -166,6 +166,13 @@ func setupReconcilersCluster(mgr ctrl.Manager, ramenConfig *ramendrv1alpha1.Rame
 		setupLog.Error(err, "unable to create controller", "controller", "VolumeReplicationGroup")
 		os.Exit(1)
 	}
+
+	if os.Getenv("ENABLE_WEBHOOKS") == "true" {
+		if err := (&controllers.VolumeReplicationGroupWebhook{}).SetupWebhookWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create webhook", "webhook", "VolumeReplicationGroup")
+			os.Exit(1)
+		}
+	}
 }
 
 func setupReconcilersHub(mgr ctrl.Manager) {